@@ -0,0 +1,86 @@
+package voucher
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// AllowlistEntry allows a vulnerability to be ignored by vulnerability scans
+// until it expires. It applies to all images, unless it is scoped to images
+// in a repository or any repository beneath it, to an image digest, or to
+// both.
+type AllowlistEntry struct {
+	Vulnerability string    `json:"vulnerability"` // Name of the Vulnerability, or it's CVE number.
+	Repository    string    `json:"repository,omitempty"`
+	Digest        string    `json:"digest,omitempty"`
+	Reason        string    `json:"reason"`
+	Expires       time.Time `json:"expires"`
+}
+
+// Validate returns an error if the AllowlistEntry is missing any of its
+// required fields.
+func (entry *AllowlistEntry) Validate() error {
+	switch {
+	case "" == entry.Vulnerability:
+		return errors.New("allowlist entry must have a vulnerability")
+	case "" == entry.Reason:
+		return errors.New("allowlist entry must have a reason")
+	case entry.Expires.IsZero():
+		return errors.New("allowlist entry must have an expiry time")
+	}
+	return nil
+}
+
+// Applies returns true if the AllowlistEntry applies to the passed
+// Vulnerability in the passed image at the passed time. Entries stop applying
+// once they expire.
+func (entry *AllowlistEntry) Applies(vuln Vulnerability, imageData ImageData, now time.Time) bool {
+	if !strings.EqualFold(entry.Vulnerability, vuln.Name) || !now.Before(entry.Expires) {
+		return false
+	}
+
+	if "" != entry.Repository && !inRepository(imageData.Name(), entry.Repository) {
+		return false
+	}
+
+	if "" != entry.Digest && entry.Digest != imageData.Digest().String() {
+		return false
+	}
+
+	return true
+}
+
+// Allowlist is a list of vulnerabilities which should not cause an image to
+// fail a vulnerability scan.
+type Allowlist []AllowlistEntry
+
+// Suppress returns a copy of the passed Vulnerabilities, where each
+// Vulnerability that an entry in the Allowlist applies to is marked as
+// suppressed, along with the reason for the first entry that applies.
+func (allowlist Allowlist) Suppress(vulns []Vulnerability, imageData ImageData, now time.Time) []Vulnerability {
+	suppressed := make([]Vulnerability, len(vulns))
+	for i, vuln := range vulns {
+		for j := range allowlist {
+			if allowlist[j].Applies(vuln, imageData, now) {
+				vuln.Suppressed = true
+				vuln.SuppressedReason = allowlist[j].Reason
+				break
+			}
+		}
+		suppressed[i] = vuln
+	}
+	return suppressed
+}
+
+// UnsuppressedVulnerabilities returns the passed Vulnerabilities which have
+// not been suppressed.
+func UnsuppressedVulnerabilities(vulns []Vulnerability) []Vulnerability {
+	unsuppressed := make([]Vulnerability, 0, len(vulns))
+	for _, vuln := range vulns {
+		if !vuln.Suppressed {
+			unsuppressed = append(unsuppressed, vuln)
+		}
+	}
+	return unsuppressed
+}
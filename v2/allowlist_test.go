@@ -0,0 +1,93 @@
+package voucher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowlistEntryApplies(t *testing.T) {
+	imageData := newTestImageData(t)
+	now := time.Now()
+	vuln := Vulnerability{Name: "CVE-2020-1234", Severity: HighSeverity}
+
+	entry := AllowlistEntry{
+		Vulnerability: "cve-2020-1234",
+		Reason:        "not reachable",
+		Expires:       now.Add(time.Hour),
+	}
+	assert.True(t, entry.Applies(vuln, imageData, now), "global entry did not apply")
+	assert.False(t, entry.Applies(Vulnerability{Name: "CVE-2020-9999"}, imageData, now))
+	assert.False(t, entry.Applies(vuln, imageData, now.Add(time.Hour)), "entry applied after it expired")
+
+	entry.Repository = "localhost.local/path/to"
+	assert.True(t, entry.Applies(vuln, imageData, now))
+
+	entry.Repository = "localhost.local/other"
+	assert.False(t, entry.Applies(vuln, imageData, now))
+
+	entry.Repository = "localhost.local/path/to/image"
+	assert.True(t, entry.Applies(vuln, imageData, now))
+
+	entry.Repository = "localhost.local/path/to/ima"
+	assert.False(t, entry.Applies(vuln, imageData, now), "entry applied to a sibling repository")
+
+	entry.Repository = ""
+	entry.Digest = imageData.Digest().String()
+	assert.True(t, entry.Applies(vuln, imageData, now))
+
+	entry.Digest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	assert.False(t, entry.Applies(vuln, imageData, now))
+}
+
+func TestAllowlistEntryValidate(t *testing.T) {
+	entry := AllowlistEntry{
+		Vulnerability: "CVE-2020-1234",
+		Reason:        "not reachable",
+		Expires:       time.Now().Add(time.Hour),
+	}
+	assert.NoError(t, entry.Validate())
+
+	for _, invalidate := range []func(*AllowlistEntry){
+		func(e *AllowlistEntry) { e.Vulnerability = "" },
+		func(e *AllowlistEntry) { e.Reason = "" },
+		func(e *AllowlistEntry) { e.Expires = time.Time{} },
+	} {
+		invalid := entry
+		invalidate(&invalid)
+		assert.Error(t, invalid.Validate())
+	}
+}
+
+func TestAllowlistSuppress(t *testing.T) {
+	imageData := newTestImageData(t)
+	now := time.Now()
+	vulns := makeTestVulns()
+
+	allowlist := Allowlist{
+		{
+			Vulnerability: "Bad One",
+			Reason:        "not reachable",
+			Expires:       now.Add(time.Hour),
+		},
+		{
+			Vulnerability: "The Rare One",
+			Reason:        "expired",
+			Expires:       now.Add(-time.Hour),
+		},
+	}
+
+	suppressed := allowlist.Suppress(vulns, imageData, now)
+	assert.True(t, suppressed[0].Suppressed)
+	assert.Equal(t, "not reachable", suppressed[0].SuppressedReason)
+	assert.False(t, suppressed[1].Suppressed)
+	assert.False(t, suppressed[2].Suppressed)
+	assert.False(t, vulns[0].Suppressed, "Suppress modified the passed vulnerabilities")
+
+	assert.Equal(t, suppressed[1:], UnsuppressedVulnerabilities(suppressed))
+
+	err := NewVulnerabilityError(suppressed)
+	assert.Equal(t, "vulnernable to 2 vulnerabilities: Mediocre One (medium), The Rare One (critical)", err.Error())
+	assert.Len(t, ErrorDetailsOf(err).(VulnerabilitiesDetails).Vulnerabilities, 3)
+}
@@ -0,0 +1,11 @@
+package voucher
+
+import "github.com/grafeas/voucher/v2/signer"
+
+// AttestationVerifierCheck represents a Voucher check which relies on the
+// attestations of other images, and verifies their signatures before it
+// trusts them.
+type AttestationVerifierCheck interface {
+	Check
+	SetAttestationVerifier(signer.AttestationVerifier)
+}
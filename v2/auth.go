@@ -2,7 +2,6 @@ package voucher
 
 import (
 	"context"
-	"errors"
 	"net/http"
 
 	"github.com/docker/distribution/reference"
@@ -11,7 +10,7 @@ import (
 
 // ErrNoAuth should be returned when something that depends on an Auth does not
 // have one.
-var ErrNoAuth = errors.New("no configured Auth")
+var ErrNoAuth = NewCheckError(ErrorCodeNoAuth, "no configured Auth")
 
 // Auth is an interface that wraps an to an OAuth2 system, to simplify the path
 // from having an image reference to getting access to the data that makes up
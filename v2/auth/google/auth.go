@@ -11,6 +11,7 @@ import (
 
 	voucher "github.com/grafeas/voucher/v2"
 	"github.com/grafeas/voucher/v2/auth"
+	"github.com/grafeas/voucher/v2/docker/uri"
 )
 
 const gcrScope = "https://www.googleapis.com/auth/cloud-platform"
@@ -49,9 +50,11 @@ func (a *gAuth) ToClient(ctx context.Context, image reference.Named) (*http.Clie
 	return client, err
 }
 
-// IsForDomain validates the domain part of the Named image reference
+// IsForDomain validates the domain part of the Named image reference, which
+// must be a Container Registry host (such as "gcr.io" or "eu.gcr.io") or an
+// Artifact Registry host (such as "us-docker.pkg.dev").
 func (a *gAuth) IsForDomain(image reference.Named) bool {
-	return "gcr.io" == reference.Domain(image)
+	return uri.IsGoogleDomain(reference.Domain(image))
 }
 
 // NewAuth returns a new voucher.Auth to access Google specific resources.
@@ -0,0 +1,198 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/docker/distribution/reference"
+	"golang.org/x/oauth2"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/auth"
+	"github.com/grafeas/voucher/v2/docker/uri"
+)
+
+// registryAuth is a voucher.Auth for registries which implement the Docker
+// registry API. It answers the challenge that the registry responds to
+// unauthenticated requests with, using Credentials from its Keychain.
+type registryAuth struct {
+	keychain Keychain
+	domains  []string
+	client   *http.Client
+
+	challengesMu sync.Mutex
+	challenges   map[string]challenge
+}
+
+// GetTokenSource returns an oauth2.TokenSource for pulling the passed image
+// from its registry. It returns an error if the registry does not require
+// authentication, as there is no token to get.
+func (a *registryAuth) GetTokenSource(ctx context.Context, image reference.Named) (oauth2.TokenSource, error) {
+	source, err := a.tokenSource(ctx, image)
+	if nil == err && nil == source {
+		err = auth.NewAuthError("registry does not use token authentication", image)
+	}
+
+	return source, err
+}
+
+// ToClient returns a new http.Client which authenticates with the passed
+// image's registry. The registry's token is only sent to its own host, and
+// not to the storage that it redirects blob requests to.
+func (a *registryAuth) ToClient(ctx context.Context, image reference.Named) (*http.Client, error) {
+	if !a.IsForDomain(image) {
+		return nil, auth.NewAuthError("does not match domain", image)
+	}
+
+	source, err := a.tokenSource(ctx, image)
+	if nil != err {
+		return nil, err
+	}
+
+	if nil == source {
+		return &http.Client{Transport: a.client.Transport}, nil
+	}
+
+	return &http.Client{
+		Transport: &hostTransport{
+			host:   uri.RegistryHost(reference.Domain(image)),
+			source: source,
+			base:   a.client.Transport,
+		},
+	}, nil
+}
+
+// IsForDomain returns true if the passed image is in one of the registries
+// that the Auth is for, or if it was not limited to any registries.
+func (a *registryAuth) IsForDomain(image reference.Named) bool {
+	if 0 == len(a.domains) {
+		return true
+	}
+
+	domain := reference.Domain(image)
+	for _, d := range a.domains {
+		if normalizeRegistry(d) == domain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tokenSource returns an oauth2.TokenSource for pulling the passed image, or
+// nil if its registry does not require authentication.
+func (a *registryAuth) tokenSource(ctx context.Context, image reference.Named) (oauth2.TokenSource, error) {
+	domain := reference.Domain(image)
+
+	c, err := a.getChallenge(ctx, domain)
+	if nil != err {
+		return nil, err
+	}
+
+	if "" == c.scheme {
+		return nil, nil
+	}
+
+	var credentials Credentials
+	if nil != a.keychain {
+		credentials, _, err = a.keychain.Credentials(ctx, domain)
+		if nil != err {
+			return nil, fmt.Errorf("failed to get credentials for %s: %s", domain, err)
+		}
+	}
+
+	return oauth2.ReuseTokenSource(nil, &tokenSource{
+		ctx:         ctx,
+		client:      a.client,
+		challenge:   c,
+		credentials: credentials,
+		scope:       pullScope(reference.Path(image)),
+	}), nil
+}
+
+// getChallenge returns the authentication challenge of the registry with the
+// passed domain, requesting its API root if it has not been seen before.
+func (a *registryAuth) getChallenge(ctx context.Context, domain string) (challenge, error) {
+	a.challengesMu.Lock()
+	c, ok := a.challenges[domain]
+	a.challengesMu.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://"+uri.RegistryHost(domain)+"/v2/", nil)
+	if nil != err {
+		return c, err
+	}
+
+	resp, err := a.client.Do(req.WithContext(ctx))
+	if nil != err {
+		return c, fmt.Errorf("failed to connect to registry %s: %s", domain, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		c = challenge{}
+	case http.StatusUnauthorized:
+		c = parseChallenge(resp.Header.Get("WWW-Authenticate"))
+		if basicScheme != c.scheme && bearerScheme != c.scheme {
+			return c, fmt.Errorf("registry %s uses unsupported authentication \"%s\"", domain, c.scheme)
+		}
+	default:
+		return c, fmt.Errorf("failed to connect to registry %s: %s", domain, resp.Status)
+	}
+
+	a.challengesMu.Lock()
+	a.challenges[domain] = c
+	a.challengesMu.Unlock()
+
+	return c, nil
+}
+
+// hostTransport is an http.RoundTripper which authenticates requests to the
+// registry's host with tokens from its TokenSource. Requests to other hosts,
+// such as the storage that blobs are redirected to, are sent without them.
+type hostTransport struct {
+	host   string
+	source oauth2.TokenSource
+	base   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != t.host {
+		return t.base.RoundTrip(req)
+	}
+
+	token, err := t.source.Token()
+	if nil != err {
+		if nil != req.Body {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	authReq := req.Clone(req.Context())
+	token.SetAuthHeader(authReq)
+
+	return t.base.RoundTrip(authReq)
+}
+
+// NewAuth returns a new voucher.Auth for registries which implement the Docker
+// registry API, such as Docker Hub, GitHub Container Registry, or Harbor. It
+// gets Credentials for them from the passed Keychain, which may be nil to
+// only access registries anonymously. If any domains are passed, the Auth is
+// only for images in those registries.
+func NewAuth(keychain Keychain, domains ...string) voucher.Auth {
+	return &registryAuth{
+		keychain: keychain,
+		domains:  domains,
+		client: &http.Client{
+			Transport: auth.DefaultTransport,
+		},
+		challenges: make(map[string]challenge),
+	}
+}
@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"strings"
+)
+
+// Authentication schemes that registries challenge clients with.
+const (
+	basicScheme  = "basic"
+	bearerScheme = "bearer"
+)
+
+// challenge is the authentication challenge in the WWW-Authenticate header
+// that a registry responds to unauthenticated requests with, such as:
+//
+//     Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+//
+// The scheme is lowercase. A registry which does not require authentication
+// has a challenge with no scheme.
+type challenge struct {
+	scheme string
+	params map[string]string
+}
+
+// parseChallenge parses the passed WWW-Authenticate header.
+func parseChallenge(header string) challenge {
+	header = strings.TrimSpace(header)
+
+	c := challenge{
+		params: make(map[string]string),
+	}
+
+	i := strings.IndexAny(header, " \t")
+	if -1 == i {
+		c.scheme = strings.ToLower(header)
+		return c
+	}
+
+	c.scheme = strings.ToLower(header[:i])
+	rest := header[i:]
+
+	for {
+		rest = strings.TrimLeft(rest, " \t,")
+		eq := strings.Index(rest, "=")
+		if -1 == eq {
+			return c
+		}
+
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		var value string
+		value, rest = parseParamValue(rest[eq+1:])
+		c.params[key] = value
+	}
+}
+
+// parseParamValue returns the value at the start of the passed string, which
+// is either a quoted string or a token ending at the next comma, and the rest
+// of the string after it.
+func parseParamValue(s string) (string, string) {
+	s = strings.TrimLeft(s, " \t")
+	if !strings.HasPrefix(s, `"`) {
+		end := strings.Index(s, ",")
+		if -1 == end {
+			return strings.TrimSpace(s), ""
+		}
+		return strings.TrimSpace(s[:end]), s[end:]
+	}
+
+	var value strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				value.WriteByte(s[i])
+			}
+		case '"':
+			return value.String(), s[i+1:]
+		default:
+			value.WriteByte(s[i])
+		}
+	}
+
+	return value.String(), ""
+}
@@ -0,0 +1,58 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChallenge(t *testing.T) {
+	cases := []struct {
+		header string
+		scheme string
+		params map[string]string
+	}{
+		{
+			header: `Bearer realm="https://auth.docker.io/token",service="registry.docker.io"`,
+			scheme: bearerScheme,
+			params: map[string]string{
+				"realm":   "https://auth.docker.io/token",
+				"service": "registry.docker.io",
+			},
+		},
+		{
+			header: `Bearer realm="https://ghcr.io/token", service="ghcr.io", scope="repository:user/image:pull"`,
+			scheme: bearerScheme,
+			params: map[string]string{
+				"realm":   "https://ghcr.io/token",
+				"service": "ghcr.io",
+				"scope":   "repository:user/image:pull",
+			},
+		},
+		{
+			header: `Basic realm="Harbor \"registry\""`,
+			scheme: basicScheme,
+			params: map[string]string{
+				"realm": `Harbor "registry"`,
+			},
+		},
+		{
+			header: `BASIC realm=registry`,
+			scheme: basicScheme,
+			params: map[string]string{
+				"realm": "registry",
+			},
+		},
+		{
+			header: "Basic",
+			scheme: basicScheme,
+			params: map[string]string{},
+		},
+	}
+
+	for _, testCase := range cases {
+		c := parseChallenge(testCase.header)
+		assert.Equal(t, testCase.scheme, c.scheme, testCase.header)
+		assert.Equal(t, testCase.params, c.params, testCase.header)
+	}
+}
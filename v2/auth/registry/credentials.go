@@ -0,0 +1,92 @@
+package registry
+
+import (
+	"context"
+	"strings"
+)
+
+// dockerHubDomain is the domain of images in Docker Hub.
+const dockerHubDomain = "docker.io"
+
+// dockerHubServerURL is the name that Docker uses for Docker Hub in its
+// config.json and when calling credential helpers.
+const dockerHubServerURL = "https://index.docker.io/v1/"
+
+// Credentials are the credentials used to log in to a registry. If
+// IdentityToken is set, it is exchanged for registry tokens instead of the
+// Password.
+type Credentials struct {
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+}
+
+// Anonymous returns true if the Credentials are empty, in which case the
+// registry is accessed without logging in.
+func (c Credentials) Anonymous() bool {
+	return "" == c.Username && "" == c.Password && "" == c.IdentityToken
+}
+
+// Keychain looks up the Credentials for registries.
+type Keychain interface {
+	// Credentials returns the Credentials for the registry with the passed
+	// domain, and false if the Keychain has none for it.
+	Credentials(ctx context.Context, domain string) (Credentials, bool, error)
+}
+
+// StaticKeychain is a Keychain with fixed Credentials for each registry, such
+// as those in the ejson secrets. Registries can be named by their domain
+// ("ghcr.io") or by a URL ("https://ghcr.io").
+type StaticKeychain map[string]Credentials
+
+// Credentials returns the Credentials for the registry with the passed domain.
+func (keychain StaticKeychain) Credentials(ctx context.Context, domain string) (Credentials, bool, error) {
+	for name, credentials := range keychain {
+		if normalizeRegistry(name) == domain {
+			return credentials, true, nil
+		}
+	}
+
+	return Credentials{}, false, nil
+}
+
+// MultiKeychain is a Keychain which returns the Credentials from the first of
+// its Keychains that has them.
+type MultiKeychain []Keychain
+
+// Credentials returns the Credentials for the registry with the passed domain.
+func (keychains MultiKeychain) Credentials(ctx context.Context, domain string) (Credentials, bool, error) {
+	for _, keychain := range keychains {
+		credentials, ok, err := keychain.Credentials(ctx, domain)
+		if nil != err || ok {
+			return credentials, ok, err
+		}
+	}
+
+	return Credentials{}, false, nil
+}
+
+// normalizeRegistry converts the passed registry name, which may be a URL such
+// as "https://index.docker.io/v1/", to the domain that its images use.
+func normalizeRegistry(name string) string {
+	name = strings.TrimPrefix(name, "https://")
+	name = strings.TrimPrefix(name, "http://")
+	if i := strings.Index(name, "/"); -1 != i {
+		name = name[:i]
+	}
+
+	switch name {
+	case "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		return dockerHubDomain
+	}
+	return name
+}
+
+// serverURL returns the name of the registry with the passed domain that Docker
+// passes to credential helpers.
+func serverURL(domain string) string {
+	if dockerHubDomain == domain {
+		return dockerHubServerURL
+	}
+	return domain
+}
@@ -0,0 +1,139 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dockerConfig is the part of Docker's config.json which holds the
+// credentials for registries.
+type dockerConfig struct {
+	Auths       map[string]dockerConfigAuth `json:"auths"`
+	CredsStore  string                      `json:"credsStore"`
+	CredHelpers map[string]string           `json:"credHelpers"`
+}
+
+// dockerConfigAuth is an entry in the "auths" of Docker's config.json. Auth is
+// the base64 encoded "username:password".
+type dockerConfigAuth struct {
+	Auth          string `json:"auth"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	IdentityToken string `json:"identitytoken"`
+}
+
+// DockerConfigKeychain is a Keychain which reads Credentials from a Docker
+// config.json, as written by "docker login". Registries that are configured
+// to use a credential helper get their Credentials from it.
+type DockerConfigKeychain struct {
+	path string
+}
+
+// NewDockerConfigKeychain creates a new DockerConfigKeychain which reads the
+// config.json at the passed path. The file is read on each lookup, so that
+// Credentials which are refreshed by "docker login" are picked up.
+func NewDockerConfigKeychain(path string) *DockerConfigKeychain {
+	return &DockerConfigKeychain{
+		path: path,
+	}
+}
+
+// DefaultDockerConfigPath returns the path to the config.json that Docker
+// uses, which is in $DOCKER_CONFIG, or in ~/.docker if that is not set.
+func DefaultDockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); "" != dir {
+		return filepath.Join(dir, "config.json")
+	}
+
+	home, err := os.UserHomeDir()
+	if nil != err {
+		return ""
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+// Credentials returns the Credentials for the registry with the passed domain.
+// It returns false if the config.json does not exist.
+func (keychain *DockerConfigKeychain) Credentials(ctx context.Context, domain string) (Credentials, bool, error) {
+	config, err := keychain.read()
+	if nil != err || nil == config {
+		return Credentials{}, false, err
+	}
+
+	helper := config.CredsStore
+	for name, registryHelper := range config.CredHelpers {
+		if normalizeRegistry(name) == domain {
+			helper = registryHelper
+		}
+	}
+
+	if "" != helper {
+		credentials, ok, err := helperCredentials(ctx, helper, serverURL(domain))
+		if nil != err || ok {
+			return credentials, ok, err
+		}
+	}
+
+	for name, auth := range config.Auths {
+		if normalizeRegistry(name) == domain {
+			return auth.credentials()
+		}
+	}
+
+	return Credentials{}, false, nil
+}
+
+// read reads and parses the config.json, returning nil if it does not exist.
+func (keychain *DockerConfigKeychain) read() (*dockerConfig, error) {
+	if "" == keychain.path {
+		return nil, nil
+	}
+
+	raw, err := ioutil.ReadFile(keychain.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if nil != err {
+		return nil, fmt.Errorf("failed to read Docker config: %s", err)
+	}
+
+	config := new(dockerConfig)
+	if err = json.Unmarshal(raw, config); nil != err {
+		return nil, fmt.Errorf("failed to parse Docker config %s: %s", keychain.path, err)
+	}
+
+	return config, nil
+}
+
+// credentials converts the dockerConfigAuth to Credentials, decoding its Auth
+// if it has one. It returns false if the entry is empty, as it is when the
+// credentials are kept in a credential helper.
+func (auth dockerConfigAuth) credentials() (Credentials, bool, error) {
+	credentials := Credentials{
+		Username:      auth.Username,
+		Password:      auth.Password,
+		IdentityToken: auth.IdentityToken,
+	}
+
+	if "" != auth.Auth {
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if nil != err {
+			return Credentials{}, false, fmt.Errorf("failed to decode Docker config auth: %s", err)
+		}
+
+		parts := strings.SplitN(string(decoded), ":", 2)
+		if 2 != len(parts) {
+			return Credentials{}, false, fmt.Errorf("Docker config auth is not in the form \"username:password\"")
+		}
+		credentials.Username = parts[0]
+		credentials.Password = parts[1]
+	}
+
+	return credentials, !credentials.Anonymous(), nil
+}
@@ -0,0 +1,126 @@
+package registry
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDockerConfig = `{
+	"auths": {
+		"https://index.docker.io/v1/": {
+			"auth": "aHViLXVzZXI6aHViLXBhc3N3b3Jk"
+		},
+		"registry.example.com": {
+			"identitytoken": "example-token"
+		},
+		"ghcr.io": {}
+	},
+	"credHelpers": {
+		"ghcr.io": "voucher-test"
+	}
+}`
+
+// testHelper is a credential helper which has credentials for ghcr.io, and
+// none for any other server.
+const testHelper = `#!/bin/sh
+read server
+if [ "$server" = "ghcr.io" ]; then
+	echo '{"ServerURL":"ghcr.io","Username":"helper-user","Secret":"helper-secret"}'
+	exit 0
+fi
+echo "credentials not found in native keychain"
+exit 1
+`
+
+// prepareDockerConfig writes a Docker config.json and a credential helper to a
+// temporary directory, and adds it to the PATH. It returns the config's path,
+// and a function which cleans up.
+func prepareDockerConfig(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "voucher-docker-config")
+	require.NoError(t, err)
+
+	configPath := filepath.Join(dir, "config.json")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(testDockerConfig), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "docker-credential-voucher-test"), []byte(testHelper), 0700))
+
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+
+	return configPath, func() {
+		os.Setenv("PATH", path)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestDockerConfigKeychain(t *testing.T) {
+	configPath, cleanup := prepareDockerConfig(t)
+	defer cleanup()
+
+	keychain := NewDockerConfigKeychain(configPath)
+	ctx := context.Background()
+
+	credentials, ok, err := keychain.Credentials(ctx, "docker.io")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Credentials{Username: "hub-user", Password: "hub-password"}, credentials)
+
+	credentials, ok, err = keychain.Credentials(ctx, "registry.example.com")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Credentials{IdentityToken: "example-token"}, credentials)
+
+	credentials, ok, err = keychain.Credentials(ctx, "ghcr.io")
+	require.NoError(t, err)
+	assert.True(t, ok, "credentials should come from the credential helper")
+	assert.Equal(t, Credentials{Username: "helper-user", Password: "helper-secret"}, credentials)
+
+	_, ok, err = keychain.Credentials(ctx, "quay.io")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestDockerConfigKeychainWithMissingHelper(t *testing.T) {
+	dir, err := ioutil.TempDir("", "voucher-docker-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "config.json")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`{"credsStore": "voucher-missing"}`), 0600))
+
+	_, _, err = NewDockerConfigKeychain(configPath).Credentials(context.Background(), "ghcr.io")
+	assert.Error(t, err, "a helper that cannot be run should be an error")
+}
+
+func TestDockerConfigKeychainWithNoConfig(t *testing.T) {
+	_, ok, err := NewDockerConfigKeychain("/does/not/exist/config.json").Credentials(context.Background(), "docker.io")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestMultiKeychain(t *testing.T) {
+	configPath, cleanup := prepareDockerConfig(t)
+	defer cleanup()
+
+	keychain := MultiKeychain{
+		StaticKeychain{
+			"https://index.docker.io/v1/": {Username: "secret-user", Password: "secret-password"},
+		},
+		NewDockerConfigKeychain(configPath),
+	}
+
+	credentials, ok, err := keychain.Credentials(context.Background(), "docker.io")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "secret-user", credentials.Username, "the first keychain should take precedence")
+
+	credentials, ok, err = keychain.Credentials(context.Background(), "registry.example.com")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "example-token", credentials.IdentityToken)
+}
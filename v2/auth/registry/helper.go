@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// helperTokenUsername is the username that credential helpers return when
+// their Secret is an identity token rather than a password.
+const helperTokenUsername = "<token>"
+
+// helperResponse is the output of a credential helper's "get" command.
+type helperResponse struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// helperCredentials runs the Docker credential helper with the passed name
+// (such as "gcloud", which runs "docker-credential-gcloud") to get the
+// Credentials for the passed server URL. It returns false if the helper has
+// no Credentials for the server.
+func helperCredentials(ctx context.Context, helper, serverURL string) (Credentials, bool, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(serverURL)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); nil != err {
+		output := strings.TrimSpace(stdout.String() + stderr.String())
+		if strings.Contains(output, "credentials not found") {
+			return Credentials{}, false, nil
+		}
+		return Credentials{}, false, fmt.Errorf("credential helper %s failed: %s: %s", helper, err, output)
+	}
+
+	var response helperResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); nil != err {
+		return Credentials{}, false, fmt.Errorf("failed to parse output of credential helper %s: %s", helper, err)
+	}
+
+	if helperTokenUsername == response.Username {
+		return Credentials{IdentityToken: response.Secret}, true, nil
+	}
+
+	credentials := Credentials{
+		Username: response.Username,
+		Password: response.Secret,
+	}
+	return credentials, !credentials.Anonymous(), nil
+}
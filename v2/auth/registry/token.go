@@ -0,0 +1,146 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// defaultTokenLifetime is how long registry tokens are valid for when the
+// token server does not say, as per the Docker registry token specification.
+const defaultTokenLifetime = 60 * time.Second
+
+// tokenClientID is the client ID that Voucher identifies itself with when
+// exchanging identity tokens.
+const tokenClientID = "voucher"
+
+// tokenResponse is the response of a registry's token server. Older servers
+// return the token as "token", and newer ones as "access_token".
+type tokenResponse struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// tokenSource is an oauth2.TokenSource which gets tokens to pull a repository
+// from a registry, by answering the registry's challenge with Credentials.
+type tokenSource struct {
+	ctx         context.Context
+	client      *http.Client
+	challenge   challenge
+	credentials Credentials
+	scope       string
+}
+
+// Token returns a token for the registry. For registries that use Basic
+// authentication, this is the encoded Credentials. For registries that use
+// Bearer authentication, it is requested from the challenge's realm.
+func (source *tokenSource) Token() (*oauth2.Token, error) {
+	switch source.challenge.scheme {
+	case basicScheme:
+		return source.basicToken()
+	case bearerScheme:
+		return source.bearerToken()
+	}
+
+	return nil, fmt.Errorf("unsupported registry authentication scheme \"%s\"", source.challenge.scheme)
+}
+
+// basicToken returns an oauth2.Token which sets the Credentials as Basic
+// authentication. It does not expire.
+func (source *tokenSource) basicToken() (*oauth2.Token, error) {
+	if "" == source.credentials.Username {
+		return nil, fmt.Errorf("registry requires a username and password, but none are configured")
+	}
+
+	userPass := source.credentials.Username + ":" + source.credentials.Password
+
+	return &oauth2.Token{
+		AccessToken: base64.StdEncoding.EncodeToString([]byte(userPass)),
+		TokenType:   "Basic",
+	}, nil
+}
+
+// bearerToken requests a token from the challenge's realm. Identity tokens are
+// exchanged with a POST, as OAuth2 refresh tokens, while usernames and
+// passwords are sent with a GET, as Basic authentication. Without Credentials,
+// an anonymous token is requested.
+func (source *tokenSource) bearerToken() (*oauth2.Token, error) {
+	realm := source.challenge.params["realm"]
+	if "" == realm {
+		return nil, fmt.Errorf("registry challenge has no realm to request a token from")
+	}
+
+	values := url.Values{}
+	if service := source.challenge.params["service"]; "" != service {
+		values.Set("service", service)
+	}
+	values.Set("scope", source.scope)
+
+	var req *http.Request
+	var err error
+
+	if "" != source.credentials.IdentityToken {
+		values.Set("grant_type", "refresh_token")
+		values.Set("refresh_token", source.credentials.IdentityToken)
+		values.Set("client_id", tokenClientID)
+
+		req, err = http.NewRequest(http.MethodPost, realm, strings.NewReader(values.Encode()))
+		if nil == err {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		req, err = http.NewRequest(http.MethodGet, realm+"?"+values.Encode(), nil)
+		if nil == err && "" != source.credentials.Username {
+			req.SetBasicAuth(source.credentials.Username, source.credentials.Password)
+		}
+	}
+	if nil != err {
+		return nil, err
+	}
+
+	resp, err := source.client.Do(req.WithContext(source.ctx))
+	if nil != err {
+		return nil, fmt.Errorf("failed to request registry token: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if http.StatusOK != resp.StatusCode {
+		return nil, fmt.Errorf("failed to request registry token from %s: %s", realm, resp.Status)
+	}
+
+	var response tokenResponse
+	if err = json.NewDecoder(resp.Body).Decode(&response); nil != err {
+		return nil, fmt.Errorf("failed to parse registry token: %s", err)
+	}
+
+	token := &oauth2.Token{
+		AccessToken: response.AccessToken,
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(defaultTokenLifetime),
+	}
+	if "" == token.AccessToken {
+		token.AccessToken = response.Token
+	}
+	if "" == token.AccessToken {
+		return nil, fmt.Errorf("registry token server %s returned no token", realm)
+	}
+	if 0 < response.ExpiresIn {
+		token.Expiry = time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// pullScope returns the scope of a token which can pull the repository with
+// the passed path.
+func pullScope(path string) string {
+	return "repository:" + path + ":pull"
+}
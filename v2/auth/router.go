@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/docker/distribution/reference"
+	"golang.org/x/oauth2"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// Router is a voucher.Auth which passes each image to the first of its Auths
+// that is for the image's domain. This allows images in different registries
+// to be accessed with different kinds of authentication.
+type Router struct {
+	auths []voucher.Auth
+}
+
+// GetTokenSource returns the oauth2.TokenSource of the Auth for the passed
+// image's domain.
+func (r *Router) GetTokenSource(ctx context.Context, image reference.Named) (oauth2.TokenSource, error) {
+	a := r.authFor(image)
+	if nil == a {
+		return nil, NewAuthError("does not match domain", image)
+	}
+
+	return a.GetTokenSource(ctx, image)
+}
+
+// ToClient returns the http.Client of the Auth for the passed image's domain.
+func (r *Router) ToClient(ctx context.Context, image reference.Named) (*http.Client, error) {
+	a := r.authFor(image)
+	if nil == a {
+		return nil, NewAuthError("does not match domain", image)
+	}
+
+	return a.ToClient(ctx, image)
+}
+
+// IsForDomain returns true if any of the Router's Auths are for the passed
+// image's domain.
+func (r *Router) IsForDomain(image reference.Named) bool {
+	return nil != r.authFor(image)
+}
+
+// authFor returns the first Auth which is for the passed image's domain, or
+// nil if there is none.
+func (r *Router) authFor(image reference.Named) voucher.Auth {
+	for _, a := range r.auths {
+		if a.IsForDomain(image) {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// NewRouter creates a new Router which routes images to the passed Auths.
+// Auths are tried in order, so more specific Auths should be passed before
+// ones which accept any domain.
+func NewRouter(auths ...voucher.Auth) *Router {
+	return &Router{
+		auths: auths,
+	}
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/docker/distribution/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+var errTestAuth = errors.New("test auth")
+
+// domainAuth is a voucher.Auth for a single domain, which returns a client
+// with the domain as its Transport's name so tests can tell Auths apart.
+type domainAuth struct {
+	domain string
+}
+
+type namedTransport struct {
+	http.RoundTripper
+	name string
+}
+
+func (a *domainAuth) GetTokenSource(ctx context.Context, image reference.Named) (oauth2.TokenSource, error) {
+	return nil, errTestAuth
+}
+
+func (a *domainAuth) ToClient(ctx context.Context, image reference.Named) (*http.Client, error) {
+	return &http.Client{Transport: &namedTransport{name: a.domain}}, nil
+}
+
+func (a *domainAuth) IsForDomain(image reference.Named) bool {
+	return "" == a.domain || a.domain == reference.Domain(image)
+}
+
+func TestRouter(t *testing.T) {
+	router := NewRouter(&domainAuth{domain: "gcr.io"}, &domainAuth{domain: "ghcr.io"})
+
+	for _, domain := range []string{"gcr.io", "ghcr.io"} {
+		image, err := reference.ParseNamed(domain + "/path/to/image")
+		require.NoError(t, err)
+
+		assert.True(t, router.IsForDomain(image))
+
+		client, err := router.ToClient(context.Background(), image)
+		require.NoError(t, err)
+		assert.Equal(t, domain, client.Transport.(*namedTransport).name)
+
+		_, err = router.GetTokenSource(context.Background(), image)
+		assert.Equal(t, errTestAuth, err)
+	}
+
+	image, err := reference.ParseNamed("quay.io/path/to/image")
+	require.NoError(t, err)
+
+	assert.False(t, router.IsForDomain(image))
+
+	_, err = router.ToClient(context.Background(), image)
+	assert.Equal(t, NewAuthError("does not match domain", image), err)
+
+	_, err = router.GetTokenSource(context.Background(), image)
+	assert.Error(t, err)
+}
+
+func TestRouterOrder(t *testing.T) {
+	router := NewRouter(&domainAuth{domain: "gcr.io"}, &domainAuth{})
+
+	image, err := reference.ParseNamed("gcr.io/path/to/image")
+	require.NoError(t, err)
+
+	client, err := router.ToClient(context.Background(), image)
+	require.NoError(t, err)
+	assert.Equal(t, "gcr.io", client.Transport.(*namedTransport).name, "the first matching Auth should be used")
+
+	image, err = reference.ParseNamed("quay.io/path/to/image")
+	require.NoError(t, err)
+
+	client, err = router.ToClient(context.Background(), image)
+	require.NoError(t, err)
+	assert.Equal(t, "", client.Transport.(*namedTransport).name)
+}
@@ -0,0 +1,112 @@
+package baseline
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/docker/distribution/reference"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/docker"
+	"github.com/grafeas/voucher/v2/signer"
+)
+
+// MaxCandidates is the number of earlier images that FindPrevious looks up
+// attestations for before giving up. It also limits the number of tags that
+// are resolved in registries which do not list their images' upload times.
+const MaxCandidates = 20
+
+// ErrNoPreviousImage is returned when none of the earlier images in a
+// repository were attested for a check.
+var ErrNoPreviousImage = errors.New("no earlier image in the repository was attested")
+
+// candidates returns the images in the passed list that were uploaded before
+// the passed image. If the passed image is not in the list, or its upload
+// time is unknown, there is no way to tell which images are earlier, so there
+// are no candidates. Images whose own upload times are unknown are left out
+// for the same reason.
+func candidates(images []docker.RepositoryImage, i voucher.ImageData) []docker.RepositoryImage {
+	var uploaded time.Time
+	for _, image := range images {
+		if image.Digest == i.Digest() {
+			uploaded = image.Uploaded
+			break
+		}
+	}
+
+	earlier := make([]docker.RepositoryImage, 0, len(images))
+	if uploaded.IsZero() {
+		return earlier
+	}
+
+	for _, image := range images {
+		if !image.Uploaded.IsZero() && image.Uploaded.Before(uploaded) {
+			earlier = append(earlier, image)
+		}
+	}
+	return earlier
+}
+
+// isAttestedFor returns true if one of the passed attestations is for the
+// check with the passed name, and the passed verifier confirms that it was
+// signed with that check's key, and signs the passed payload.
+func isAttestedFor(attestations []voucher.SignedAttestation, checkName string, verifier signer.AttestationVerifier, payload string) bool {
+	for _, attestation := range attestations {
+		if checkName != attestation.CheckName {
+			continue
+		}
+		if nil == verifier.Verify(checkName, payload, attestation.Signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindPrevious returns the most recent image in the passed image's repository
+// that was uploaded before it, and was attested for the check with the passed
+// name. Attestations are looked up with the MetadataClient for at most
+// MaxCandidates images, and only count if the passed verifier confirms their
+// signatures. If none of them were attested, ErrNoPreviousImage is returned.
+func FindPrevious(ctx context.Context, auth voucher.Auth, metadataClient voucher.MetadataClient, verifier signer.AttestationVerifier, i voucher.ImageData, checkName string) (voucher.ImageData, error) {
+	client, err := auth.ToClient(ctx, i)
+	if nil != err {
+		return nil, err
+	}
+
+	images, err := docker.ListImages(client, i, MaxCandidates)
+	if nil != err {
+		return nil, err
+	}
+
+	images = candidates(images, i)
+	if MaxCandidates < len(images) {
+		images = images[:MaxCandidates]
+	}
+
+	for _, image := range images {
+		candidate, err := reference.WithDigest(reference.TrimNamed(i), image.Digest)
+		if nil != err {
+			return nil, err
+		}
+
+		attestations, err := metadataClient.GetAttestations(ctx, candidate)
+		if voucher.IsNoMetadataError(err) {
+			continue
+		}
+		if nil != err {
+			return nil, err
+		}
+
+		payload, err := metadataClient.NewPayloadBody(candidate)
+		if nil != err {
+			return nil, err
+		}
+
+		if isAttestedFor(attestations, checkName, verifier, payload) {
+			return candidate, nil
+		}
+	}
+
+	return nil, ErrNoPreviousImage
+}
@@ -0,0 +1,152 @@
+package baseline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/docker"
+	"github.com/grafeas/voucher/v2/signer"
+	vtesting "github.com/grafeas/voucher/v2/testing"
+)
+
+const previousDigest = "sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2"
+
+// previousPayload is the attestation payload for the previous image.
+const previousPayload = "payload for " + previousDigest
+
+// forImage returns an argument matcher for the image with the passed digest.
+func forImage(imageDigest string) interface{} {
+	return mock.MatchedBy(func(i voucher.ImageData) bool {
+		return imageDigest == i.Digest().String()
+	})
+}
+
+// newSignedAttestation returns an attestation for the check with the passed
+// name, which signs the passed payload with the passed signer.
+func newSignedAttestation(t *testing.T, attestationSigner signer.AttestationSigner, checkName, payload string) voucher.SignedAttestation {
+	t.Helper()
+
+	attestation, err := voucher.SignAttestation(attestationSigner, voucher.NewAttestation(checkName, payload))
+	require.NoError(t, err)
+	return attestation
+}
+
+func TestFindPrevious(t *testing.T) {
+	server := vtesting.NewTestDockerServer(t)
+	defer server.Close()
+
+	i := vtesting.NewTestReference(t)
+	keyring := vtesting.NewPGPSigner(t)
+
+	metadataClient := new(voucher.MockMetadataClient)
+	metadataClient.On("NewPayloadBody", forImage(previousDigest)).Return(previousPayload, nil)
+	metadataClient.On("GetAttestations", mock.Anything, forImage(previousDigest)).Return(
+		[]voucher.SignedAttestation{
+			{Attestation: voucher.NewAttestation("diy", "")},
+			newSignedAttestation(t, keyring, "snakeoil", previousPayload),
+		},
+		nil,
+	)
+
+	previous, err := FindPrevious(context.Background(), vtesting.NewAuth(server), metadataClient, keyring.(signer.AttestationVerifier), i, "snakeoil")
+	require.NoError(t, err)
+
+	assert.Equal(t, "localhost/path/to/image@"+previousDigest, previous.String())
+	metadataClient.AssertExpectations(t)
+}
+
+func TestFindPreviousWithoutAttestation(t *testing.T) {
+	server := vtesting.NewTestDockerServer(t)
+	defer server.Close()
+
+	i := vtesting.NewTestReference(t)
+	keyring := vtesting.NewPGPSigner(t)
+
+	metadataClient := new(voucher.MockMetadataClient)
+	metadataClient.On("NewPayloadBody", forImage(previousDigest)).Return(previousPayload, nil)
+	metadataClient.On("GetAttestations", mock.Anything, forImage(previousDigest)).Return(
+		[]voucher.SignedAttestation{
+			newSignedAttestation(t, vtesting.NewGeneratedPGPSigner(t, "diy"), "diy", previousPayload),
+		},
+		nil,
+	)
+
+	_, err := FindPrevious(context.Background(), vtesting.NewAuth(server), metadataClient, keyring.(signer.AttestationVerifier), i, "snakeoil")
+	assert.Equal(t, ErrNoPreviousImage, err)
+}
+
+func TestFindPreviousRejectsUnverifiedAttestations(t *testing.T) {
+	server := vtesting.NewTestDockerServer(t)
+	defer server.Close()
+
+	i := vtesting.NewTestReference(t)
+	keyring := vtesting.NewPGPSigner(t)
+
+	metadataClient := new(voucher.MockMetadataClient)
+	metadataClient.On("NewPayloadBody", forImage(previousDigest)).Return(previousPayload, nil)
+	metadataClient.On("GetAttestations", mock.Anything, forImage(previousDigest)).Return(
+		[]voucher.SignedAttestation{
+			{Attestation: voucher.NewAttestation("snakeoil", previousPayload)},
+			newSignedAttestation(t, vtesting.NewGeneratedPGPSigner(t, "snakeoil"), "snakeoil", previousPayload),
+			newSignedAttestation(t, keyring, "snakeoil", "payload for another image"),
+		},
+		nil,
+	)
+
+	_, err := FindPrevious(context.Background(), vtesting.NewAuth(server), metadataClient, keyring.(signer.AttestationVerifier), i, "snakeoil")
+	assert.Equal(t, ErrNoPreviousImage, err)
+}
+
+func TestFindPreviousWithoutMetadata(t *testing.T) {
+	server := vtesting.NewTestDockerServer(t)
+	defer server.Close()
+
+	i := vtesting.NewTestReference(t)
+
+	metadataClient := new(voucher.MockMetadataClient)
+	metadataClient.On("GetAttestations", mock.Anything, forImage(previousDigest)).Return(
+		[]voucher.SignedAttestation{},
+		&voucher.NoMetadataError{Type: voucher.AttestationType},
+	)
+
+	_, err := FindPrevious(context.Background(), vtesting.NewAuth(server), metadataClient, vtesting.NewPGPSigner(t).(signer.AttestationVerifier), i, "snakeoil")
+	assert.Equal(t, ErrNoPreviousImage, err)
+}
+
+func TestFindPreviousSkipsLaterImages(t *testing.T) {
+	server := vtesting.NewTestDockerServer(t)
+	defer server.Close()
+
+	// The earlier image in the test repository has no earlier image of its
+	// own, so the latest image must not be used as its baseline.
+	i := vtesting.NewNobodyBadTestReference(t)
+
+	metadataClient := new(voucher.MockMetadataClient)
+
+	_, err := FindPrevious(context.Background(), vtesting.NewAuth(server), metadataClient, vtesting.NewPGPSigner(t).(signer.AttestationVerifier), i, "snakeoil")
+	assert.Equal(t, ErrNoPreviousImage, err)
+	metadataClient.AssertNotCalled(t, "GetAttestations", mock.Anything, mock.Anything)
+}
+
+func TestCandidates(t *testing.T) {
+	i := vtesting.NewTestReference(t)
+	now := time.Now()
+
+	later := docker.RepositoryImage{Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111", Uploaded: now.Add(time.Hour)}
+	earlier := docker.RepositoryImage{Digest: previousDigest, Uploaded: now.Add(-time.Hour)}
+	unknown := docker.RepositoryImage{Digest: "sha256:2222222222222222222222222222222222222222222222222222222222222222"}
+
+	image := docker.RepositoryImage{Digest: i.Digest(), Uploaded: now}
+	assert.Equal(t, []docker.RepositoryImage{earlier}, candidates([]docker.RepositoryImage{later, image, earlier, unknown}, i))
+
+	assert.Empty(t, candidates([]docker.RepositoryImage{later, earlier, unknown}, i), "images were candidates for an image that is not listed")
+
+	image.Uploaded = time.Time{}
+	assert.Empty(t, candidates([]docker.RepositoryImage{later, image, earlier, unknown}, i), "images were candidates for an image with no upload time")
+}
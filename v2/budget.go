@@ -0,0 +1,87 @@
+package voucher
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VulnerabilityBudget is the number of vulnerabilities of each Severity that an
+// image may have before it is considered vulnerable. Severities without a
+// budget have a budget of zero, and a negative budget is unlimited.
+type VulnerabilityBudget map[Severity]int
+
+// BudgetUsage describes how many vulnerabilities of a Severity an image has,
+// against the budget for that Severity.
+type BudgetUsage struct {
+	Severity Severity `json:"severity"`
+	Count    int      `json:"count"`
+	Budget   int      `json:"budget"` // A negative budget is unlimited.
+	Exceeded bool     `json:"exceeded"`
+}
+
+// String returns a description of the BudgetUsage, such as "high (4 of 3)".
+func (usage BudgetUsage) String() string {
+	if 0 > usage.Budget {
+		return fmt.Sprintf("%s (%d, unlimited)", usage.Severity, usage.Count)
+	}
+	return fmt.Sprintf("%s (%d of %d)", usage.Severity, usage.Count, usage.Budget)
+}
+
+// Usage counts the passed vulnerabilities by Severity, ignoring those that
+// have been suppressed, and compares each count against the budget. It returns
+// the usage of each Severity which has vulnerabilities or a budget, from the
+// most severe to the least.
+func (budget VulnerabilityBudget) Usage(vulns []Vulnerability) []BudgetUsage {
+	counts := make(map[Severity]int)
+	for severity := range budget {
+		counts[severity] = 0
+	}
+	for _, vuln := range UnsuppressedVulnerabilities(vulns) {
+		counts[vuln.Severity]++
+	}
+
+	usages := make([]BudgetUsage, 0, len(counts))
+	for severity, count := range counts {
+		allowed := budget[severity]
+		usages = append(usages, BudgetUsage{
+			Severity: severity,
+			Count:    count,
+			Budget:   allowed,
+			Exceeded: 0 <= allowed && count > allowed,
+		})
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Severity > usages[j].Severity
+	})
+
+	return usages
+}
+
+// BudgetExceeded returns true if any of the passed BudgetUsages exceeded their
+// budget.
+func BudgetExceeded(usages []BudgetUsage) bool {
+	for _, usage := range usages {
+		if usage.Exceeded {
+			return true
+		}
+	}
+	return false
+}
+
+// describeBudgets returns a description of the passed BudgetUsages, listing
+// the Severities which exceeded their budget.
+func describeBudgets(usages []BudgetUsage) string {
+	exceeded := make([]string, 0, len(usages))
+	for _, usage := range usages {
+		if usage.Exceeded {
+			exceeded = append(exceeded, usage.String())
+		}
+	}
+
+	if 0 == len(exceeded) {
+		return "within budget"
+	}
+	return "over budget for " + strings.Join(exceeded, ", ")
+}
@@ -0,0 +1,38 @@
+package voucher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVulnerabilityBudgetUsage(t *testing.T) {
+	budget := VulnerabilityBudget{
+		CriticalSeverity: 0,
+		HighSeverity:     1,
+		MediumSeverity:   -1,
+	}
+
+	vulns := []Vulnerability{
+		{Name: "high-1", Severity: HighSeverity},
+		{Name: "high-2", Severity: HighSeverity},
+		{Name: "high-3", Severity: HighSeverity, Suppressed: true},
+		{Name: "medium-1", Severity: MediumSeverity},
+		{Name: "low-1", Severity: LowSeverity},
+	}
+
+	usages := budget.Usage(vulns)
+	assert.Equal(t, []BudgetUsage{
+		{Severity: CriticalSeverity, Count: 0, Budget: 0},
+		{Severity: HighSeverity, Count: 2, Budget: 1, Exceeded: true},
+		{Severity: MediumSeverity, Count: 1, Budget: -1},
+		{Severity: LowSeverity, Count: 1, Budget: 0, Exceeded: true},
+	}, usages)
+	assert.True(t, BudgetExceeded(usages))
+
+	err := VulnerabilitiesError{Vulnerabilities: vulns, Budgets: usages}
+	assert.Equal(t, "vulnernable to 4 vulnerabilities: high-1 (high), high-2 (high), medium-1 (medium), low-1 (low); over budget for high (2 of 1), low (1 of 0)", err.Error())
+
+	usages = budget.Usage(vulns[2:4])
+	assert.False(t, BudgetExceeded(usages))
+}
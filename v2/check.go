@@ -2,12 +2,11 @@ package voucher
 
 import (
 	"context"
-	"errors"
 )
 
 // ErrNoCheck is an error that is returned when a requested check hasn't
 // been registered.
-var ErrNoCheck = errors.New("requested check doesn't exist")
+var ErrNoCheck = NewCheckError(ErrorCodeNoCheck, "requested check doesn't exist")
 
 // Check represents a Voucher test.
 type Check interface {
@@ -2,7 +2,6 @@ package approved
 
 import (
 	"context"
-	"errors"
 
 	voucher "github.com/grafeas/voucher/v2"
 	"github.com/grafeas/voucher/v2/repository"
@@ -10,17 +9,17 @@ import (
 
 // ErrNoBuildData is an error returned if we can't pull any BuildData from
 // Grafeas for an image.
-var ErrNoBuildData = errors.New("no build metadata associated with this image")
+var ErrNoBuildData = voucher.NewCheckError(voucher.ErrorCodeNoBuildData, "no build metadata associated with this image")
 
 // ErrNeedsRepositoryClient is an error returned if there is no repository client
 // configured for an image
-var ErrNeedsRepositoryClient = errors.New("this check requires a repository client")
+var ErrNeedsRepositoryClient = voucher.NewCheckError(voucher.ErrorCodeNoRepositoryClient, "this check requires a repository client")
 
-var ErrNotSigned = errors.New("commit was not signed by a valid key")
-var ErrNotOnDefaultBranch = errors.New("commit is not the latest commit on the production branch")
-var ErrNotMergeCommit = errors.New("commit is not a merge commit")
-var ErrMissingRequiredApprovals = errors.New("the PR associated with this commit does not have the required number of approvals")
-var ErrNotPassedCI = errors.New("commit did not pass CI in source code repository")
+var ErrNotSigned = voucher.NewCheckError(voucher.ErrorCodeNotSigned, "commit was not signed by a valid key")
+var ErrNotOnDefaultBranch = voucher.NewCheckError(voucher.ErrorCodeNotOnDefaultBranch, "commit is not the latest commit on the production branch")
+var ErrNotMergeCommit = voucher.NewCheckError(voucher.ErrorCodeNotMergeCommit, "commit is not a merge commit")
+var ErrMissingRequiredApprovals = voucher.NewCheckError(voucher.ErrorCodeMissingApprovals, "the PR associated with this commit does not have the required number of approvals")
+var ErrNotPassedCI = voucher.NewCheckError(voucher.ErrorCodeNotPassedCI, "commit did not pass CI in source code repository")
 
 type check struct {
 	metadataClient   voucher.MetadataClient
@@ -109,6 +109,7 @@ func TestApprovedCheck(t *testing.T) {
 			assert.Equal(t, testCase.shouldPass, status)
 			if testCase.err != nil {
 				assert.EqualError(t, testCase.err, err.Error())
+				assert.Equal(t, testCase.err, err)
 			}
 		})
 	}
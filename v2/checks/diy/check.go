@@ -2,7 +2,6 @@ package diy
 
 import (
 	"context"
-	"errors"
 	"strings"
 
 	voucher "github.com/grafeas/voucher/v2"
@@ -11,7 +10,7 @@ import (
 
 // ErrNotFromRepo is returned when an image does not match one of the valid
 // repo paths.
-var ErrNotFromRepo = errors.New("image is not from a valid repo")
+var ErrNotFromRepo = voucher.NewCheckError(voucher.ErrorCodeRepoNotAllowed, "image is not from a valid repo")
 
 // check is a check that verifies if the passed image was built
 // by us.
@@ -0,0 +1,98 @@
+package kev
+
+import (
+	"context"
+	"strings"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// ErrNoScanner is the error returned when there is no VulnerabilityScanner set
+// for the KEV Check.
+var ErrNoScanner = voucher.NewCheckError(voucher.ErrorCodeNoScanner, "no scanner configured for kev")
+
+// ErrNoExploitSource is the error returned when there is no ExploitSource set
+// for the KEV Check.
+var ErrNoExploitSource = voucher.NewCheckError(voucher.ErrorCodeNoExploitData, "no known exploited vulnerabilities catalog configured for kev")
+
+// check verifies that none of the vulnerabilities in the passed image are
+// known to be exploited, or are likely to be exploited.
+type check struct {
+	scanner       voucher.VulnerabilityScanner
+	source        voucher.ExploitSource
+	epssThreshold float32
+}
+
+// SetScanner sets the scanner that KEV should use.
+func (c *check) SetScanner(scanner voucher.VulnerabilityScanner) {
+	c.scanner = scanner
+}
+
+// SetExploitSource sets the source of the Known Exploited Vulnerabilities
+// catalog and EPSS scores that KEV should use.
+func (c *check) SetExploitSource(source voucher.ExploitSource) {
+	c.source = source
+}
+
+// SetEPSSThreshold sets the EPSS probability that a vulnerability must exceed
+// for KEV to fail. If the threshold is zero, EPSS scores are ignored.
+func (c *check) SetEPSSThreshold(threshold float32) {
+	c.epssThreshold = threshold
+}
+
+// Check verifies that the image has no vulnerabilities which are in the Known
+// Exploited Vulnerabilities catalog, or have an EPSS probability above the
+// threshold. Suppressed vulnerabilities are ignored.
+func (c *check) Check(ctx context.Context, i voucher.ImageData) (bool, error) {
+	if nil == c.scanner {
+		return false, ErrNoScanner
+	}
+
+	if nil == c.source {
+		return false, ErrNoExploitSource
+	}
+
+	db, err := c.source.Load()
+	if nil != err {
+		return false, err
+	}
+
+	vulns, err := c.scanner.Scan(ctx, i)
+	if nil != err {
+		return false, err
+	}
+
+	exploited := make([]voucher.ExploitedVulnerability, 0)
+	for _, vuln := range voucher.UnsuppressedVulnerabilities(vulns) {
+		if !strings.HasPrefix(strings.ToUpper(vuln.Name), "CVE-") {
+			continue
+		}
+
+		exploitedVuln := voucher.ExploitedVulnerability{Vulnerability: vuln}
+		if entry, ok := db.KnownExploited(vuln.Name); ok {
+			exploitedVuln.KnownExploited = &entry
+		}
+
+		if 0 < c.epssThreshold {
+			if probability, ok := db.ExploitProbability(vuln.Name); ok && probability > c.epssThreshold {
+				exploitedVuln.EPSS = probability
+			}
+		}
+
+		if nil != exploitedVuln.KnownExploited || 0 < exploitedVuln.EPSS {
+			exploited = append(exploited, exploitedVuln)
+		}
+	}
+
+	if 0 != len(exploited) {
+		return false, voucher.ExploitedVulnerabilitiesError{Vulnerabilities: exploited}
+	}
+
+	return true, nil
+}
+
+func init() {
+	voucher.RegisterCheckFactory("kev", func() voucher.Check {
+		return new(check)
+	})
+}
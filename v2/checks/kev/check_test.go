@@ -0,0 +1,122 @@
+package kev
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+	vtesting "github.com/grafeas/voucher/v2/testing"
+)
+
+type testExploitDatabase struct {
+	catalog map[string]voucher.KnownExploitedVulnerability
+	epss    map[string]float32
+}
+
+func (db *testExploitDatabase) KnownExploited(cve string) (voucher.KnownExploitedVulnerability, bool) {
+	entry, ok := db.catalog[cve]
+	return entry, ok
+}
+
+func (db *testExploitDatabase) ExploitProbability(cve string) (float32, bool) {
+	score, ok := db.epss[cve]
+	return score, ok
+}
+
+type testExploitSource struct {
+	db  voucher.ExploitDatabase
+	err error
+}
+
+func (source *testExploitSource) Load() (voucher.ExploitDatabase, error) {
+	return source.db, source.err
+}
+
+func newTestSource() voucher.ExploitSource {
+	return &testExploitSource{
+		db: &testExploitDatabase{
+			catalog: map[string]voucher.KnownExploitedVulnerability{
+				"CVE-2021-44228": {CVEID: "CVE-2021-44228", DueDate: "2021-12-24"},
+			},
+			epss: map[string]float32{
+				"CVE-2021-44228": 0.97,
+				"CVE-2020-1967":  0.6,
+				"CVE-2019-18276": 0.01,
+			},
+		},
+	}
+}
+
+func newTestImageData(t *testing.T) voucher.ImageData {
+	t.Helper()
+
+	i, err := voucher.NewImageData("gcr.io/path/to/image@sha256:97db2bc359ccc94d3b2d6f5daa4173e9e91c513b0dcd961408adbb95ec5e5ce5")
+	require.NoError(t, err)
+	return i
+}
+
+func TestKEVWithoutScannerOrSource(t *testing.T) {
+	check := new(check)
+
+	ok, err := check.Check(context.Background(), newTestImageData(t))
+	assert.False(t, ok)
+	assert.Equal(t, ErrNoScanner, err)
+
+	check.SetScanner(vtesting.NewScanner(t))
+
+	ok, err = check.Check(context.Background(), newTestImageData(t))
+	assert.False(t, ok)
+	assert.Equal(t, ErrNoExploitSource, err)
+	assert.Equal(t, voucher.ErrorCodeNoExploitData, voucher.ErrorCodeOf(err))
+
+	check.SetExploitSource(&testExploitSource{err: errors.New("catalog missing")})
+
+	ok, err = check.Check(context.Background(), newTestImageData(t))
+	assert.False(t, ok)
+	assert.EqualError(t, err, "catalog missing")
+}
+
+func TestKEV(t *testing.T) {
+	check := new(check)
+	check.SetExploitSource(newTestSource())
+	check.SetScanner(vtesting.NewScanner(t,
+		voucher.Vulnerability{Name: "CVE-2020-1967", Severity: voucher.HighSeverity},
+		voucher.Vulnerability{Name: "CVE-2019-18276", Severity: voucher.LowSeverity},
+	))
+
+	ok, err := check.Check(context.Background(), newTestImageData(t))
+	assert.True(t, ok, "check failed without any known exploited vulnerabilities")
+	assert.NoError(t, err)
+
+	check.SetEPSSThreshold(0.5)
+
+	ok, err = check.Check(context.Background(), newTestImageData(t))
+	assert.False(t, ok, "check passed with a vulnerability above the EPSS threshold")
+	assert.EqualError(t, err, "1 vulnerabilities are exploited or likely to be exploited: CVE-2020-1967 (EPSS 0.600)")
+}
+
+func TestKEVWithKnownExploitedVulnerability(t *testing.T) {
+	check := new(check)
+	check.SetExploitSource(newTestSource())
+	check.SetEPSSThreshold(0.9)
+	check.SetScanner(vtesting.NewScanner(t,
+		voucher.Vulnerability{Name: "CVE-2021-44228", Severity: voucher.CriticalSeverity},
+		voucher.Vulnerability{Name: "CVE-2020-1967", Severity: voucher.HighSeverity},
+		voucher.Vulnerability{Name: "CVE-2021-44228", Severity: voucher.CriticalSeverity, Suppressed: true},
+	))
+
+	ok, err := check.Check(context.Background(), newTestImageData(t))
+	assert.False(t, ok)
+	assert.Equal(t, voucher.ErrorCodeExploited, voucher.ErrorCodeOf(err))
+	assert.EqualError(t, err, "1 vulnerabilities are exploited or likely to be exploited: CVE-2021-44228 (known exploited, EPSS 0.970)")
+
+	details, ok := voucher.ErrorDetailsOf(err).(voucher.ExploitedVulnerabilitiesDetails)
+	require.True(t, ok, "error details were not ExploitedVulnerabilitiesDetails")
+	require.Len(t, details.Vulnerabilities, 1)
+	assert.Equal(t, "2021-12-24", details.Vulnerabilities[0].KnownExploited.DueDate)
+	assert.Equal(t, float32(0.97), details.Vulnerabilities[0].EPSS)
+}
@@ -7,10 +7,15 @@ import (
 	"github.com/grafeas/voucher/v2/docker"
 )
 
+// runsAsRoot is the error reported for each platform whose image runs as
+// root.
+const runsAsRoot = "runs as root"
+
 // check is for verifying that the passed image does not run as
 // root or user 0.
 type check struct {
-	auth voucher.Auth
+	auth      voucher.Auth
+	platforms []string
 }
 
 // SetAuth sets the authentication system that this check will use
@@ -19,25 +24,60 @@ func (n *check) SetAuth(auth voucher.Auth) {
 	n.auth = auth
 }
 
+// SetPlatforms sets the platforms of multi-platform images that this check
+// verifies. If no platforms are set, every platform is verified.
+func (n *check) SetPlatforms(platforms []string) {
+	n.platforms = platforms
+}
+
 // Check verifies if the image runs as root and returns a boolean (true if
-// the user is not root, false otherwise) and an error as response.
+// the user is not root, false otherwise) and an error as response. For a
+// multi-platform image, the image for each platform is verified, and if any
+// of them run as root, the error lists the result for each platform.
 func (n *check) Check(ctx context.Context, i voucher.ImageData) (bool, error) {
 	if nil == n.auth {
 		return false, voucher.ErrNoAuth
 	}
 
-	client, err := n.auth.ToClient(ctx, i)
+	platforms, err := docker.ParsePlatforms(n.platforms)
 	if nil != err {
 		return false, err
 	}
 
-	imageConfig, err := docker.RequestImageConfig(client, i)
+	client, err := n.auth.ToClient(ctx, i)
+	if nil != err {
+		return false, err
+	}
 
+	imageConfigs, err := docker.RequestImageConfigs(client, i, platforms...)
 	if nil != err {
 		return false, err
 	}
 
-	return !imageConfig.RunsAsRoot(), nil
+	if 1 == len(imageConfigs) && (docker.Platform{}) == imageConfigs[0].Platform {
+		return !imageConfigs[0].RunsAsRoot(), nil
+	}
+
+	passed := true
+	results := make([]voucher.PlatformResult, 0, len(imageConfigs))
+	for _, imageConfig := range imageConfigs {
+		result := voucher.PlatformResult{
+			Platform: imageConfig.Platform.String(),
+			Digest:   imageConfig.Digest.String(),
+			Success:  !imageConfig.RunsAsRoot(),
+		}
+		if !result.Success {
+			result.Err = runsAsRoot
+			passed = false
+		}
+		results = append(results, result)
+	}
+
+	if !passed {
+		return false, voucher.NewPlatformsError(voucher.ErrorCodeRunsAsRoot, results)
+	}
+
+	return true, nil
 }
 
 func init() {
@@ -76,3 +76,44 @@ func TestFailingNobodyCheck(t *testing.T) {
 	require.NoError(t, err, "check should have failed with error, but didn't")
 	assert.False(t, pass, "check passed when it should have failed")
 }
+
+func TestNobodyCheckWithIndex(t *testing.T) {
+	server := vtesting.NewTestDockerServer(t)
+
+	nobodyCheck := new(check)
+	nobodyCheck.SetAuth(vtesting.NewAuth(server))
+
+	i := vtesting.NewTestIndexReference(t)
+
+	pass, err := nobodyCheck.Check(context.Background(), i)
+	require.Error(t, err, "check should have failed for linux/arm64, but didn't")
+	assert.False(t, pass, "check passed when an image in the index runs as root")
+
+	assert.Equal(t, "failed for 1 of 2 platforms: linux/arm64/v8 (runs as root)", err.Error())
+	assert.Equal(t, voucher.ErrorCodeRunsAsRoot, voucher.ErrorCodeOf(err))
+	assert.Equal(t, voucher.PlatformsDetails{
+		Platforms: []voucher.PlatformResult{
+			{
+				Platform: "linux/amd64",
+				Digest:   "sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9",
+				Success:  true,
+			},
+			{
+				Platform: "linux/arm64/v8",
+				Digest:   "sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2",
+				Err:      "runs as root",
+			},
+		},
+	}, voucher.ErrorDetailsOf(err))
+
+	nobodyCheck.SetPlatforms([]string{"linux/amd64"})
+
+	pass, err = nobodyCheck.Check(context.Background(), i)
+	require.NoErrorf(t, err, "check failed with error: %s", err)
+	assert.True(t, pass, "check failed when the configured platform doesn't run as root")
+
+	nobodyCheck.SetPlatforms([]string{"linux"})
+
+	_, err = nobodyCheck.Check(context.Background(), i)
+	assert.Error(t, err, "check should have failed with an invalid platform, but didn't")
+}
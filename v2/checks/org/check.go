@@ -2,7 +2,6 @@ package org
 
 import (
 	"context"
-	"errors"
 
 	voucher "github.com/grafeas/voucher/v2"
 	"github.com/grafeas/voucher/v2/repository"
@@ -10,10 +9,10 @@ import (
 
 // ErrNoBuildData is an error returned if we can't pull any BuildData from
 // Grafeas for an image.
-var ErrNoBuildData = errors.New("no build metadata associated with this image")
+var ErrNoBuildData = voucher.NewCheckError(voucher.ErrorCodeNoBuildData, "no build metadata associated with this image")
 
 // ErrNoRepositoryClient is an error returned if we can't connect to the source code repository for an image.
-var ErrNoRepositoryClient = errors.New("no repository client configured for check")
+var ErrNoRepositoryClient = voucher.NewCheckError(voucher.ErrorCodeNoRepositoryClient, "no repository client configured for check")
 
 // check holds the required data for the check
 type check struct {
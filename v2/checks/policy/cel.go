@@ -0,0 +1,58 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+)
+
+// celEvaluator evaluates a CEL expression, which has the Input document
+// available as the variable "input".
+type celEvaluator struct {
+	program cel.Program
+}
+
+// newCELEvaluator compiles the CEL expression in the passed Source.
+func newCELEvaluator(source Source) (Evaluator, error) {
+	env, err := cel.NewEnv(
+		cel.Declarations(decls.NewIdent("input", decls.Dyn, nil)),
+	)
+	if nil != err {
+		return nil, err
+	}
+
+	ast, issues := env.Compile(source.Policy)
+	if nil != issues && nil != issues.Err() {
+		return nil, fmt.Errorf("failed to compile %s: %s", source.Filename, issues.Err())
+	}
+
+	if !proto.Equal(decls.Bool, ast.ResultType()) && !proto.Equal(decls.Dyn, ast.ResultType()) {
+		return nil, fmt.Errorf("%s must evaluate to a bool", source.Filename)
+	}
+
+	program, err := env.Program(ast)
+	if nil != err {
+		return nil, err
+	}
+
+	return &celEvaluator{program: program}, nil
+}
+
+// Evaluate evaluates the expression against the passed Input document.
+func (evaluator *celEvaluator) Evaluate(ctx context.Context, input map[string]interface{}) (bool, error) {
+	value, _, err := evaluator.program.Eval(map[string]interface{}{
+		"input": input,
+	})
+	if nil != err {
+		return false, err
+	}
+
+	allowed, ok := value.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("policy returned %v, rather than a bool", value.Value())
+	}
+	return allowed, nil
+}
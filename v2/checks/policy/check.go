@@ -0,0 +1,87 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/repository"
+)
+
+// check evaluates a user-supplied policy against the metadata of an image.
+type check struct {
+	name             string
+	evaluator        Evaluator
+	auth             voucher.Auth
+	scanner          voucher.VulnerabilityScanner
+	metadataClient   voucher.MetadataClient
+	repositoryClient repository.Client
+}
+
+// SetAuth sets the authentication system that this check will use to
+// request the image's configuration.
+func (p *check) SetAuth(auth voucher.Auth) {
+	p.auth = auth
+}
+
+// SetScanner sets the scanner that this check will use to find the image's
+// vulnerabilities.
+func (p *check) SetScanner(scanner voucher.VulnerabilityScanner) {
+	p.scanner = scanner
+}
+
+// PolicyName returns the name of the policy that this check evaluates.
+func (p *check) PolicyName() string {
+	return p.name
+}
+
+// SetMetadataClient sets the MetadataClient for this Check.
+func (p *check) SetMetadataClient(metadataClient voucher.MetadataClient) {
+	p.metadataClient = metadataClient
+}
+
+// SetRepositoryClient sets the repository.Client for this Check.
+func (p *check) SetRepositoryClient(repositoryClient repository.Client) {
+	p.repositoryClient = repositoryClient
+}
+
+// Check gathers the Input for the image, and returns true if the policy
+// allows it.
+func (p *check) Check(ctx context.Context, i voucher.ImageData) (bool, error) {
+	input, err := p.gatherInput(ctx, i)
+	if nil != err {
+		return false, err
+	}
+
+	document, err := input.toDocument()
+	if nil != err {
+		return false, err
+	}
+
+	allowed, err := p.evaluator.Evaluate(ctx, document)
+	if nil != err {
+		return false, fmt.Errorf("failed to evaluate policy %s: %w", p.name, err)
+	}
+
+	if !allowed {
+		return false, &voucher.CheckError{
+			Code:    voucher.ErrorCodePolicyDenied,
+			Message: fmt.Sprintf("image is not allowed by policy %s", p.name),
+			Details: map[string]string{"policy": p.name},
+		}
+	}
+
+	return true, nil
+}
+
+// NewPolicyCheckFactory returns a voucher.CheckFactory which creates Checks
+// that evaluate the passed policy. The name is used in error messages, and
+// should match the name the factory is registered with.
+func NewPolicyCheckFactory(name string, evaluator Evaluator) voucher.CheckFactory {
+	return func() voucher.Check {
+		return &check{
+			name:      name,
+			evaluator: evaluator,
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/repository"
+	vtesting "github.com/grafeas/voucher/v2/testing"
+)
+
+const testCELPolicy = `
+	input.config.user == "nobody" &&
+	input.commit.is_signed &&
+	input.build_detail.project_id == "test" &&
+	input.vulnerabilities.all(v, v.severity < 4.0)
+`
+
+const testRegoPolicy = `
+package voucher
+
+default allow = false
+
+allow {
+	input.commit.is_signed
+	input.config.user != "root"
+	count(input.vulnerabilities) == 0
+}
+`
+
+func newTestPolicyCheck(t *testing.T, source Source, vulnerabilities []voucher.Vulnerability) (voucher.Check, voucher.ImageData) {
+	server := vtesting.NewTestDockerServer(t)
+	i := vtesting.NewTestReference(t)
+
+	buildDetail := repository.BuildDetail{ProjectID: "test", RepositoryURL: "https://github.com/grafeas/voucher"}
+
+	metadataClient := new(voucher.MockMetadataClient)
+	metadataClient.On("GetBuildDetail", mock.Anything, i).Return(buildDetail, nil)
+
+	repositoryClient := new(repository.MockClient)
+	repositoryClient.On("GetCommit", mock.Anything, buildDetail).Return(repository.Commit{IsSigned: true}, nil)
+
+	evaluator, err := NewEvaluator(context.Background(), source)
+	require.NoError(t, err)
+
+	policyCheck := NewPolicyCheckFactory("test_policy", evaluator)()
+	policyCheck.(voucher.AuthorizedCheck).SetAuth(vtesting.NewAuth(server))
+	policyCheck.(voucher.VulnerabilityCheck).SetScanner(vtesting.NewScanner(t, vulnerabilities...))
+	policyCheck.(voucher.MetadataCheck).SetMetadataClient(metadataClient)
+	policyCheck.(voucher.RepositoryCheck).SetRepositoryClient(repositoryClient)
+
+	return policyCheck, i
+}
+
+func TestPolicyCheck(t *testing.T) {
+	for _, source := range []Source{
+		{Language: CEL, Filename: "test.cel", Policy: testCELPolicy},
+		{Language: Rego, Filename: "test.rego", Policy: testRegoPolicy},
+	} {
+		t.Run(source.Language, func(t *testing.T) {
+			policyCheck, i := newTestPolicyCheck(t, source, []voucher.Vulnerability{})
+
+			pass, err := policyCheck.Check(context.Background(), i)
+			require.NoError(t, err)
+			assert.True(t, pass, "check failed when it should have passed")
+
+			policyCheck, i = newTestPolicyCheck(t, source, []voucher.Vulnerability{
+				{Name: "CVE-2020-1234", Severity: voucher.CriticalSeverity},
+			})
+
+			pass, err = policyCheck.Check(context.Background(), i)
+			assert.False(t, pass, "check passed when it should have failed")
+			assert.EqualError(t, err, "image is not allowed by policy test_policy")
+			assert.Equal(t, voucher.ErrorCodePolicyDenied, voucher.ErrorCodeOf(err))
+		})
+	}
+}
+
+func TestInputDocument(t *testing.T) {
+	policyCheck, i := newTestPolicyCheck(t, Source{Language: CEL, Filename: "test.cel", Policy: "true"}, []voucher.Vulnerability{
+		{Name: "CVE-2020-1234", Severity: voucher.HighSeverity},
+	})
+
+	input, err := policyCheck.(*check).gatherInput(context.Background(), i)
+	require.NoError(t, err)
+
+	document, err := input.toDocument()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"repository":    "https://github.com/grafeas/voucher",
+		"commit":        "",
+		"build_creator": "",
+		"build_url":     "",
+		"project_id":    "test",
+		"artifacts":     []interface{}{},
+	}, document["build_detail"])
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":              "CVE-2020-1234",
+			"description":       "",
+			"severity":          float64(voucher.HighSeverity),
+			"fixed_by":          "",
+			"cvss_score":        float64(0),
+			"cvss_vector":       "",
+			"package_name":      "",
+			"package_version":   "",
+			"urls":              []interface{}{},
+			"scanners":          []interface{}{},
+			"suppressed":        false,
+			"suppressed_reason": "",
+			"vex_status":        "",
+			"vex_justification": "",
+		},
+	}, document["vulnerabilities"])
+
+	assert.Equal(t, map[string]interface{}{
+		"url":                      "",
+		"status":                   "",
+		"is_signed":                true,
+		"checks":                   []interface{}{},
+		"associated_pull_requests": []interface{}{},
+	}, document["commit"])
+
+	config, ok := document["config"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Contains(t, config, "user")
+	assert.Contains(t, config, "env")
+	assert.Contains(t, config, "cmd")
+}
+
+func TestPolicyCheckWithoutClients(t *testing.T) {
+	i := vtesting.NewTestReference(t)
+
+	evaluator, err := NewEvaluator(context.Background(), Source{
+		Language: CEL,
+		Filename: "test.cel",
+		Policy:   `input.build_detail == null && input.config == null && input.digest.startsWith("sha256:")`,
+	})
+	require.NoError(t, err)
+
+	pass, err := NewPolicyCheckFactory("test_policy", evaluator)().Check(context.Background(), i)
+	require.NoError(t, err)
+	assert.True(t, pass, "check failed when it should have passed")
+}
+
+func TestInvalidPolicies(t *testing.T) {
+	for _, source := range []Source{
+		{Language: "python", Filename: "test.py", Policy: "True"},
+		{Language: CEL, Filename: "test.cel", Policy: "input.config.user =="},
+		{Language: CEL, Filename: "test.cel", Policy: "1 + 1"},
+		{Language: Rego, Filename: "test.rego", Policy: "package voucher\n\nallow {"},
+	} {
+		_, err := NewEvaluator(context.Background(), source)
+		assert.Errorf(t, err, "expected %s policy \"%s\" to be invalid", source.Language, source.Policy)
+	}
+}
@@ -0,0 +1,41 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+)
+
+// Languages that policies can be written in.
+const (
+	CEL  = "cel"
+	Rego = "rego"
+)
+
+// DefaultRegoQuery is the query used to evaluate Rego policies when no other
+// query is configured.
+const DefaultRegoQuery = "data.voucher.allow"
+
+// Evaluator evaluates a policy against an Input document, returning true if
+// the document is allowed by the policy.
+type Evaluator interface {
+	Evaluate(ctx context.Context, input map[string]interface{}) (bool, error)
+}
+
+// Source describes a policy, and the language it is written in.
+type Source struct {
+	Language string // The language of the policy, either CEL or Rego.
+	Filename string // The name of the file that the policy was loaded from.
+	Policy   string // The text of the policy.
+	Query    string // For Rego policies, the query which decides the result.
+}
+
+// NewEvaluator compiles the passed Source into an Evaluator.
+func NewEvaluator(ctx context.Context, source Source) (Evaluator, error) {
+	switch source.Language {
+	case CEL:
+		return newCELEvaluator(source)
+	case Rego:
+		return newRegoEvaluator(ctx, source)
+	}
+	return nil, fmt.Errorf("unsupported policy language \"%s\"", source.Language)
+}
@@ -0,0 +1,254 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+
+	dockerTypes "github.com/docker/docker/api/types"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/docker"
+	"github.com/grafeas/voucher/v2/repository"
+)
+
+// Input is the document that policies are evaluated against. Fields are nil
+// if the data they describe is not available for the image. Every field is
+// snake_case, and is present even when it is empty, so that policies do not
+// depend on how Voucher's own types are encoded.
+type Input struct {
+	Image           string               `json:"image"`
+	Digest          string               `json:"digest"`
+	BuildDetail     *BuildDetailInput    `json:"build_detail"`
+	Vulnerabilities []VulnerabilityInput `json:"vulnerabilities"`
+	Config          *ConfigInput         `json:"config"`
+	Commit          *CommitInput         `json:"commit"`
+}
+
+// BuildDetailInput is the build metadata of the image.
+type BuildDetailInput struct {
+	Repository   string          `json:"repository"`
+	Commit       string          `json:"commit"`
+	BuildCreator string          `json:"build_creator"`
+	BuildURL     string          `json:"build_url"`
+	ProjectID    string          `json:"project_id"`
+	Artifacts    []ArtifactInput `json:"artifacts"`
+}
+
+// ArtifactInput is an artifact created by the build of the image.
+type ArtifactInput struct {
+	ID       string `json:"id"`
+	Checksum string `json:"checksum"`
+}
+
+// newBuildDetailInput creates a BuildDetailInput from the passed BuildDetail.
+func newBuildDetailInput(buildDetail repository.BuildDetail) *BuildDetailInput {
+	input := &BuildDetailInput{
+		Repository:   buildDetail.RepositoryURL,
+		Commit:       buildDetail.Commit,
+		BuildCreator: buildDetail.BuildCreator,
+		BuildURL:     buildDetail.BuildURL,
+		ProjectID:    buildDetail.ProjectID,
+		Artifacts:    make([]ArtifactInput, 0, len(buildDetail.Artifacts)),
+	}
+
+	for _, artifact := range buildDetail.Artifacts {
+		input.Artifacts = append(input.Artifacts, ArtifactInput{
+			ID:       artifact.ID,
+			Checksum: artifact.Checksum,
+		})
+	}
+
+	return input
+}
+
+// VulnerabilityInput is a vulnerability in the image. Severity is a number,
+// from 0 for "negligible" to 5 for "critical", as in API responses.
+type VulnerabilityInput struct {
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	Severity         voucher.Severity `json:"severity"`
+	FixedBy          string           `json:"fixed_by"`
+	CVSSScore        float32          `json:"cvss_score"`
+	CVSSVector       string           `json:"cvss_vector"`
+	PackageName      string           `json:"package_name"`
+	PackageVersion   string           `json:"package_version"`
+	URLs             []string         `json:"urls"`
+	Scanners         []string         `json:"scanners"`
+	Suppressed       bool             `json:"suppressed"`
+	SuppressedReason string           `json:"suppressed_reason"`
+	VEXStatus        string           `json:"vex_status"`
+	VEXJustification string           `json:"vex_justification"`
+}
+
+// newVulnerabilityInputs creates a VulnerabilityInput for each of the passed
+// vulnerabilities.
+func newVulnerabilityInputs(vulnerabilities []voucher.Vulnerability) []VulnerabilityInput {
+	inputs := make([]VulnerabilityInput, 0, len(vulnerabilities))
+	for _, vuln := range vulnerabilities {
+		inputs = append(inputs, VulnerabilityInput{
+			Name:             vuln.Name,
+			Description:      vuln.Description,
+			Severity:         vuln.Severity,
+			FixedBy:          vuln.FixedBy,
+			CVSSScore:        vuln.CVSSScore,
+			CVSSVector:       vuln.CVSSVector,
+			PackageName:      vuln.PackageName,
+			PackageVersion:   vuln.PackageVersion,
+			URLs:             nonNilStrings(vuln.URLs),
+			Scanners:         nonNilStrings(vuln.Scanners),
+			Suppressed:       vuln.Suppressed,
+			SuppressedReason: vuln.SuppressedReason,
+			VEXStatus:        string(vuln.VEXStatus),
+			VEXJustification: vuln.VEXJustification,
+		})
+	}
+	return inputs
+}
+
+// ConfigInput is the configuration of the image.
+type ConfigInput struct {
+	User string   `json:"user"`
+	Env  []string `json:"env"`
+	Cmd  []string `json:"cmd"`
+}
+
+// newConfigInput creates a ConfigInput from the passed image configuration.
+func newConfigInput(config dockerTypes.ExecConfig) *ConfigInput {
+	return &ConfigInput{
+		User: config.User,
+		Env:  nonNilStrings(config.Env),
+		Cmd:  nonNilStrings(config.Cmd),
+	}
+}
+
+// CommitInput is the commit that the image was built from.
+type CommitInput struct {
+	URL                    string             `json:"url"`
+	Status                 string             `json:"status"`
+	IsSigned               bool               `json:"is_signed"`
+	Checks                 []CheckRunInput    `json:"checks"`
+	AssociatedPullRequests []PullRequestInput `json:"associated_pull_requests"`
+}
+
+// CheckRunInput is the result of the check runs created for the commit by a
+// CI/CD app.
+type CheckRunInput struct {
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// PullRequestInput is a pull request which the commit is part of.
+type PullRequestInput struct {
+	BaseBranchName       string `json:"base_branch_name"`
+	HeadBranchName       string `json:"head_branch_name"`
+	IsMerged             bool   `json:"is_merged"`
+	MergeCommit          string `json:"merge_commit"`
+	HasRequiredApprovals bool   `json:"has_required_approvals"`
+}
+
+// newCommitInput creates a CommitInput from the passed Commit.
+func newCommitInput(commit repository.Commit) *CommitInput {
+	input := &CommitInput{
+		URL:                    commit.URL,
+		Status:                 commit.Status,
+		IsSigned:               commit.IsSigned,
+		Checks:                 make([]CheckRunInput, 0, len(commit.Checks)),
+		AssociatedPullRequests: make([]PullRequestInput, 0, len(commit.AssociatedPullRequests)),
+	}
+
+	for _, check := range commit.Checks {
+		input.Checks = append(input.Checks, CheckRunInput{
+			Status:     check.Status,
+			Conclusion: check.Conclusion,
+		})
+	}
+
+	for _, pullRequest := range commit.AssociatedPullRequests {
+		input.AssociatedPullRequests = append(input.AssociatedPullRequests, PullRequestInput{
+			BaseBranchName:       pullRequest.BaseBranchName,
+			HeadBranchName:       pullRequest.HeadBranchName,
+			IsMerged:             pullRequest.IsMerged,
+			MergeCommit:          pullRequest.MergeCommit.URL,
+			HasRequiredApprovals: pullRequest.HasRequiredApprovals,
+		})
+	}
+
+	return input
+}
+
+// nonNilStrings returns the passed slice, or an empty slice if it is nil, so
+// that it is encoded as an empty list rather than null.
+func nonNilStrings(values []string) []string {
+	if nil == values {
+		return []string{}
+	}
+	return values
+}
+
+// toDocument converts the Input to the generic form that policy engines
+// expect, using the same field names as its JSON encoding.
+func (input *Input) toDocument() (map[string]interface{}, error) {
+	encoded, err := json.Marshal(input)
+	if nil != err {
+		return nil, err
+	}
+
+	document := make(map[string]interface{})
+	err = json.Unmarshal(encoded, &document)
+	return document, err
+}
+
+// gatherInput collects the Input for the passed image from the Check's
+// clients. Data which the Check has no client for, or which does not exist
+// for the image, is left out.
+func (p *check) gatherInput(ctx context.Context, i voucher.ImageData) (*Input, error) {
+	input := &Input{
+		Image:           i.String(),
+		Digest:          i.Digest().String(),
+		Vulnerabilities: []VulnerabilityInput{},
+	}
+
+	var buildDetail *repository.BuildDetail
+
+	if nil != p.metadataClient {
+		detail, err := p.metadataClient.GetBuildDetail(ctx, i)
+		if nil == err {
+			buildDetail = &detail
+			input.BuildDetail = newBuildDetailInput(detail)
+		} else if !voucher.IsNoMetadataError(err) {
+			return nil, err
+		}
+	}
+
+	if nil != p.scanner {
+		vulnerabilities, err := p.scanner.Scan(ctx, i)
+		if nil == err {
+			input.Vulnerabilities = newVulnerabilityInputs(vulnerabilities)
+		} else if !voucher.IsNoMetadataError(err) {
+			return nil, err
+		}
+	}
+
+	if nil != p.repositoryClient && nil != buildDetail {
+		commit, err := p.repositoryClient.GetCommit(ctx, *buildDetail)
+		if nil != err {
+			return nil, err
+		}
+		input.Commit = newCommitInput(commit)
+	}
+
+	if nil != p.auth {
+		client, err := p.auth.ToClient(ctx, i)
+		if nil != err {
+			return nil, err
+		}
+
+		imageConfig, err := docker.RequestImageConfig(client, i)
+		if nil != err {
+			return nil, err
+		}
+		input.Config = newConfigInput(imageConfig.Config())
+	}
+
+	return input, nil
+}
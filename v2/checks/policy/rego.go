@@ -0,0 +1,52 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// regoEvaluator evaluates a query against a Rego module, which has the Input
+// document available as "input".
+type regoEvaluator struct {
+	query rego.PreparedEvalQuery
+}
+
+// newRegoEvaluator compiles the Rego module in the passed Source, and
+// prepares its query.
+func newRegoEvaluator(ctx context.Context, source Source) (Evaluator, error) {
+	query := source.Query
+	if "" == query {
+		query = DefaultRegoQuery
+	}
+
+	prepared, err := rego.New(
+		rego.Query(query),
+		rego.Module(source.Filename, source.Policy),
+	).PrepareForEval(ctx)
+	if nil != err {
+		return nil, fmt.Errorf("failed to compile %s: %s", source.Filename, err)
+	}
+
+	return &regoEvaluator{query: prepared}, nil
+}
+
+// Evaluate evaluates the query against the passed Input document. Queries
+// which are undefined for the Input do not allow it.
+func (evaluator *regoEvaluator) Evaluate(ctx context.Context, input map[string]interface{}) (bool, error) {
+	results, err := evaluator.query.Eval(ctx, rego.EvalInput(input))
+	if nil != err {
+		return false, err
+	}
+
+	if 0 == len(results) || 0 == len(results[0].Expressions) {
+		return false, nil
+	}
+
+	allowed, ok := results[0].Expressions[0].Value.(bool)
+	if !ok {
+		return false, fmt.Errorf("policy returned %v, rather than a bool", results[0].Expressions[0].Value)
+	}
+	return allowed, nil
+}
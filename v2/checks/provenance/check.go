@@ -2,7 +2,6 @@ package provenance
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
@@ -12,7 +11,7 @@ import (
 
 // ErrNoBuildData is an error returned if we can't pull any BuildData from
 // Grafeas for an image.
-var ErrNoBuildData = errors.New("no build metadata associated with this image")
+var ErrNoBuildData = voucher.NewCheckError(voucher.ErrorCodeNoBuildData, "no build metadata associated with this image")
 
 // check holds the required data for the check
 type check struct {
@@ -61,12 +60,20 @@ func (p *check) Check(ctx context.Context, i voucher.ImageData) (bool, error) {
 
 func validateProvenance(p *check, detail repository.BuildDetail) (trusted bool, err error) {
 	if !p.trustedBuildCreators[detail.BuildCreator] {
-		err = fmt.Errorf("builder identity not trusted: %s", detail.BuildCreator)
+		err = &voucher.CheckError{
+			Code:    voucher.ErrorCodeUntrustedBuilder,
+			Message: fmt.Sprintf("builder identity not trusted: %s", detail.BuildCreator),
+			Details: map[string]string{"builder_identity": detail.BuildCreator},
+		}
 		return
 	}
 
 	if !p.trustedProjects[detail.ProjectID] {
-		err = fmt.Errorf("builder project not trusted: %s", detail.ProjectID)
+		err = &voucher.CheckError{
+			Code:    voucher.ErrorCodeUntrustedProject,
+			Message: fmt.Sprintf("builder project not trusted: %s", detail.ProjectID),
+			Details: map[string]string{"project_id": detail.ProjectID},
+		}
 		return
 	}
 
@@ -35,3 +35,22 @@ func TestArtifactIsImage(t *testing.T) {
 	result := validateArtifacts(imageDataTestData, buildDetailsTestData)
 	assert.True(result)
 }
+
+func TestValidateProvenanceErrorCodes(t *testing.T) {
+	p := new(check)
+	p.SetTrustedBuildCreators([]string{builderIdentityTestData})
+	p.SetTrustedProjects([]string{"other-project"})
+
+	trusted, err := validateProvenance(p, buildDetailsTestData)
+	assert.False(t, trusted)
+	assert.EqualError(t, err, "builder project not trusted: "+projectTestData)
+	assert.Equal(t, voucher.ErrorCodeUntrustedProject, voucher.ErrorCodeOf(err))
+	assert.Equal(t, map[string]string{"project_id": projectTestData}, voucher.ErrorDetailsOf(err))
+
+	p.SetTrustedBuildCreators([]string{})
+
+	trusted, err = validateProvenance(p, buildDetailsTestData)
+	assert.False(t, trusted)
+	assert.Equal(t, voucher.ErrorCodeUntrustedBuilder, voucher.ErrorCodeOf(err))
+	assert.Equal(t, map[string]string{"builder_identity": builderIdentityTestData}, voucher.ErrorDetailsOf(err))
+}
@@ -2,19 +2,28 @@ package snakeoil
 
 import (
 	"context"
-	"errors"
+
+	log "github.com/sirupsen/logrus"
 
 	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/baseline"
+	"github.com/grafeas/voucher/v2/signer"
 )
 
 // ErrNoScanner is the error thrown when there is no SnakeoilScanner set for
 // the Snakeoil Check.
-var ErrNoScanner = errors.New("no scanner configured for snakeoil")
+var ErrNoScanner = voucher.NewCheckError(voucher.ErrorCodeNoScanner, "no scanner configured for snakeoil")
 
 // check verifies if there are any known vulnerabilities for the
 // passed image.
 type check struct {
-	scanner voucher.VulnerabilityScanner
+	scanner        voucher.VulnerabilityScanner
+	fixableOnly    bool
+	budget         voucher.VulnerabilityBudget
+	newOnly        bool
+	auth           voucher.Auth
+	metadataClient voucher.MetadataClient
+	verifier       signer.AttestationVerifier
 }
 
 // SetScanner sets the scanner that Snakeoil should use.
@@ -22,6 +31,45 @@ func (s *check) SetScanner(newScanner voucher.VulnerabilityScanner) {
 	s.scanner = newScanner
 }
 
+// SetFixableOnly sets whether Snakeoil should only fail on vulnerabilities that
+// have a fix available. Vulnerabilities without a fix are reported as warnings.
+func (s *check) SetFixableOnly(fixableOnly bool) {
+	s.fixableOnly = fixableOnly
+}
+
+// SetBudget sets the number of vulnerabilities of each Severity that an image
+// may have before Snakeoil fails. Images within budget pass, but their
+// vulnerabilities are reported as a warning.
+func (s *check) SetBudget(budget voucher.VulnerabilityBudget) {
+	s.budget = budget
+}
+
+// SetNewOnly sets whether Snakeoil should only fail on vulnerabilities that
+// were not in the most recent earlier image from the same repository that
+// was attested for Snakeoil.
+func (s *check) SetNewOnly(newOnly bool) {
+	s.newOnly = newOnly
+}
+
+// SetAuth sets the authentication system that Snakeoil uses to list the
+// images in a repository when looking for an earlier image.
+func (s *check) SetAuth(auth voucher.Auth) {
+	s.auth = auth
+}
+
+// SetMetadataClient sets the MetadataClient that Snakeoil uses to look up
+// the attestations of earlier images.
+func (s *check) SetMetadataClient(metadataClient voucher.MetadataClient) {
+	s.metadataClient = metadataClient
+}
+
+// SetAttestationVerifier sets the verifier that Snakeoil uses to check the
+// signatures of the attestations of earlier images, so that only images which
+// Voucher attested are used as a baseline.
+func (s *check) SetAttestationVerifier(verifier signer.AttestationVerifier) {
+	s.verifier = verifier
+}
+
 // Check verifies if the image has known vulnerabilities
 func (s *check) Check(ctx context.Context, i voucher.ImageData) (bool, error) {
 	if nil == s.scanner {
@@ -33,13 +81,80 @@ func (s *check) Check(ctx context.Context, i voucher.ImageData) (bool, error) {
 		return false, err
 	}
 
-	if 0 != len(vulns) {
-		return false, voucher.NewVulnerabilityError(vulns)
+	var previous voucher.ImageData
+	var removed []voucher.Vulnerability
+	if s.newOnly {
+		previous, removed, vulns = s.compareWithPrevious(ctx, i, vulns)
+	}
+
+	vulnErr := voucher.VulnerabilitiesError{Vulnerabilities: vulns}
+	if s.fixableOnly {
+		vulnErr = splitFixable(vulns)
+	}
+	if nil != previous {
+		vulnErr.Baseline = previous.String()
+		vulnErr.Removed = removed
+	}
+
+	failing := voucher.UnsuppressedVulnerabilities(vulnErr.Vulnerabilities)
+	if nil != s.budget {
+		vulnErr.Budgets = s.budget.Usage(vulnErr.Vulnerabilities)
+		if voucher.BudgetExceeded(vulnErr.Budgets) {
+			return false, vulnErr
+		}
+	} else if 0 != len(failing) {
+		return false, vulnErr
+	}
+
+	// Passing images still report their vulnerabilities, including those which
+	// were suppressed, and their budget usage.
+	if 0 != len(vulnErr.Vulnerabilities) || 0 != len(vulnErr.Warnings) || 0 != len(vulnErr.Budgets) {
+		return true, voucher.NewWarning(vulnErr)
 	}
 
 	return true, nil
 }
 
+// compareWithPrevious finds the most recent earlier image that was attested
+// for Snakeoil, and returns it along with the vulnerabilities that were
+// removed and added since it. If there is no such image, or it cannot be
+// scanned, the passed vulnerabilities are returned unchanged.
+func (s *check) compareWithPrevious(ctx context.Context, i voucher.ImageData, vulns []voucher.Vulnerability) (voucher.ImageData, []voucher.Vulnerability, []voucher.Vulnerability) {
+	if nil == s.auth || nil == s.metadataClient || nil == s.verifier {
+		log.Warning("snakeoil cannot compare with an earlier image without auth, a metadata client, and a signer which can verify attestations")
+		return nil, nil, vulns
+	}
+
+	previous, err := baseline.FindPrevious(ctx, s.auth, s.metadataClient, s.verifier, i, "snakeoil")
+	if nil != err {
+		log.Infof("checking all vulnerabilities in %s: %s", i, err)
+		return nil, nil, vulns
+	}
+
+	previousVulns, err := s.scanner.Scan(ctx, previous)
+	if nil != err {
+		log.Warningf("checking all vulnerabilities in %s, failed to scan %s: %s", i, previous, err)
+		return nil, nil, vulns
+	}
+
+	added, removed := voucher.DiffVulnerabilities(vulns, previousVulns)
+	return previous, removed, added
+}
+
+// splitFixable returns a VulnerabilitiesError with the passed vulnerabilities
+// that have a fix available, and with those that don't as warnings.
+func splitFixable(vulns []voucher.Vulnerability) voucher.VulnerabilitiesError {
+	vulnErr := voucher.VulnerabilitiesError{}
+	for _, vuln := range vulns {
+		if vuln.Fixable() {
+			vulnErr.Vulnerabilities = append(vulnErr.Vulnerabilities, vuln)
+		} else {
+			vulnErr.Warnings = append(vulnErr.Warnings, vuln)
+		}
+	}
+	return vulnErr
+}
+
 func init() {
 	voucher.RegisterCheckFactory("snakeoil", func() voucher.Check {
 		return new(check)
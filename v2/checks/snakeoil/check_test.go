@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/signer"
 	vtesting "github.com/grafeas/voucher/v2/testing"
 )
 
@@ -67,3 +69,193 @@ func TestSnakeoilWithVulnerabilities(t *testing.T) {
 	assert.Containsf(t, err.Error(), "cve-this-is-fine (negligible)", "error message is incorrectly formatted: %s", err)
 	assert.False(t, status, "check passed when it should have failed")
 }
+
+func TestSnakeoilWithSuppressedVulnerabilities(t *testing.T) {
+	check := new(check)
+
+	i, err := voucher.NewImageData("gcr.io/path/to/image@sha256:97db2bc359ccc94d3b2d6f5daa4173e9e91c513b0dcd961408adbb95ec5e5ce5")
+	require.NoErrorf(t, err, "failed to get ImageData: %s", err)
+
+	suppressed := voucher.Vulnerability{
+		Name:             "cve-the-worst",
+		Severity:         voucher.CriticalSeverity,
+		Suppressed:       true,
+		SuppressedReason: "not reachable",
+	}
+
+	check.SetScanner(vtesting.NewScanner(t, suppressed))
+
+	status, err := check.Check(context.Background(), i)
+	require.Error(t, err, "check returned no warnings, when it should have")
+	assert.True(t, voucher.IsWarning(err), "suppressed vulnerabilities were not reported as a warning")
+	assert.Equal(t, "no unsuppressed vulnerabilities (1 suppressed)", err.Error())
+	assert.Equal(t, voucher.VulnerabilitiesDetails{
+		Vulnerabilities: []voucher.Vulnerability{suppressed},
+	}, voucher.ErrorDetailsOf(err))
+	assert.True(t, status, "check failed when all vulnerabilities were suppressed")
+}
+
+func TestSnakeoilFixableOnly(t *testing.T) {
+	check := new(check)
+	check.SetFixableOnly(true)
+
+	i, err := voucher.NewImageData("gcr.io/path/to/image@sha256:97db2bc359ccc94d3b2d6f5daa4173e9e91c513b0dcd961408adbb95ec5e5ce5")
+	require.NoErrorf(t, err, "failed to get ImageData: %s", err)
+
+	unfixable := voucher.Vulnerability{
+		Name:     "cve-no-fix",
+		Severity: voucher.CriticalSeverity,
+	}
+	fixable := voucher.Vulnerability{
+		Name:     "cve-fixed",
+		Severity: voucher.HighSeverity,
+		FixedBy:  "1.2.3",
+	}
+
+	check.SetScanner(vtesting.NewScanner(t, unfixable))
+
+	status, err := check.Check(context.Background(), i)
+	require.Error(t, err, "check returned no warnings, when it should have")
+	assert.True(t, voucher.IsWarning(err), "unfixable vulnerabilities were not reported as a warning")
+	assert.Equal(t, "1 vulnerabilities have no fix available: cve-no-fix (critical)", err.Error())
+	assert.True(t, status, "check failed on an unfixable vulnerability")
+
+	check.SetScanner(vtesting.NewScanner(t, unfixable, fixable))
+
+	status, err = check.Check(context.Background(), i)
+	require.Error(t, err, "check returned no errors, when it should have")
+	assert.False(t, voucher.IsWarning(err))
+	assert.Equal(t, "vulnernable to 1 vulnerabilities: cve-fixed (high) (1 more have no fix available)", err.Error())
+	assert.Equal(t, voucher.VulnerabilitiesDetails{
+		Vulnerabilities: []voucher.Vulnerability{fixable},
+		Warnings:        []voucher.Vulnerability{unfixable},
+	}, voucher.ErrorDetailsOf(err))
+	assert.False(t, status, "check passed when it should have failed")
+}
+
+func TestSnakeoilWithBudget(t *testing.T) {
+	check := new(check)
+	check.SetBudget(voucher.VulnerabilityBudget{
+		voucher.CriticalSeverity: 0,
+		voucher.HighSeverity:     1,
+	})
+
+	i, err := voucher.NewImageData("gcr.io/path/to/image@sha256:97db2bc359ccc94d3b2d6f5daa4173e9e91c513b0dcd961408adbb95ec5e5ce5")
+	require.NoErrorf(t, err, "failed to get ImageData: %s", err)
+
+	high := voucher.Vulnerability{Name: "cve-high", Severity: voucher.HighSeverity}
+	critical := voucher.Vulnerability{Name: "cve-critical", Severity: voucher.CriticalSeverity}
+
+	check.SetScanner(vtesting.NewScanner(t))
+
+	status, err := check.Check(context.Background(), i)
+	require.Error(t, err, "check returned no warnings, when it should have")
+	assert.True(t, voucher.IsWarning(err), "budget usage was not reported as a warning")
+	assert.Equal(t, "no unsuppressed vulnerabilities; within budget", err.Error())
+	assert.True(t, status, "check failed without vulnerabilities")
+
+	check.SetScanner(vtesting.NewScanner(t, high))
+
+	status, err = check.Check(context.Background(), i)
+	require.Error(t, err, "check returned no warnings, when it should have")
+	assert.True(t, voucher.IsWarning(err), "vulnerabilities within budget were not reported as a warning")
+	assert.Equal(t, "vulnernable to 1 vulnerabilities: cve-high (high); within budget", err.Error())
+	assert.True(t, status, "check failed when it was within budget")
+
+	check.SetScanner(vtesting.NewScanner(t, high, critical))
+
+	status, err = check.Check(context.Background(), i)
+	require.Error(t, err, "check returned no errors, when it should have")
+	assert.False(t, voucher.IsWarning(err))
+	assert.Equal(t, []voucher.BudgetUsage{
+		{Severity: voucher.CriticalSeverity, Count: 1, Budget: 0, Exceeded: true},
+		{Severity: voucher.HighSeverity, Count: 1, Budget: 1},
+	}, voucher.ErrorDetailsOf(err).(voucher.VulnerabilitiesDetails).Budgets)
+	assert.False(t, status, "check passed when it was over budget")
+}
+
+// imageScanner is a VulnerabilityScanner which returns different
+// vulnerabilities for each image.
+type imageScanner map[string][]voucher.Vulnerability
+
+func (s imageScanner) FailOn(voucher.Severity) {}
+
+func (s imageScanner) Scan(ctx context.Context, i voucher.ImageData) ([]voucher.Vulnerability, error) {
+	return s[i.Digest().String()], nil
+}
+
+func TestSnakeoilWithNewOnly(t *testing.T) {
+	server := vtesting.NewTestDockerServer(t)
+	defer server.Close()
+
+	i := vtesting.NewTestReference(t)
+	previous := vtesting.NewNobodyBadTestReference(t)
+
+	kept := voucher.Vulnerability{Name: "cve-kept", Severity: voucher.HighSeverity}
+	removed := voucher.Vulnerability{Name: "cve-removed", Severity: voucher.HighSeverity}
+	added := voucher.Vulnerability{Name: "cve-added", Severity: voucher.CriticalSeverity}
+
+	scanner := imageScanner{
+		previous.Digest().String(): {kept, removed},
+		i.Digest().String():        {kept},
+	}
+
+	keyring := vtesting.NewGeneratedPGPSigner(t, "snakeoil")
+	attestation, err := voucher.SignAttestation(keyring, voucher.NewAttestation("snakeoil", "previous payload"))
+	require.NoError(t, err)
+
+	metadataClient := new(voucher.MockMetadataClient)
+	metadataClient.On("NewPayloadBody", previous).Return("previous payload", nil)
+	metadataClient.On("GetAttestations", mock.Anything, previous).Return(
+		[]voucher.SignedAttestation{attestation},
+		nil,
+	)
+
+	check := new(check)
+	check.SetScanner(scanner)
+	check.SetAuth(vtesting.NewAuth(server))
+	check.SetMetadataClient(metadataClient)
+	check.SetAttestationVerifier(keyring.(signer.AttestationVerifier))
+	check.SetNewOnly(true)
+
+	status, err := check.Check(context.Background(), i)
+	assert.NoError(t, err)
+	assert.True(t, status, "check failed on a vulnerability the previous image had")
+
+	scanner[i.Digest().String()] = []voucher.Vulnerability{kept, added}
+
+	status, err = check.Check(context.Background(), i)
+	require.Error(t, err, "check returned no errors, when it should have")
+	assert.Equal(t, voucher.VulnerabilitiesDetails{
+		Vulnerabilities: []voucher.Vulnerability{added},
+		Baseline:        previous.String(),
+		Removed:         []voucher.Vulnerability{removed},
+	}, voucher.ErrorDetailsOf(err))
+	assert.False(t, status, "check passed with a new vulnerability")
+}
+
+func TestSnakeoilWithNewOnlyWithoutBaseline(t *testing.T) {
+	server := vtesting.NewTestDockerServer(t)
+	defer server.Close()
+
+	i := vtesting.NewTestReference(t)
+
+	metadataClient := new(voucher.MockMetadataClient)
+	metadataClient.On("NewPayloadBody", mock.Anything).Return("payload", nil)
+	metadataClient.On("GetAttestations", mock.Anything, mock.Anything).Return(
+		[]voucher.SignedAttestation{},
+		nil,
+	)
+
+	check := new(check)
+	check.SetScanner(vtesting.NewScanner(t, voucher.Vulnerability{Name: "cve-old", Severity: voucher.HighSeverity}))
+	check.SetAuth(vtesting.NewAuth(server))
+	check.SetMetadataClient(metadataClient)
+	check.SetAttestationVerifier(vtesting.NewGeneratedPGPSigner(t, "snakeoil").(signer.AttestationVerifier))
+	check.SetNewOnly(true)
+
+	status, err := check.Check(context.Background(), i)
+	require.Error(t, err, "check returned no errors, when it should have")
+	assert.Equal(t, "vulnernable to 1 vulnerabilities: cve-old (high)", err.Error())
+	assert.False(t, status, "check passed without an earlier image to compare with")
+}
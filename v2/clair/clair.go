@@ -106,7 +106,7 @@ func getLayerFromClair(config Config, digest digest.Digest) (layer v1.Layer, err
 	return
 }
 
-func checkParentDigest(parent digest.Digest, config Config, vulns map[string]v1.Vulnerability) (map[string]v1.Vulnerability, error) {
+func checkParentDigest(parent digest.Digest, config Config, vulns map[string]vulnerability) (map[string]vulnerability, error) {
 	if "" != string(parent) {
 		var layer v1.Layer
 
@@ -121,7 +121,11 @@ func checkParentDigest(parent digest.Digest, config Config, vulns map[string]v1.
 
 		for _, feature := range layer.Features {
 			for _, vul := range feature.Vulnerabilities {
-				vulns[vul.Name] = vul
+				vulns[vul.Name] = vulnerability{
+					Vulnerability:  vul,
+					PackageName:    feature.Name,
+					PackageVersion: feature.Version,
+				}
 			}
 		}
 	} else {
@@ -131,10 +135,10 @@ func checkParentDigest(parent digest.Digest, config Config, vulns map[string]v1.
 	return vulns, nil
 }
 
-// getClairVulnerabilities gets a map[string]v1.Vulnerability from Clair, so that we can convert
+// getClairVulnerabilities gets a map[string]vulnerability from Clair, so that we can convert
 // them to Voucher Vulnerabilities all at once.
-func getClairVulnerabilities(manifest distribution.Manifest, config Config, tokenSrc oauth2.TokenSource, image reference.Canonical) (map[string]v1.Vulnerability, error) {
-	var vulns map[string]v1.Vulnerability
+func getClairVulnerabilities(manifest distribution.Manifest, config Config, tokenSrc oauth2.TokenSource, image reference.Canonical) (map[string]vulnerability, error) {
+	var vulns map[string]vulnerability
 
 	var err error
 
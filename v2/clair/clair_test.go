@@ -20,7 +20,7 @@ func TestGetClairVulnerabilitesSchema1(t *testing.T) {
 	defer clairServer.Close()
 
 	clairVulns, err := getClairVulnerabilities(vtesting.NewTestSchema1SignedManifest(vtesting.NewPrivateKey()), createClairConfig(clairServer.URL), tokenSrc, img)
-	voucherVulns := convertToVoucherVulnerabilities(clairVulns, voucher.MediumSeverity)
+	voucherVulns := convertToVoucherVulnerabilities(clairVulns, voucher.MediumSeverity, 0)
 
 	assert.Nil(t, err)
 	assert.Equal(t, 3, len(voucherVulns))
@@ -32,7 +32,7 @@ func TestGetClairVulnerabilitesSchema2(t *testing.T) {
 	defer clairServer.Close()
 
 	clairVulns, err := getClairVulnerabilities(vtesting.NewTestManifest(), createClairConfig(clairServer.URL), tokenSrc, img)
-	voucherVulns := convertToVoucherVulnerabilities(clairVulns, voucher.MediumSeverity)
+	voucherVulns := convertToVoucherVulnerabilities(clairVulns, voucher.MediumSeverity, 0)
 
 	assert.Nil(t, err)
 	assert.Equal(t, 3, len(voucherVulns))
@@ -44,13 +44,26 @@ func TestFilterClairVulnerabilities(t *testing.T) {
 	defer clairServer.Close()
 
 	clairVulns, err := getClairVulnerabilities(vtesting.NewTestManifest(), createClairConfig(clairServer.URL), tokenSrc, img)
-	voucherVulns := convertToVoucherVulnerabilities(clairVulns, voucher.HighSeverity)
+	voucherVulns := convertToVoucherVulnerabilities(clairVulns, voucher.HighSeverity, 0)
 
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(voucherVulns))
 	require.Equal(t, VoucherVulnerabilities("high"), voucherVulns)
 }
 
+func TestFilterClairVulnerabilitiesByCVSS(t *testing.T) {
+	img, tokenSrc, clairServer := PrepareClairTest(t, ClairVulnerabilities())
+	defer clairServer.Close()
+
+	clairVulns, err := getClairVulnerabilities(vtesting.NewTestManifest(), createClairConfig(clairServer.URL), tokenSrc, img)
+	require.NoError(t, err)
+
+	// Only "Super bad vul 2 meow" has a CVSS score, the others fall back to
+	// their severity.
+	require.ElementsMatch(t, VoucherVulnerabilities("medium", "high"), convertToVoucherVulnerabilities(clairVulns, voucher.MediumSeverity, 7.0))
+	require.ElementsMatch(t, VoucherVulnerabilities("medium"), convertToVoucherVulnerabilities(clairVulns, voucher.MediumSeverity, 8.0))
+}
+
 func createClairConfig(hostname string) Config {
 	return Config{
 		Hostname: hostname,
@@ -2,10 +2,7 @@ package clair
 
 import (
 	"context"
-
-	"github.com/docker/distribution"
-	"github.com/docker/distribution/reference"
-	"golang.org/x/oauth2"
+	"time"
 
 	voucher "github.com/grafeas/voucher/v2"
 	"github.com/grafeas/voucher/v2/docker"
@@ -13,9 +10,11 @@ import (
 
 // Scanner implements the interface SnakeoilScanner.
 type Scanner struct {
-	config Config
-	failOn voucher.Severity
-	auth   voucher.Auth
+	config     Config
+	failOn     voucher.Severity
+	failOnCVSS float32
+	auth       voucher.Auth
+	allowlist  voucher.Allowlist
 }
 
 // FailOn sets severity level that a vulnerability must match or exheed to
@@ -24,6 +23,17 @@ func (scanner *Scanner) FailOn(severity voucher.Severity) {
 	scanner.failOn = severity
 }
 
+// FailOnCVSS sets the CVSS score that a vulnerability must match or exceed to
+// prompt a failure.
+func (scanner *Scanner) FailOnCVSS(score float32) {
+	scanner.failOnCVSS = score
+}
+
+// SetAllowlist sets the Allowlist to suppress vulnerabilities with.
+func (scanner *Scanner) SetAllowlist(allowlist voucher.Allowlist) {
+	scanner.allowlist = allowlist
+}
+
 // Scan runs a scan in the Clair namespace.
 func (scanner *Scanner) Scan(ctx context.Context, i voucher.ImageData) ([]voucher.Vulnerability, error) {
 	vulns := make([]voucher.Vulnerability, 0)
@@ -33,7 +43,12 @@ func (scanner *Scanner) Scan(ctx context.Context, i voucher.ImageData) ([]vouche
 		return vulns, err
 	}
 
-	manifest, err := getDockerManifest(ctx, tokenSrc, i)
+	client, err := scanner.auth.ToClient(ctx, i)
+	if nil != err {
+		return vulns, err
+	}
+
+	manifest, err := docker.RequestManifest(client, i)
 	if nil != err {
 		return vulns, err
 	}
@@ -43,7 +58,9 @@ func (scanner *Scanner) Scan(ctx context.Context, i voucher.ImageData) ([]vouche
 		return vulns, err
 	}
 
-	return convertToVoucherVulnerabilities(clairVulns, scanner.failOn), nil
+	vulns = convertToVoucherVulnerabilities(clairVulns, scanner.failOn, scanner.failOnCVSS)
+
+	return scanner.allowlist.Suppress(vulns, i, time.Now()), nil
 }
 
 // SetBasicAuth sets the username and password to use for Basic Auth,
@@ -63,8 +80,3 @@ func NewScanner(config Config, auth voucher.Auth) *Scanner {
 
 	return scanner
 }
-
-func getDockerManifest(ctx context.Context, tokenSrc oauth2.TokenSource, image reference.Canonical) (distribution.Manifest, error) {
-	client := oauth2.NewClient(ctx, tokenSrc)
-	return docker.RequestManifest(client, image)
-}
@@ -1,15 +1,14 @@
 package clair
 
 import (
-	v1 "github.com/coreos/clair/api/v1"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/distribution/reference"
 	digest "github.com/opencontainers/go-digest"
 	"golang.org/x/oauth2"
 )
 
-func getSchema2Layers(m schema2.Manifest, config Config, tokenSrc oauth2.TokenSource, image reference.Canonical, parent digest.Digest) (map[string]v1.Vulnerability, error) {
-	vulns := make(map[string]v1.Vulnerability)
+func getSchema2Layers(m schema2.Manifest, config Config, tokenSrc oauth2.TokenSource, image reference.Canonical, parent digest.Digest) (map[string]vulnerability, error) {
+	vulns := make(map[string]vulnerability)
 
 	var err error
 
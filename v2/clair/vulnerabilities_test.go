@@ -35,6 +35,15 @@ func ClairVulnerabilities() map[string][]v1.Vulnerability {
 				Description: "meow meow",
 				FixedBy:     "Hackercat",
 				Severity:    "High",
+				Link:        "https://security-tracker.debian.org/tracker/meow",
+				Metadata: map[string]interface{}{
+					"NVD": map[string]interface{}{
+						"CVSSv2": map[string]interface{}{
+							"Score":   7.5,
+							"Vectors": "AV:N/AC:L/Au:N/C:P/I:P/A:P",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -73,6 +82,9 @@ func VoucherVulnerabilities(keys ...string) []voucher.Vulnerability {
 				Description: "meow meow",
 				FixedBy:     "Hackercat",
 				Severity:    voucher.HighSeverity,
+				CVSSScore:   7.5,
+				CVSSVector:  "AV:N/AC:L/Au:N/C:P/I:P/A:P",
+				URLs:        []string{"https://security-tracker.debian.org/tracker/meow"},
 			},
 		},
 	}
@@ -1,12 +1,33 @@
 package clair
 
 import (
+	"encoding/json"
+
 	v1 "github.com/coreos/clair/api/v1"
 	clairdb "github.com/coreos/clair/database"
 
 	voucher "github.com/grafeas/voucher/v2"
 )
 
+// vulnerability is a Clair Vulnerability, along with the name and version of
+// the package (Feature, in Clair) which it was found in.
+type vulnerability struct {
+	v1.Vulnerability
+	PackageName    string
+	PackageVersion string
+}
+
+// nvdMetadata is the part of a Clair Vulnerability's metadata which is
+// populated from the National Vulnerability Database.
+type nvdMetadata struct {
+	NVD struct {
+		CVSSv2 struct {
+			Score   float32 `json:"Score"`
+			Vectors string  `json:"Vectors"`
+		} `json:"CVSSv2"`
+	} `json:"NVD"`
+}
+
 // getSeverity converts a Clair serverity into a Voucher serverity.
 func getSeverity(severity string) voucher.Severity {
 	switch clairdb.Severity(severity) {
@@ -24,29 +45,56 @@ func getSeverity(severity string) voucher.Severity {
 	return voucher.UnknownSeverity
 }
 
+// getNVDMetadata returns the NVD metadata of the passed Clair Vulnerability.
+// If the metadata is missing or malformed, the returned metadata is empty.
+func getNVDMetadata(clairVuln v1.Vulnerability) (metadata nvdMetadata) {
+	if nil == clairVuln.Metadata {
+		return
+	}
+
+	data, err := json.Marshal(clairVuln.Metadata)
+	if nil != err {
+		return
+	}
+
+	_ = json.Unmarshal(data, &metadata)
+	return
+}
+
 // vulnerabilityToVoucherVulnerability converts a Clair Vulnerability to
 // a Voucher Vulnerability.
-func vulnerabilityToVoucherVulnerability(clairVuln v1.Vulnerability) voucher.Vulnerability {
+func vulnerabilityToVoucherVulnerability(clairVuln vulnerability) voucher.Vulnerability {
 	severity := getSeverity(clairVuln.Severity)
+	metadata := getNVDMetadata(clairVuln.Vulnerability)
 
-	return voucher.Vulnerability{
-		Name:        clairVuln.Name,
-		Description: clairVuln.Description,
-		FixedBy:     clairVuln.FixedBy,
-		Severity:    severity,
+	vuln := voucher.Vulnerability{
+		Name:           clairVuln.Name,
+		Description:    clairVuln.Description,
+		FixedBy:        clairVuln.FixedBy,
+		Severity:       severity,
+		CVSSScore:      metadata.NVD.CVSSv2.Score,
+		CVSSVector:     metadata.NVD.CVSSv2.Vectors,
+		PackageName:    clairVuln.PackageName,
+		PackageVersion: clairVuln.PackageVersion,
 	}
+
+	if "" != clairVuln.Link {
+		vuln.URLs = []string{clairVuln.Link}
+	}
+
+	return vuln
 }
 
 // convertToVoucherVulnerabilities convert a list of clair vulnerabilities to
 // voucher vulnerabilities
-func convertToVoucherVulnerabilities(clairVulns map[string]v1.Vulnerability, failOnSeverity voucher.Severity) []voucher.Vulnerability {
+func convertToVoucherVulnerabilities(clairVulns map[string]vulnerability, failOnSeverity voucher.Severity, failOnCVSS float32) []voucher.Vulnerability {
 	vulns := make([]voucher.Vulnerability, 0, len(clairVulns))
 	for _, clairVuln := range clairVulns {
 		if "" == clairVuln.Name {
 			continue
 		}
 		vuln := vulnerabilityToVoucherVulnerability(clairVuln)
-		if voucher.ShouldIncludeVulnerability(vuln, failOnSeverity) {
+		if voucher.ShouldIncludeVulnerabilityWithCVSS(vuln, failOnSeverity, failOnCVSS) {
 			vulns = append(vulns, vuln)
 		}
 	}
@@ -0,0 +1,108 @@
+package clairv4
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/reference"
+	digest "github.com/opencontainers/go-digest"
+	"golang.org/x/oauth2"
+
+	"github.com/grafeas/voucher/v2/clair"
+	"github.com/grafeas/voucher/v2/docker"
+	dockerURI "github.com/grafeas/voucher/v2/docker/uri"
+)
+
+// newManifest creates a Manifest describing the passed image, with the passed
+// token used to authorize Clair's requests for its layers.
+func newManifest(image reference.Canonical, manifest distribution.Manifest, token *oauth2.Token) (Manifest, error) {
+	digests, err := docker.LayerDigests(manifest)
+	if nil != err {
+		return Manifest{}, err
+	}
+
+	clairManifest := Manifest{
+		Hash:   string(image.Digest()),
+		Layers: make([]Layer, 0, len(digests)),
+	}
+
+	for _, layerDigest := range digests {
+		clairManifest.Layers = append(clairManifest.Layers, Layer{
+			Hash: string(layerDigest),
+			URI:  dockerURI.GetBlobURI(image, layerDigest),
+			Headers: map[string][]string{
+				"Authorization": {token.Type() + " " + token.AccessToken},
+			},
+		})
+	}
+
+	return clairManifest, nil
+}
+
+// doRequest sends the passed request to Clair, authorizing it with the passed
+// Config, and decodes the response into the passed value.
+func doRequest(ctx context.Context, config clair.Config, request *http.Request, v interface{}) error {
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json")
+
+	if config.UseBasicAuth() {
+		config.UpdateRequest(request)
+	}
+
+	resp, err := http.DefaultClient.Do(request)
+	if nil != err {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if 300 <= resp.StatusCode {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("request to %s failed: status code %d, %s", request.URL.Path, resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// indexManifest submits the passed Manifest to Clair's indexer, and waits for
+// it to be indexed.
+func indexManifest(ctx context.Context, config clair.Config, manifest Manifest) error {
+	var buffer bytes.Buffer
+	if err := json.NewEncoder(&buffer).Encode(manifest); nil != err {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, getIndexReportURI(config.Hostname), &buffer)
+	if nil != err {
+		return err
+	}
+
+	var report IndexReport
+	if err = doRequest(ctx, config, request, &report); nil != err {
+		return err
+	}
+
+	if !report.Success {
+		return fmt.Errorf("indexing %s failed in state %s: %s", manifest.Hash, report.State, report.Err)
+	}
+
+	return nil
+}
+
+// getVulnerabilityReport gets the VulnerabilityReport for the passed manifest
+// digest from Clair's matcher.
+func getVulnerabilityReport(ctx context.Context, config clair.Config, manifest digest.Digest) (VulnerabilityReport, error) {
+	var report VulnerabilityReport
+
+	request, err := http.NewRequest(http.MethodGet, getVulnerabilityReportURI(config.Hostname, manifest), nil)
+	if nil != err {
+		return report, err
+	}
+
+	err = doRequest(ctx, config, request, &report)
+	return report, err
+}
@@ -0,0 +1,101 @@
+package clairv4
+
+import (
+	"context"
+	"time"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/clair"
+	"github.com/grafeas/voucher/v2/docker"
+)
+
+// Scanner implements voucher.VulnerabilityScanner, and connects to the
+// indexer and matcher of Clair v4.
+type Scanner struct {
+	config     clair.Config
+	failOn     voucher.Severity
+	failOnCVSS float32
+	auth       voucher.Auth
+	allowlist  voucher.Allowlist
+}
+
+// FailOn sets severity level that a vulnerability must match or exheed to
+// prompt a failure.
+func (scanner *Scanner) FailOn(severity voucher.Severity) {
+	scanner.failOn = severity
+}
+
+// FailOnCVSS sets the CVSS score that a vulnerability must match or exceed to
+// prompt a failure. Clair only reports CVSS scores when its CVSS enricher is
+// enabled, so vulnerabilities without one are compared against FailOn.
+func (scanner *Scanner) FailOnCVSS(score float32) {
+	scanner.failOnCVSS = score
+}
+
+// SetAllowlist sets the Allowlist to suppress vulnerabilities with.
+func (scanner *Scanner) SetAllowlist(allowlist voucher.Allowlist) {
+	scanner.allowlist = allowlist
+}
+
+// Scan submits the passed image's manifest to Clair's indexer, and returns the
+// vulnerabilities in Clair's vulnerability report for it. If the image is an
+// image index, the image in it for docker.DefaultPlatform is scanned.
+func (scanner *Scanner) Scan(ctx context.Context, i voucher.ImageData) ([]voucher.Vulnerability, error) {
+	vulns := make([]voucher.Vulnerability, 0)
+
+	client, err := scanner.auth.ToClient(ctx, i)
+	if nil != err {
+		return vulns, err
+	}
+
+	tokenSrc, err := scanner.auth.GetTokenSource(ctx, i)
+	if nil != err {
+		return vulns, err
+	}
+
+	token, err := tokenSrc.Token()
+	if nil != err {
+		return vulns, err
+	}
+
+	image, manifest, err := docker.RequestImageManifest(client, i)
+	if nil != err {
+		return vulns, err
+	}
+
+	clairManifest, err := newManifest(image, manifest, token)
+	if nil != err {
+		return vulns, err
+	}
+
+	if err = indexManifest(ctx, scanner.config, clairManifest); nil != err {
+		return vulns, err
+	}
+
+	report, err := getVulnerabilityReport(ctx, scanner.config, image.Digest())
+	if nil != err {
+		return vulns, err
+	}
+
+	vulns = convertToVoucherVulnerabilities(report, scanner.failOn, scanner.failOnCVSS)
+
+	return scanner.allowlist.Suppress(vulns, i, time.Now()), nil
+}
+
+// SetBasicAuth sets the username and password to use for Basic Auth,
+// and enforces the use of Basic Auth for new connections.
+func (scanner *Scanner) SetBasicAuth(username, password string) {
+	scanner.config.Username = username
+	scanner.config.Password = password
+}
+
+// NewScanner creates a new Scanner.
+func NewScanner(config clair.Config, auth voucher.Auth) *Scanner {
+	scanner := new(Scanner)
+
+	scanner.config = config
+
+	scanner.auth = auth
+
+	return scanner
+}
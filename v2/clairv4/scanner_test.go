@@ -0,0 +1,169 @@
+package clairv4
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/clair"
+	vtesting "github.com/grafeas/voucher/v2/testing"
+)
+
+func newTestReport() VulnerabilityReport {
+	return VulnerabilityReport{
+		ManifestHash: "sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9",
+		Packages: map[string]Package{
+			"1": {ID: "1", Name: "openssl", Version: "1.1.1d-0+deb10u2"},
+			"2": {ID: "2", Name: "bash", Version: "5.0-4"},
+		},
+		Vulnerabilities: map[string]Vulnerability{
+			"10": {
+				ID:                 "10",
+				Name:               "CVE-2020-1967",
+				Description:        "Segmentation fault in SSL_check_chain",
+				Links:              "https://security-tracker.debian.org/tracker/CVE-2020-1967 https://www.openssl.org/news/secadv/20200421.txt",
+				NormalizedSeverity: "High",
+				FixedInVersion:     "1.1.1d-0+deb10u3",
+			},
+			"11": {
+				ID:                 "11",
+				Name:               "CVE-2019-18276",
+				NormalizedSeverity: "Low",
+			},
+		},
+		PackageVulnerabilities: map[string][]string{
+			"1": {"10"},
+			"2": {"11"},
+		},
+	}
+}
+
+func newTestScanner(t *testing.T, reports map[string]interface{}) (*Scanner, func()) {
+	dockerServer := vtesting.NewTestDockerServer(t)
+	clairServer := vtesting.NewTestClairV4Server(t, reports)
+
+	scanner := NewScanner(clair.Config{Hostname: clairServer.URL}, vtesting.NewAuth(dockerServer))
+	scanner.SetBasicAuth("shopifolk", "shopify")
+
+	return scanner, func() {
+		dockerServer.Close()
+		clairServer.Close()
+	}
+}
+
+func TestScanner(t *testing.T) {
+	i := vtesting.NewTestReference(t)
+
+	scanner, closeServers := newTestScanner(t, map[string]interface{}{
+		i.Digest().String(): newTestReport(),
+	})
+	defer closeServers()
+
+	scanner.FailOn(voucher.LowSeverity)
+
+	vulns, err := scanner.Scan(context.Background(), i)
+	require.NoError(t, err)
+	assert.Equal(t, []voucher.Vulnerability{
+		{
+			Name:           "CVE-2019-18276",
+			Severity:       voucher.LowSeverity,
+			PackageName:    "bash",
+			PackageVersion: "5.0-4",
+		},
+		{
+			Name:           "CVE-2020-1967",
+			Description:    "Segmentation fault in SSL_check_chain",
+			Severity:       voucher.HighSeverity,
+			FixedBy:        "1.1.1d-0+deb10u3",
+			PackageName:    "openssl",
+			PackageVersion: "1.1.1d-0+deb10u2",
+			URLs:           []string{"https://security-tracker.debian.org/tracker/CVE-2020-1967", "https://www.openssl.org/news/secadv/20200421.txt"},
+		},
+	}, vulns)
+
+	scanner.FailOn(voucher.HighSeverity)
+
+	vulns, err = scanner.Scan(context.Background(), i)
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	assert.Equal(t, "CVE-2020-1967", vulns[0].Name)
+}
+
+func TestScannerWithCVSS(t *testing.T) {
+	i := vtesting.NewTestReference(t)
+
+	report := newTestReport()
+	report.Enrichments = map[string][]json.RawMessage{
+		cvssEnrichmentType + " schema=https://csrc.nist.gov/schema/nvd/feed/1.1/cvss-v3.x.json": {
+			json.RawMessage(`{"11": [{"version": "3.1", "vectorString": "CVSS:3.1/AV:L/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", "baseScore": 8.4}]}`),
+		},
+	}
+
+	scanner, closeServers := newTestScanner(t, map[string]interface{}{
+		i.Digest().String(): report,
+	})
+	defer closeServers()
+
+	scanner.FailOn(voucher.HighSeverity)
+	scanner.FailOnCVSS(8.0)
+
+	vulns, err := scanner.Scan(context.Background(), i)
+	require.NoError(t, err)
+	require.Len(t, vulns, 2, "a low severity vulnerability with a high CVSS score should be included")
+	assert.Equal(t, "CVE-2019-18276", vulns[0].Name)
+	assert.Equal(t, float32(8.4), vulns[0].CVSSScore)
+	assert.Equal(t, "CVSS:3.1/AV:L/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", vulns[0].CVSSVector)
+	assert.Equal(t, "CVE-2020-1967", vulns[1].Name, "vulnerabilities without a CVSS score should be compared to the severity")
+}
+
+func TestScannerWithIndex(t *testing.T) {
+	report := newTestReport()
+
+	scanner, closeServers := newTestScanner(t, map[string]interface{}{
+		report.ManifestHash: report,
+	})
+	defer closeServers()
+
+	scanner.FailOn(voucher.LowSeverity)
+
+	vulns, err := scanner.Scan(context.Background(), vtesting.NewTestIndexReference(t))
+	require.NoError(t, err, "the image for the default platform should be scanned")
+	assert.Len(t, vulns, 2)
+}
+
+func TestScannerWithOCIManifest(t *testing.T) {
+	i := vtesting.NewTestOCIReference(t)
+
+	scanner, closeServers := newTestScanner(t, map[string]interface{}{
+		i.Digest().String(): newTestReport(),
+	})
+	defer closeServers()
+
+	scanner.FailOn(voucher.LowSeverity)
+
+	vulns, err := scanner.Scan(context.Background(), i)
+	require.NoError(t, err)
+	assert.Len(t, vulns, 2)
+}
+
+func TestScannerWithoutReport(t *testing.T) {
+	scanner, closeServers := newTestScanner(t, map[string]interface{}{})
+	defer closeServers()
+
+	_, err := scanner.Scan(context.Background(), vtesting.NewTestReference(t))
+	assert.Error(t, err)
+}
+
+func TestScannerWithBadCredentials(t *testing.T) {
+	scanner, closeServers := newTestScanner(t, map[string]interface{}{})
+	defer closeServers()
+
+	scanner.SetBasicAuth("shopifolk", "wrong")
+
+	_, err := scanner.Scan(context.Background(), vtesting.NewTestReference(t))
+	assert.Error(t, err)
+}
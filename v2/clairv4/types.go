@@ -0,0 +1,66 @@
+package clairv4
+
+import "encoding/json"
+
+// Manifest describes an image to Clair's indexer, as its digest and a list of
+// layers that Clair can download.
+type Manifest struct {
+	Hash   string  `json:"hash"`
+	Layers []Layer `json:"layers"`
+}
+
+// Layer describes a layer of an image, along with the URI that Clair can
+// download it from, and the headers to download it with.
+type Layer struct {
+	Hash    string              `json:"hash"`
+	URI     string              `json:"uri"`
+	Headers map[string][]string `json:"headers"`
+}
+
+// IndexReport is the response from Clair's indexer.
+type IndexReport struct {
+	ManifestHash string `json:"manifest_hash"`
+	State        string `json:"state"`
+	Success      bool   `json:"success"`
+	Err          string `json:"err"`
+}
+
+// VulnerabilityReport is the response from Clair's matcher, which lists the
+// vulnerabilities in each package of an image. Enrichments are added by
+// Clair's enrichers, keyed by their media types.
+type VulnerabilityReport struct {
+	ManifestHash           string                       `json:"manifest_hash"`
+	Packages               map[string]Package           `json:"packages"`
+	Vulnerabilities        map[string]Vulnerability     `json:"vulnerabilities"`
+	PackageVulnerabilities map[string][]string          `json:"package_vulnerabilities"`
+	Enrichments            map[string][]json.RawMessage `json:"enrichments,omitempty"`
+}
+
+// cvssEnrichmentType is the start of the media type of the enrichments added
+// by Clair's CVSS enricher, which map vulnerability IDs to their CVSS scores.
+const cvssEnrichmentType = "message/vnd.clair.map.vulnerability; enricher=clair.cvss"
+
+// CVSS is a CVSS v3 score added by Clair's CVSS enricher, in the format of
+// NVD's JSON feeds.
+type CVSS struct {
+	VectorString string  `json:"vectorString"`
+	BaseScore    float32 `json:"baseScore"`
+}
+
+// Package is a package that Clair found in an image.
+type Package struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Vulnerability is a vulnerability that Clair matched to a package.
+type Vulnerability struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Description        string `json:"description"`
+	Links              string `json:"links"`
+	Severity           string `json:"severity"`
+	NormalizedSeverity string `json:"normalized_severity"`
+	FixedInVersion     string `json:"fixed_in_version"`
+}
@@ -0,0 +1,30 @@
+package clairv4
+
+import (
+	"path"
+	"strings"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// getIndexReportURI gets the URI of the indexer's index report endpoint on the
+// passed hostname.
+func getIndexReportURI(hostname string) string {
+	return createURI(hostname, "indexer/api/v1/index_report")
+}
+
+// getVulnerabilityReportURI gets the URI of the matcher's vulnerability report
+// for the passed manifest digest on the passed hostname.
+func getVulnerabilityReportURI(hostname string, manifest digest.Digest) string {
+	return createURI(hostname, "matcher/api/v1/vulnerability_report", string(manifest))
+}
+
+// createURI creates a new Clair URI based on the passed hostname. This will
+// automatically add "https://" if a protocol is omitted.
+func createURI(hostname string, pieces ...string) string {
+	if !strings.HasPrefix(hostname, "https://") && !strings.HasPrefix(hostname, "http://") {
+		hostname = "https://" + hostname
+	}
+
+	return strings.TrimSuffix(hostname, "/") + "/" + path.Join(pieces...)
+}
@@ -0,0 +1,111 @@
+package clairv4
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// getSeverity converts a Clair normalized severity into a Voucher severity.
+func getSeverity(severity string) voucher.Severity {
+	switch strings.ToLower(severity) {
+	case "negligible":
+		return voucher.NegligibleSeverity
+	case "low":
+		return voucher.LowSeverity
+	case "medium":
+		return voucher.MediumSeverity
+	case "high":
+		return voucher.HighSeverity
+	case "critical":
+		return voucher.CriticalSeverity
+	}
+	return voucher.UnknownSeverity
+}
+
+// toVoucherVulnerability converts a Clair Vulnerability in the passed Package
+// to a Voucher Vulnerability.
+func toVoucherVulnerability(vuln Vulnerability, pkg Package) voucher.Vulnerability {
+	converted := voucher.Vulnerability{
+		Name:           vuln.Name,
+		Description:    vuln.Description,
+		Severity:       getSeverity(vuln.NormalizedSeverity),
+		FixedBy:        vuln.FixedInVersion,
+		PackageName:    pkg.Name,
+		PackageVersion: pkg.Version,
+	}
+
+	if links := strings.Fields(vuln.Links); 0 < len(links) {
+		converted.URLs = links
+	}
+
+	return converted
+}
+
+// getCVSSScores returns the CVSS scores in the CVSS enrichments of the passed
+// VulnerabilityReport, keyed by vulnerability ID. Enrichments which cannot be
+// decoded are ignored.
+func getCVSSScores(report VulnerabilityReport) map[string]CVSS {
+	scores := make(map[string]CVSS)
+
+	for mediaType, enrichments := range report.Enrichments {
+		if !strings.HasPrefix(mediaType, cvssEnrichmentType) {
+			continue
+		}
+
+		for _, enrichment := range enrichments {
+			var byID map[string][]CVSS
+			if err := json.Unmarshal(enrichment, &byID); nil != err {
+				continue
+			}
+
+			for vulnID, cvss := range byID {
+				if _, ok := scores[vulnID]; !ok && 0 < len(cvss) {
+					scores[vulnID] = cvss[0]
+				}
+			}
+		}
+	}
+
+	return scores
+}
+
+// convertToVoucherVulnerabilities converts the vulnerabilities in the passed
+// VulnerabilityReport to Voucher Vulnerabilities, sorted by package and name.
+// Vulnerabilities are included if they match or exceed the passed CVSS score,
+// or the passed Severity if they do not have one.
+func convertToVoucherVulnerabilities(report VulnerabilityReport, failOn voucher.Severity, failOnCVSS float32) []voucher.Vulnerability {
+	vulns := make([]voucher.Vulnerability, 0)
+	scores := getCVSSScores(report)
+
+	for packageID, vulnIDs := range report.PackageVulnerabilities {
+		pkg := report.Packages[packageID]
+		for _, vulnID := range vulnIDs {
+			clairVuln, ok := report.Vulnerabilities[vulnID]
+			if !ok || "" == clairVuln.Name {
+				continue
+			}
+
+			vuln := toVoucherVulnerability(clairVuln, pkg)
+			if cvss, ok := scores[vulnID]; ok {
+				vuln.CVSSScore = cvss.BaseScore
+				vuln.CVSSVector = cvss.VectorString
+			}
+
+			if voucher.ShouldIncludeVulnerabilityWithCVSS(vuln, failOn, failOnCVSS) {
+				vulns = append(vulns, vuln)
+			}
+		}
+	}
+
+	sort.Slice(vulns, func(i, j int) bool {
+		if vulns[i].PackageName != vulns[j].PackageName {
+			return vulns[i].PackageName < vulns[j].PackageName
+		}
+		return vulns[i].Name < vulns[j].Name
+	})
+
+	return vulns
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// getAllowlist returns the vulnerability allowlist in the file at
+// allowlist.file. Like waivers, the file is read each time this is called.
+// Entries that are invalid are logged and ignored.
+func getAllowlist() voucher.Allowlist {
+	filename := viper.GetString("allowlist.file")
+	if "" == filename {
+		return nil
+	}
+
+	entries, err := readAllowlist(filename)
+	if nil != err {
+		log.Errorf("failed to load vulnerability allowlist: %s", err)
+		return nil
+	}
+
+	valid := make(voucher.Allowlist, 0, len(entries))
+	for i, entry := range entries {
+		if err = entry.Validate(); nil != err {
+			log.Errorf("ignoring allowlist entry %d in %s: %s", i, filename, err)
+			continue
+		}
+		valid = append(valid, entry)
+	}
+
+	return valid
+}
+
+// readAllowlist reads the passed file as a JSON array of allowlist entries.
+func readAllowlist(filename string) (voucher.Allowlist, error) {
+	data, err := ioutil.ReadFile(filename)
+	if nil != err {
+		return nil, err
+	}
+
+	var entries voucher.Allowlist
+	if err = json.Unmarshal(data, &entries); nil != err {
+		return nil, fmt.Errorf("could not parse %s: %s", filename, err)
+	}
+
+	return entries, nil
+}
@@ -1,10 +1,38 @@
 package config
 
 import (
+	"github.com/spf13/viper"
+
 	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/auth"
 	"github.com/grafeas/voucher/v2/auth/google"
+	"github.com/grafeas/voucher/v2/auth/registry"
 )
 
-func newAuth() voucher.Auth {
-	return google.NewAuth()
+// newAuth creates the voucher.Auth that Checks use to access images. Images in
+// Google's registries are accessed with Google's Auth, and images in any other
+// registry (or only those in registry.domains, if it is set) are accessed with
+// credentials from the ejson secrets or the Docker config.
+func newAuth(secrets *Secrets) voucher.Auth {
+	keychain := registry.MultiKeychain{}
+	if nil != secrets && 0 < len(secrets.Registries) {
+		keychain = append(keychain, secrets.Registries)
+	}
+	keychain = append(keychain, registry.NewDockerConfigKeychain(getDockerConfigPath()))
+
+	return auth.NewRouter(
+		google.NewAuth(),
+		registry.NewAuth(keychain, viper.GetStringSlice("registry.domains")...),
+	)
+}
+
+// getDockerConfigPath returns the path to the Docker config.json that registry
+// credentials are read from, which is registry.docker_config, or Docker's
+// default config if that is not set.
+func getDockerConfigPath() string {
+	if path := viper.GetString("registry.docker_config"); "" != path {
+		return path
+	}
+
+	return registry.DefaultDockerConfigPath()
 }
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/docker/distribution/reference"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuth(t *testing.T) {
+	gcr, err := reference.ParseNormalizedNamed("gcr.io/project/image")
+	require.NoError(t, err)
+
+	hub, err := reference.ParseNormalizedNamed("alpine")
+	require.NoError(t, err)
+
+	ghcr, err := reference.ParseNormalizedNamed("ghcr.io/org/image")
+	require.NoError(t, err)
+
+	a := newAuth(nil)
+	assert.True(t, a.IsForDomain(gcr))
+	assert.True(t, a.IsForDomain(hub))
+	assert.True(t, a.IsForDomain(ghcr))
+
+	viper.Set("registry.domains", []string{"ghcr.io"})
+	defer viper.Set("registry.domains", nil)
+
+	a = newAuth(&Secrets{})
+	assert.True(t, a.IsForDomain(gcr), "Google's registries should always be supported")
+	assert.False(t, a.IsForDomain(hub))
+	assert.True(t, a.IsForDomain(ghcr))
+}
+
+func TestGetDockerConfigPath(t *testing.T) {
+	viper.Set("registry.docker_config", "/etc/voucher/docker.json")
+	defer viper.Set("registry.docker_config", "")
+
+	assert.Equal(t, "/etc/voucher/docker.json", getDockerConfigPath())
+}
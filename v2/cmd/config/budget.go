@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// budgetKey is the block that snakeoil's vulnerability budget is set in.
+const budgetKey = "snakeoil.budget"
+
+// getVulnerabilityBudget returns the VulnerabilityBudget in the
+// snakeoil.budget block, which maps severities to the number of
+// vulnerabilities of that severity an image may have. It returns nil if there
+// is no budget.
+//
+// Scanners only report vulnerabilities at or above failon, so a budget for a
+// less severe Severity would never be used, and is rejected.
+func getVulnerabilityBudget() (voucher.VulnerabilityBudget, error) {
+	budgets := viper.GetStringMap(budgetKey)
+	if 0 == len(budgets) {
+		return nil, nil
+	}
+
+	failOn, err := voucher.StringToSeverity(viper.GetString("failon"))
+	if nil != err {
+		return nil, fmt.Errorf("invalid vulnerability budget: %s", err)
+	}
+
+	budget := make(voucher.VulnerabilityBudget, len(budgets))
+	for name := range budgets {
+		severity, err := voucher.StringToSeverity(name)
+		if nil != err {
+			return nil, fmt.Errorf("invalid vulnerability budget: %s", err)
+		}
+		if failOn > severity {
+			return nil, fmt.Errorf("invalid vulnerability budget: %s is below failon (%s), so its vulnerabilities are never counted", severity, failOn)
+		}
+		budget[severity] = viper.GetInt(budgetKey + "." + name)
+	}
+
+	return budget, nil
+}
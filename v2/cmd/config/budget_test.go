@@ -0,0 +1,70 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+func TestGetVulnerabilityBudget(t *testing.T) {
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	budget, err := getVulnerabilityBudget()
+	require.NoError(t, err)
+	assert.Nil(t, budget)
+
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(`
+failon = "medium"
+
+[snakeoil.budget]
+critical = 0
+high = 3
+medium = -1
+`)))
+
+	budget, err = getVulnerabilityBudget()
+	require.NoError(t, err)
+	assert.Equal(t, voucher.VulnerabilityBudget{
+		voucher.CriticalSeverity: 0,
+		voucher.HighSeverity:     3,
+		voucher.MediumSeverity:   -1,
+	}, budget)
+
+	require.NoError(t, viper.ReadConfig(strings.NewReader(`
+failon = "medium"
+
+[snakeoil.budget]
+severe = 1
+`)))
+
+	_, err = getVulnerabilityBudget()
+	assert.Error(t, err)
+}
+
+func TestGetVulnerabilityBudgetBelowFailOn(t *testing.T) {
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(`
+failon = "high"
+
+[snakeoil.budget]
+high = 3
+low = 10
+`)))
+
+	_, err := getVulnerabilityBudget()
+	assert.EqualError(t, err, "invalid vulnerability budget: low is below failon (high), so its vulnerabilities are never counted")
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"sync"
+
+	"github.com/spf13/viper"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/docker/cache"
+	"github.com/grafeas/voucher/v2/metrics"
+)
+
+// registryCaches holds the process-wide registry caches that have been
+// created, keyed by their size, so that manifests and image configurations
+// are kept between requests.
+var registryCaches = struct {
+	sync.Mutex
+	caches map[int]*cache.Cache
+}{caches: make(map[int]*cache.Cache)}
+
+// getRegistryCache returns the cache.Cache that manifests and image
+// configurations are kept in. If registry.cache_size is set, this is a
+// process-wide cache of up to that many entries, which is shared between
+// requests. Otherwise, a new cache is returned, which is only shared by the
+// checks and scanners of a single request.
+func getRegistryCache() *cache.Cache {
+	size := viper.GetInt("registry.cache_size")
+	if 0 >= size {
+		return cache.New(0)
+	}
+
+	registryCaches.Lock()
+	defer registryCaches.Unlock()
+
+	c, ok := registryCaches.caches[size]
+	if !ok {
+		c = cache.New(size)
+		registryCaches.caches[size] = c
+	}
+
+	return c
+}
+
+// newCachedAuth wraps the passed Auth so that the registry artifacts requested
+// with it are cached, reporting cache hits and misses to the passed
+// metrics.Client.
+func newCachedAuth(auth voucher.Auth, metricsClient metrics.Client) voucher.Auth {
+	if nil == metricsClient {
+		metricsClient = &metrics.NoopClient{}
+	}
+
+	return cache.NewAuth(auth, getRegistryCache(), metricsClient)
+}
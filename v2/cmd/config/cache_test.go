@@ -0,0 +1,17 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRegistryCache(t *testing.T) {
+	assert.False(t, getRegistryCache() == getRegistryCache(), "without a size, each request should get its own cache")
+
+	viper.Set("registry.cache_size", 100)
+	defer viper.Set("registry.cache_size", 0)
+
+	assert.True(t, getRegistryCache() == getRegistryCache(), "with a size, the cache should be shared between requests")
+}
@@ -0,0 +1,11 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+// getCheckDependencies returns the names of the checks that the check with
+// the passed name requires, as configured in its `checks.[name]` block.
+func getCheckDependencies(name string) []string {
+	return viper.GetStringSlice("checks." + name + ".requires")
+}
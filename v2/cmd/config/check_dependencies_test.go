@@ -0,0 +1,24 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCheckDependencies(t *testing.T) {
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(testCheckPolicyConfig)))
+
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	assert.Equal(t, []string{"provenance"}, getCheckDependencies("approved"))
+	assert.Empty(t, getCheckDependencies("snakeoil"))
+	assert.Empty(t, getCheckDependencies("diy"))
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// getCheckPolicy returns the CheckPolicy for the check with the passed name,
+// as configured in its `checks.[name]` block. Timeout and backoff are
+// configured in seconds.
+func getCheckPolicy(name string) voucher.CheckPolicy {
+	prefix := "checks." + name + "."
+
+	return voucher.CheckPolicy{
+		Timeout: time.Duration(viper.GetInt(prefix+"timeout")) * time.Second,
+		Retries: viper.GetInt(prefix + "retries"),
+		Backoff: time.Duration(viper.GetInt(prefix+"backoff")) * time.Second,
+	}
+}
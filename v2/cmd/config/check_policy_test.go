@@ -0,0 +1,46 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+const testCheckPolicyConfig = `
+[checks]
+diy = true
+
+[checks.snakeoil]
+timeout = 30
+retries = 2
+backoff = 5
+
+[checks.approved]
+requires = ["provenance"]
+`
+
+func TestGetCheckPolicy(t *testing.T) {
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(testCheckPolicyConfig)))
+
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	assert.ElementsMatch(t, []string{"approved", "diy", "snakeoil"}, GetRequiredChecksFromConfig()["all"])
+
+	assert.Equal(t, voucher.CheckPolicy{
+		Timeout: 30 * time.Second,
+		Retries: 2,
+		Backoff: 5 * time.Second,
+	}, getCheckPolicy("snakeoil"))
+
+	assert.Equal(t, voucher.CheckPolicy{}, getCheckPolicy("diy"))
+}
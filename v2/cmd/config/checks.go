@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/metrics"
 	"github.com/grafeas/voucher/v2/repository"
+	"github.com/grafeas/voucher/v2/signer"
 	"github.com/spf13/viper"
 
 	// Register the DIY check
@@ -17,6 +19,8 @@ import (
 	_ "github.com/grafeas/voucher/v2/checks/snakeoil"
 	// Register the Repo check
 	_ "github.com/grafeas/voucher/v2/checks/approved"
+	// Register the KEV check
+	_ "github.com/grafeas/voucher/v2/checks/kev"
 )
 
 // setAuth sets the Auth for the passed Check, if that Check implements
@@ -35,6 +39,70 @@ func setCheckScanner(check voucher.Check, scanner voucher.VulnerabilityScanner)
 	}
 }
 
+// seesEveryVulnerability returns true if the passed Check should be given a
+// scanner without the failon thresholds, because it is an ExploitCheck or a
+// PolicyCheck.
+func seesEveryVulnerability(check voucher.Check) bool {
+	switch check.(type) {
+	case voucher.ExploitCheck, voucher.PolicyCheck:
+		return true
+	}
+	return false
+}
+
+// setCheckFixableOnly sets whether the passed Check only fails on fixable
+// vulnerabilities, if that Check implements FixableVulnerabilityCheck.
+func setCheckFixableOnly(check voucher.Check, fixableOnly bool) {
+	if fixableCheck, ok := check.(voucher.FixableVulnerabilityCheck); ok {
+		fixableCheck.SetFixableOnly(fixableOnly)
+	}
+}
+
+// setCheckNewOnly sets whether the passed Check only fails on vulnerabilities
+// that were not in the last attested image, if that Check implements
+// NewVulnerabilityCheck.
+func setCheckNewOnly(check voucher.Check, newOnly bool) {
+	if newVulnerabilityCheck, ok := check.(voucher.NewVulnerabilityCheck); ok {
+		newVulnerabilityCheck.SetNewOnly(newOnly)
+	}
+}
+
+// setCheckBudget sets the vulnerability budget for the passed Check, if that
+// Check implements BudgetedVulnerabilityCheck.
+func setCheckBudget(check voucher.Check, budget voucher.VulnerabilityBudget) {
+	if budgetedCheck, ok := check.(voucher.BudgetedVulnerabilityCheck); ok {
+		budgetedCheck.SetBudget(budget)
+	}
+}
+
+// setCheckExploitSource sets the source of exploit data, and the EPSS
+// threshold, for the passed Check, if that Check implements ExploitCheck.
+func setCheckExploitSource(check voucher.Check, source voucher.ExploitSource, epssThreshold float32) {
+	if exploitCheck, ok := check.(voucher.ExploitCheck); ok {
+		if nil != source {
+			exploitCheck.SetExploitSource(source)
+		}
+		exploitCheck.SetEPSSThreshold(epssThreshold)
+	}
+}
+
+// setCheckAttestationVerifier sets the verifier for the signatures of other
+// images' attestations on the passed Check, if that Check implements
+// AttestationVerifierCheck.
+func setCheckAttestationVerifier(check voucher.Check, verifier signer.AttestationVerifier) {
+	if verifierCheck, ok := check.(voucher.AttestationVerifierCheck); ok && nil != verifier {
+		verifierCheck.SetAttestationVerifier(verifier)
+	}
+}
+
+// setCheckPlatforms sets the platforms of multi-platform images that the
+// passed Check verifies, if that Check implements PlatformCheck.
+func setCheckPlatforms(check voucher.Check, platforms []string) {
+	if platformCheck, ok := check.(voucher.PlatformCheck); ok {
+		platformCheck.SetPlatforms(platforms)
+	}
+}
+
 // setCheckMetadataClient sets the MetadataClient for the passed Check, if that Check implements
 // MetadataCheck.
 func setCheckMetadataClient(check voucher.Check, metadataClient voucher.MetadataClient) {
@@ -73,29 +141,71 @@ func setCheckRepositoryClient(check voucher.Check, repositoryClient repository.C
 // Checks, passing any necessary configuration details to the
 // checks.
 func NewCheckSuite(secrets *Secrets, metadataClient voucher.MetadataClient, repositoryClient repository.Client, names ...string) (*voucher.Suite, error) {
-	auth := newAuth()
+	return NewCheckSuiteWithMetrics(secrets, metadataClient, repositoryClient, &metrics.NoopClient{}, names...)
+}
+
+// NewCheckSuiteWithMetrics creates a new checks.Suite in the same way
+// as NewCheckSuite. The Checks share a cache of the manifests and
+// image configurations they request, whose hits and misses are
+// reported to the passed metrics.Client.
+func NewCheckSuiteWithMetrics(secrets *Secrets, metadataClient voucher.MetadataClient, repositoryClient repository.Client, metricsClient metrics.Client, names ...string) (*voucher.Suite, error) {
+	auth := newCachedAuth(newAuth(secrets), metricsClient)
 	repos := validRepos()
-	scanner := newScanner(secrets, metadataClient, auth)
 	checksuite := voucher.NewSuite()
 
 	trustedBuildCreators := viper.GetStringSlice("trusted_builder_identities")
 	trustedProjects := viper.GetStringSlice("trusted_projects")
+	exploitSource := getExploitSource()
+
+	var verifier signer.AttestationVerifier
+	if viper.GetBool("new_only") {
+		verifier = NewAttestationVerifier(secrets)
+	}
 
 	checks, err := voucher.GetCheckFactories(names...)
 	if nil != err {
 		return checksuite, fmt.Errorf("can't create check suite: %s", err)
 	}
 
+	scanner, err := newScanner(secrets, metadataClient, auth)
+	if nil != err {
+		return checksuite, fmt.Errorf("can't create check suite: %s", err)
+	}
+
+	unfilteredScanner, err := newUnfilteredScanner(secrets, metadataClient, auth)
+	if nil != err {
+		return checksuite, fmt.Errorf("can't create check suite: %s", err)
+	}
+
+	budget, err := getVulnerabilityBudget()
+	if nil != err {
+		return checksuite, fmt.Errorf("can't create check suite: %s", err)
+	}
+
 	for name, check := range checks {
 		setCheckAuth(check, auth)
-		setCheckScanner(check, scanner)
+		setCheckPlatforms(check, viper.GetStringSlice("platforms"))
+		if seesEveryVulnerability(check) {
+			setCheckScanner(check, unfilteredScanner)
+		} else {
+			setCheckScanner(check, scanner)
+		}
+		setCheckFixableOnly(check, viper.GetBool("fixable_only"))
+		setCheckNewOnly(check, viper.GetBool("new_only"))
+		setCheckAttestationVerifier(check, verifier)
+		setCheckBudget(check, budget)
+		setCheckExploitSource(check, exploitSource, float32(viper.GetFloat64("kev.epss_threshold")))
 		setCheckMetadataClient(check, metadataClient)
 		setCheckValidRepos(check, repos)
 		setCheckTrustedIdentitiesAndProjects(check, trustedBuildCreators, trustedProjects)
 		setCheckRepositoryClient(check, repositoryClient)
 
 		checksuite.Add(name, check)
+		checksuite.SetPolicy(name, getCheckPolicy(name))
+		checksuite.SetDependencies(name, getCheckDependencies(name)...)
 	}
 
+	checksuite.SetWaivers(getWaivers())
+
 	return checksuite, nil
 }
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// policyKey is the key in a check group's table which sets the policy
+// expression that decides whether an image passes that group. It is not the
+// name of a check.
+const policyKey = "policy"
+
+// GetGroupPoliciesFromConfig returns a map of check group names to the
+// PolicyExpression that decides whether an image passes that group. Groups
+// without a policy are not included. The "all" group is configured with
+// `policy` in the checks table, and other groups with `policy` in their
+// table under required. A policy which uses a check that is not registered is
+// an error, so checks must be registered before this is called.
+func GetGroupPoliciesFromConfig() (map[string]voucher.PolicyExpression, error) {
+	policies := make(map[string]voucher.PolicyExpression)
+
+	for group, expression := range getGroupPolicyExpressions() {
+		policy, err := voucher.ParsePolicyExpression(expression)
+		if nil != err {
+			return nil, fmt.Errorf("check group \"%s\" has an %s", group, err)
+		}
+		for _, name := range policy.Checks() {
+			if !voucher.IsCheckFactoryRegistered(name) {
+				return nil, fmt.Errorf("check group \"%s\" has a policy which uses the unknown check \"%s\"", group, name)
+			}
+		}
+		policies[group] = policy
+	}
+
+	return policies, nil
+}
+
+// getGroupPolicyExpressions returns a map of check group names to the
+// unparsed policy expression configured for that group.
+func getGroupPolicyExpressions() map[string]string {
+	expressions := make(map[string]string)
+
+	if expression := viper.GetString("checks." + policyKey); "" != expression {
+		expressions["all"] = expression
+	}
+
+	for env, val := range viper.GetStringMap("required") {
+		if m, ok := val.(map[string]interface{}); ok {
+			if expression, ok := m[policyKey].(string); ok && "" != expression {
+				expressions[env] = expression
+			}
+		}
+	}
+	return expressions
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGroupPoliciesConfig = `
+[checks]
+policy = "snakeoil AND (approved OR diy)"
+snakeoil = true
+diy = true
+
+[required.env1]
+diy = true
+
+[required.env2]
+policy = "2 of [provenance, approved, diy]"
+diy = true
+`
+
+func TestGetGroupPoliciesFromConfig(t *testing.T) {
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(testGroupPoliciesConfig)))
+
+	policies, err := GetGroupPoliciesFromConfig()
+	require.NoError(t, err)
+
+	require.Len(t, policies, 2)
+	assert.Equal(t, "snakeoil AND (approved OR diy)", policies["all"].String())
+	assert.Equal(t, "2 of [provenance, approved, diy]", policies["env2"].String())
+
+	assert.Equal(t, map[string][]string{
+		"all":  {"approved", "diy", "snakeoil"},
+		"env1": {"diy"},
+		"env2": {"approved", "diy", "provenance"},
+	}, sortedGroups(GetRequiredChecksFromConfig()))
+}
+
+func TestGetInvalidGroupPoliciesFromConfig(t *testing.T) {
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(`
+[required.env1]
+policy = "diy AND"
+diy = true
+`)))
+
+	_, err := GetGroupPoliciesFromConfig()
+	assert.EqualError(t, err, "check group \"env1\" has an invalid policy expression \"diy AND\": expected a check name, found the end of the expression")
+
+	assert.Equal(t, []string{"diy"}, GetRequiredChecksFromConfig()["env1"])
+}
+
+func TestGetGroupPoliciesWithUnknownChecksFromConfig(t *testing.T) {
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(`
+[required.env1]
+policy = "diy OR snakoil"
+diy = true
+`)))
+
+	_, err := GetGroupPoliciesFromConfig()
+	assert.EqualError(t, err, "check group \"env1\" has a policy which uses the unknown check \"snakoil\"")
+}
@@ -2,8 +2,14 @@ package config
 
 import (
 	"github.com/spf13/viper"
+
+	voucher "github.com/grafeas/voucher/v2"
 )
 
+// failFastKey is the key in a check group's table which enables fail-fast
+// mode for that group. It is not the name of a check.
+const failFastKey = "fail_fast"
+
 func GetRequiredChecksFromConfig() map[string][]string {
 	requiredChecks := make(map[string][]string)
 
@@ -18,20 +24,74 @@ func GetRequiredChecksFromConfig() map[string][]string {
 			requiredChecks[env] = toStringSlice(m)
 		}
 	}
+
+	// Checks used in a group's policy are part of that group, even if they
+	// are not listed in its table.
+	for group, expression := range getGroupPolicyExpressions() {
+		if policy, err := voucher.ParsePolicyExpression(expression); nil == err {
+			requiredChecks[group] = mergeCheckNames(requiredChecks[group], policy.Checks())
+		}
+	}
 	return requiredChecks
 }
 
+// mergeCheckNames returns the names in the passed slices, without duplicates.
+func mergeCheckNames(names []string, others []string) []string {
+	for _, other := range others {
+		found := false
+		for _, name := range names {
+			if name == other {
+				found = true
+				break
+			}
+		}
+		if !found {
+			names = append(names, other)
+		}
+	}
+	return names
+}
+
 // toStringSlice takes a map[string]interface{} and converts it to a
 // slice of strings using the keys (dropping any values that do not cast to
 // booleans cleanly, or have the value of false).
+//
+// Values may also be tables of check options, such as a check's timeout. Those
+// are treated as enabled unless their `enabled` key is set to false.
 func toStringSlice(in map[string]interface{}) []string {
 	out := make([]string, 0, len(in))
 	for key, rawValue := range in {
-		if value, ok := rawValue.(bool); ok {
+		if failFastKey == key {
+			continue
+		}
+		switch value := rawValue.(type) {
+		case bool:
 			if value {
 				out = append(out, key)
 			}
+		case map[string]interface{}:
+			if enabled, ok := value["enabled"].(bool); !ok || enabled {
+				out = append(out, key)
+			}
 		}
 	}
 	return out
 }
+
+// GetFailFastGroupsFromConfig returns a map of check group names to whether
+// that group should be run in fail-fast mode. The "all" group is configured
+// with `fail_fast` in the checks table, and other groups with `fail_fast` in
+// their table under required.
+func GetFailFastGroupsFromConfig() map[string]bool {
+	failFastGroups := make(map[string]bool)
+
+	failFastGroups["all"] = viper.GetBool("checks." + failFastKey)
+
+	for env, val := range viper.GetStringMap("required") {
+		if m, ok := val.(map[string]interface{}); ok {
+			failFast, _ := m[failFastKey].(bool)
+			failFastGroups[env] = failFast
+		}
+	}
+	return failFastGroups
+}
@@ -1,23 +1,30 @@
 package config
 
 import (
+	"sort"
 	"strings"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var testExpectedSlice = []string{
 	"a",
 	"f",
+	"g",
 }
 
 var testGoodMap = map[string]interface{}{
-	"a": true,
-	"b": false,
-	"c": false,
-	"e": 55,
-	"f": true,
+	"a":         true,
+	"b":         false,
+	"c":         false,
+	"e":         55,
+	"f":         true,
+	"g":         map[string]interface{}{"timeout": 30},
+	"h":         map[string]interface{}{"enabled": false, "timeout": 30},
+	"fail_fast": true,
 }
 
 func TestGetRequiredChecksFromConfig(t *testing.T) {
@@ -59,3 +66,48 @@ func TestToStringSlice(t *testing.T) {
 	convert := toStringSlice(testGoodMap)
 	assert.ElementsMatch(t, testExpectedSlice, convert)
 }
+
+const testFailFastConfig = `
+[checks]
+fail_fast = true
+diy = true
+nobody = true
+
+[required.env1]
+diy = true
+
+[required.env2]
+fail_fast = true
+diy = true
+nobody = true
+`
+
+func TestGetFailFastGroupsFromConfig(t *testing.T) {
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(testFailFastConfig)))
+
+	assert.Equal(t, map[string]bool{
+		"all":  true,
+		"env1": false,
+		"env2": true,
+	}, GetFailFastGroupsFromConfig())
+
+	assert.Equal(t, map[string][]string{
+		"all":  {"diy", "nobody"},
+		"env1": {"diy"},
+		"env2": {"diy", "nobody"},
+	}, sortedGroups(GetRequiredChecksFromConfig()))
+}
+
+// sortedGroups sorts the check names in each of the passed groups.
+func sortedGroups(groups map[string][]string) map[string][]string {
+	for _, checks := range groups {
+		sort.Strings(checks)
+	}
+	return groups
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/kev"
+)
+
+// exploitSources holds the kev.Sources that have been created, keyed by their
+// configuration, so that their data is kept between requests and only
+// reloaded once their reload interval passes.
+var exploitSources = struct {
+	sync.Mutex
+	sources map[exploitSourceConfig]*kev.Source
+}{sources: make(map[exploitSourceConfig]*kev.Source)}
+
+// exploitSourceConfig is the configuration of a kev.Source.
+type exploitSourceConfig struct {
+	catalog  string
+	epss     string
+	interval time.Duration
+}
+
+// getExploitSource returns the ExploitSource for the Known Exploited
+// Vulnerabilities catalog at kev.catalog and the EPSS scores at kev.epss,
+// which are reloaded every kev.reload_interval seconds. It returns nil if
+// neither file is configured.
+func getExploitSource() voucher.ExploitSource {
+	config := exploitSourceConfig{
+		catalog:  viper.GetString("kev.catalog"),
+		epss:     viper.GetString("kev.epss"),
+		interval: time.Duration(viper.GetInt("kev.reload_interval")) * time.Second,
+	}
+
+	if "" == config.catalog && "" == config.epss {
+		return nil
+	}
+
+	exploitSources.Lock()
+	defer exploitSources.Unlock()
+
+	source, ok := exploitSources.sources[config]
+	if !ok {
+		source = kev.NewSource(config.catalog, config.epss, config.interval)
+		exploitSources.sources[config] = source
+	}
+
+	return source
+}
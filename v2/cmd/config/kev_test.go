@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExploitSource(t *testing.T) {
+	defer func() {
+		viper.Set("kev.catalog", "")
+		viper.Set("kev.epss", "")
+		viper.Set("kev.reload_interval", 0)
+	}()
+
+	assert.Nil(t, getExploitSource())
+
+	viper.Set("kev.catalog", "/var/lib/voucher/known_exploited_vulnerabilities.json")
+	viper.Set("kev.reload_interval", 3600)
+
+	source := getExploitSource()
+	assert.NotNil(t, source)
+	assert.True(t, source == getExploitSource(), "source was not reused between calls")
+
+	viper.Set("kev.epss", "/var/lib/voucher/epss_scores.csv")
+	assert.False(t, source == getExploitSource(), "source was reused after its configuration changed")
+}
@@ -71,3 +71,21 @@ func NewAttestationSigner(secrets *Secrets) signer.AttestationSigner {
 	log.Printf("signer %q is unknown, supported values are 'kms' or 'pgp'\n", signerName)
 	return nil
 }
+
+// NewAttestationVerifier creates the configured AttestationSigner, if it can
+// also verify the signatures of attestations. Otherwise, the signer is closed
+// and nil is returned.
+func NewAttestationVerifier(secrets *Secrets) signer.AttestationVerifier {
+	attestationSigner := NewAttestationSigner(secrets)
+	if nil == attestationSigner {
+		return nil
+	}
+
+	verifier, ok := attestationSigner.(signer.AttestationVerifier)
+	if !ok {
+		attestationSigner.Close()
+		return nil
+	}
+
+	return verifier
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/checks/policy"
+)
+
+// GetPolicySourcesFromConfig returns a map of check names to the policies
+// those checks evaluate, as configured in the policy_checks table. Policies
+// are read from disk. The language of a policy defaults to the extension of
+// its file. Policies which cannot be read are logged and left out, so that
+// the others can still be used.
+func GetPolicySourcesFromConfig() map[string]policy.Source {
+	sources := make(map[string]policy.Source)
+
+	for name := range viper.GetStringMap("policy_checks") {
+		source, err := getPolicySource(name)
+		if nil != err {
+			log.Errorf("skipping policy check \"%s\": %s", name, err)
+			continue
+		}
+
+		sources[name] = source
+	}
+
+	return sources
+}
+
+// getPolicySource reads the policy for the policy check with the passed name.
+func getPolicySource(name string) (policy.Source, error) {
+	key := "policy_checks." + name
+
+	filename := viper.GetString(key + ".file")
+	if "" == filename {
+		return policy.Source{}, errors.New("no policy file is configured")
+	}
+
+	language := strings.ToLower(viper.GetString(key + ".language"))
+	if "" == language {
+		language = strings.TrimPrefix(filepath.Ext(filename), ".")
+	}
+
+	text, err := ioutil.ReadFile(filename)
+	if nil != err {
+		return policy.Source{}, fmt.Errorf("could not read policy: %s", err)
+	}
+
+	return policy.Source{
+		Language: language,
+		Filename: filename,
+		Policy:   string(text),
+		Query:    viper.GetString(key + ".query"),
+	}, nil
+}
+
+// registerPolicyChecks compiles the configured policies, and registers a
+// check for each of them. Policies which are invalid are logged and not
+// registered, and the rest are registered as usual.
+func registerPolicyChecks() {
+	for name, source := range GetPolicySourcesFromConfig() {
+		evaluator, err := policy.NewEvaluator(context.Background(), source)
+		if nil != err {
+			log.Errorf("skipping policy check \"%s\", it is invalid: %s", name, err)
+			continue
+		}
+		voucher.RegisterCheckFactory(name, policy.NewPolicyCheckFactory(name, evaluator))
+	}
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/checks/policy"
+)
+
+func TestGetPolicySourcesFromConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "voucher-policies")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	celFile := filepath.Join(dir, "signed.cel")
+	require.NoError(t, ioutil.WriteFile(celFile, []byte("input.commit.IsSigned"), 0600))
+
+	regoFile := filepath.Join(dir, "nonroot.policy")
+	require.NoError(t, ioutil.WriteFile(regoFile, []byte("package images\n\nallow { input.config.User != \"root\" }\n"), 0600))
+
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(`
+[policy_checks.is_signed]
+file = "`+celFile+`"
+
+[policy_checks.not_root]
+file = "`+regoFile+`"
+language = "Rego"
+query = "data.images.allow"
+`)))
+
+	sources := GetPolicySourcesFromConfig()
+
+	assert.Equal(t, map[string]policy.Source{
+		"is_signed": {
+			Language: policy.CEL,
+			Filename: celFile,
+			Policy:   "input.commit.IsSigned",
+		},
+		"not_root": {
+			Language: policy.Rego,
+			Filename: regoFile,
+			Policy:   "package images\n\nallow { input.config.User != \"root\" }\n",
+			Query:    "data.images.allow",
+		},
+	}, sources)
+
+	registerPolicyChecks()
+	assert.True(t, voucher.IsCheckFactoryRegistered("is_signed"))
+	assert.True(t, voucher.IsCheckFactoryRegistered("not_root"))
+
+	checks, err := voucher.GetCheckFactories("is_signed", "kev", "snakeoil")
+	require.NoError(t, err)
+	assert.True(t, seesEveryVulnerability(checks["is_signed"]), "policies should see vulnerabilities below failon")
+	assert.True(t, seesEveryVulnerability(checks["kev"]))
+	assert.False(t, seesEveryVulnerability(checks["snakeoil"]))
+}
+
+func TestGetMissingPolicySourcesFromConfig(t *testing.T) {
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(`
+[policy_checks.missing]
+file = "does/not/exist.cel"
+`)))
+
+	assert.Empty(t, GetPolicySourcesFromConfig())
+}
+
+func TestRegisterPolicyChecksSkipsInvalidPolicies(t *testing.T) {
+	dir, err := ioutil.TempDir("", "voucher-policies")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	validFile := filepath.Join(dir, "valid.cel")
+	require.NoError(t, ioutil.WriteFile(validFile, []byte("input.commit.is_signed"), 0600))
+
+	invalidFile := filepath.Join(dir, "invalid.cel")
+	require.NoError(t, ioutil.WriteFile(invalidFile, []byte("input.commit.is_signed =="), 0600))
+
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(`
+[policy_checks.valid_policy]
+file = "`+validFile+`"
+
+[policy_checks.invalid_policy]
+file = "`+invalidFile+`"
+
+[policy_checks.missing_policy]
+file = "`+filepath.Join(dir, "missing.cel")+`"
+`)))
+
+	registerPolicyChecks()
+	assert.True(t, voucher.IsCheckFactoryRegistered("valid_policy"))
+	assert.False(t, voucher.IsCheckFactoryRegistered("invalid_policy"))
+	assert.False(t, voucher.IsCheckFactoryRegistered("missing_policy"))
+}
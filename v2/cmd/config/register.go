@@ -7,10 +7,15 @@ import (
 	"github.com/grafeas/voucher/v2/checks/org"
 )
 
+// RegisterDynamicChecks registers the checks which are defined in the
+// configuration, rather than in code. Policy checks which fail to load are
+// logged and skipped, and the remaining checks are still registered.
 func RegisterDynamicChecks() {
 	orgs := GetOrganizationsFromConfig()
 	for alias, organization := range orgs {
 		orgCheck := org.NewOrganizationCheckFactory(organization)
 		voucher.RegisterCheckFactory("is_"+strings.ToLower(alias), orgCheck)
 	}
+
+	registerPolicyChecks()
 }
@@ -1,44 +1,244 @@
 package config
 
 import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 
 	voucher "github.com/grafeas/voucher/v2"
 	"github.com/grafeas/voucher/v2/clair"
+	"github.com/grafeas/voucher/v2/clairv4"
+	"github.com/grafeas/voucher/v2/composite"
+	"github.com/grafeas/voucher/v2/osv"
+	"github.com/grafeas/voucher/v2/trivy"
 )
 
-func newScanner(secrets *Secrets, metadataClient voucher.MetadataClient, auth voucher.Auth) (scanner voucher.VulnerabilityScanner) {
+// newScanner creates the VulnerabilityScanner configured in scanner, with the
+// settings shared by every scanner applied to it. Configuration errors are
+// returned rather than stopping the server, as this is called for each
+// request.
+func newScanner(secrets *Secrets, metadataClient voucher.MetadataClient, auth voucher.Auth) (voucher.VulnerabilityScanner, error) {
+	severity, err := voucher.StringToSeverity(viper.GetString("failon"))
+	if nil != err {
+		return nil, err
+	}
+
+	return newScannerWithThresholds(secrets, metadataClient, auth, severity, float32(viper.GetFloat64("failon_cvss")))
+}
+
+// newUnfilteredScanner creates the VulnerabilityScanner configured in scanner,
+// without the failon and failon_cvss thresholds, so that checks for exploited
+// vulnerabilities and policies see every vulnerability in an image. VEX and
+// the allowlist still apply.
+func newUnfilteredScanner(secrets *Secrets, metadataClient voucher.MetadataClient, auth voucher.Auth) (voucher.VulnerabilityScanner, error) {
+	return newScannerWithThresholds(secrets, metadataClient, auth, voucher.NegligibleSeverity, 0)
+}
+
+// newScannerWithThresholds creates the VulnerabilityScanner configured in
+// scanner, which fails on vulnerabilities at or above the passed severity and
+// CVSS score.
+func newScannerWithThresholds(secrets *Secrets, metadataClient voucher.MetadataClient, auth voucher.Auth, severity voucher.Severity, cvss float32) (voucher.VulnerabilityScanner, error) {
 	scannerName := viper.GetString("scanner")
+
+	var scanner voucher.VulnerabilityScanner
+	var err error
+	if "composite" == scannerName {
+		scanner, err = newCompositeScanner(secrets, metadataClient, auth)
+	} else {
+		scanner, err = newNamedScanner(scannerName, secrets, metadataClient, auth)
+	}
+
+	if nil != err {
+		return nil, err
+	}
+
+	if vex := getVEX(); 0 < len(vex) {
+		scanner = voucher.NewVEXScanner(scanner, vex)
+	}
+
+	scanner.FailOn(severity)
+
+	if cvssScanner, ok := scanner.(voucher.CVSSScanner); ok {
+		cvssScanner.FailOnCVSS(cvss)
+	}
+
+	if allowlistScanner, ok := scanner.(voucher.AllowlistScanner); ok {
+		allowlistScanner.SetAllowlist(getAllowlist())
+	}
+
+	return scanner, nil
+}
+
+// newNamedScanner creates the VulnerabilityScanner with the passed name.
+func newNamedScanner(scannerName string, secrets *Secrets, metadataClient voucher.MetadataClient, auth voucher.Auth) (voucher.VulnerabilityScanner, error) {
 	switch scannerName {
 	case "clair", "c":
 		if secrets == nil {
-			log.Fatalf("No secrets were configured, unable to use Clair as scanner")
+			return nil, fmt.Errorf("no secrets were configured, unable to use Clair as scanner")
 		}
 		config := secrets.ClairConfig
 		if "" == config.Hostname {
 			config.Hostname = viper.GetString("clair.address")
 		}
-		scanner = clair.NewScanner(config, auth)
+		return clair.NewScanner(config, auth), nil
+	case "clairv4":
+		if secrets == nil {
+			return nil, fmt.Errorf("no secrets were configured, unable to use Clair v4 as scanner")
+		}
+		config := secrets.ClairConfig
+		if "" == config.Hostname {
+			config.Hostname = viper.GetString("clairv4.address")
+		}
+		return clairv4.NewScanner(config, auth), nil
 	case "gca", "g":
 		log.Warningf("the %s option for `scanner` has been deprecated and will be removed in the future. Please use `metadata` instead.", scannerName)
-		scanner = voucher.NewScanner(metadataClient)
+		return voucher.NewScanner(metadataClient), nil
 	case "metadata":
-		scanner = voucher.NewScanner(metadataClient)
-	default:
-		scanner = nil
+		return voucher.NewScanner(metadataClient), nil
+	case "trivy":
+		return newTrivyScanner()
+	case "osv":
+		return newOSVScanner(auth)
 	}
 
-	if nil == scanner {
-		log.Fatalf("not a valid scanner: %s", scannerName)
+	return nil, fmt.Errorf("not a valid scanner: %s", scannerName)
+}
+
+// newTrivyScanner creates a trivy.Scanner, which reads reports from the
+// directory in trivy.reports, or from the file server at trivy.reports_url.
+func newTrivyScanner() (voucher.VulnerabilityScanner, error) {
+	if dir := viper.GetString("trivy.reports"); "" != dir {
+		return trivy.NewScanner(trivy.NewDirectorySource(dir)), nil
 	}
 
-	severity, err := voucher.StringToSeverity(viper.GetString("failon"))
+	if address := viper.GetString("trivy.reports_url"); "" != address {
+		return trivy.NewScanner(trivy.NewFileServerSource(address, http.DefaultClient)), nil
+	}
+
+	return nil, fmt.Errorf("no trivy reports directory or reports_url was configured, unable to use Trivy as scanner")
+}
+
+// osvDatabases holds the OSV databases that have been loaded, keyed by their
+// directories, so that they are kept between requests and only reloaded once
+// osv.reload_interval passes.
+var osvDatabases = struct {
+	sync.Mutex
+	databases map[string]*loadedOSVDatabase
+	now       func() time.Time
+}{databases: make(map[string]*loadedOSVDatabase), now: time.Now}
+
+// loadedOSVDatabase is an OSV database, along with when it was loaded and
+// whether it is being reloaded.
+type loadedOSVDatabase struct {
+	db        *osv.Database
+	loadedAt  time.Time
+	reloading bool
+}
+
+// getOSVDatabase returns the OSV database in the passed directory, loading it
+// if it has not been loaded before. Once it is older than the passed interval,
+// it is reloaded in the background, and the copy that was loaded before is
+// returned until that finishes. If the interval is zero, it is only loaded
+// once.
+func getOSVDatabase(dir string, interval time.Duration) (*osv.Database, error) {
+	osvDatabases.Lock()
+	defer osvDatabases.Unlock()
+
+	now := osvDatabases.now()
+	if loaded, ok := osvDatabases.databases[dir]; ok {
+		if 0 < interval && !loaded.reloading && now.Sub(loaded.loadedAt) >= interval {
+			loaded.reloading = true
+			go reloadOSVDatabase(dir, loaded)
+		}
+		return loaded.db, nil
+	}
+
+	db, err := osv.LoadDatabase(dir)
 	if nil != err {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	scanner.FailOn(severity)
+	osvDatabases.databases[dir] = &loadedOSVDatabase{db: db, loadedAt: now}
+	return db, nil
+}
+
+// reloadOSVDatabase reloads the passed OSV database from the passed directory.
+// If reloading fails, the error is logged and the database loaded before is
+// kept until the reload interval passes again.
+func reloadOSVDatabase(dir string, loaded *loadedOSVDatabase) {
+	db, err := osv.LoadDatabase(dir)
+
+	osvDatabases.Lock()
+	defer osvDatabases.Unlock()
+
+	loaded.reloading = false
+	if nil != err {
+		log.Errorf("failed to reload the OSV database in %s, using the copy from %s: %s", dir, loaded.loadedAt.Format(time.RFC3339), err)
+	} else {
+		loaded.db = db
+	}
+	loaded.loadedAt = osvDatabases.now()
+}
+
+// newOSVScanner creates an osv.Scanner, which matches packages against the
+// OSV database in osv.database, reloaded every osv.reload_interval seconds.
+// Packages are read from the inventories in osv.inventories if it is set, and
+// from the image layers otherwise.
+func newOSVScanner(auth voucher.Auth) (voucher.VulnerabilityScanner, error) {
+	dir := viper.GetString("osv.database")
+	if "" == dir {
+		return nil, fmt.Errorf("no osv database directory was configured, unable to use OSV as scanner")
+	}
+
+	db, err := getOSVDatabase(dir, time.Duration(viper.GetInt("osv.reload_interval"))*time.Second)
+	if nil != err {
+		return nil, err
+	}
+
+	inventory := osv.NewLayerInventory(auth)
+	if inventories := viper.GetString("osv.inventories"); "" != inventories {
+		inventory = osv.NewDirectoryInventory(inventories)
+	}
+
+	return osv.NewScanner(db, inventory), nil
+}
+
+// newCompositeScanner creates a composite.Scanner which runs each of the
+// scanners listed in the composite block.
+func newCompositeScanner(secrets *Secrets, metadataClient voucher.MetadataClient, auth voucher.Auth) (voucher.VulnerabilityScanner, error) {
+	mode, err := composite.ParseMode(viper.GetString("composite.mode"))
+	if nil != err {
+		return nil, err
+	}
+
+	var degrade bool
+	switch onError := viper.GetString("composite.on_error"); onError {
+	case "", "fail":
+		degrade = false
+	case "degrade":
+		degrade = true
+	default:
+		return nil, fmt.Errorf("not a valid composite on_error behaviour: %s", onError)
+	}
+
+	names := viper.GetStringSlice("composite.scanners")
+	if 0 == len(names) {
+		return nil, composite.ErrNoScanners
+	}
+
+	scanner := composite.NewScanner(mode, degrade)
+
+	for _, name := range names {
+		child, err := newNamedScanner(name, secrets, metadataClient, auth)
+		if nil != err {
+			return nil, err
+		}
+		scanner.Add(name, child)
+	}
 
-	return
+	return scanner, nil
 }
@@ -0,0 +1,240 @@
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/clairv4"
+	"github.com/grafeas/voucher/v2/composite"
+	"github.com/grafeas/voucher/v2/osv"
+	vtesting "github.com/grafeas/voucher/v2/testing"
+	"github.com/grafeas/voucher/v2/trivy"
+)
+
+func TestNewScannerWithBadConfig(t *testing.T) {
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	viper.Set("scanner", "unknown")
+	_, err := newScanner(nil, nil, nil)
+	assert.EqualError(t, err, "not a valid scanner: unknown")
+
+	_, err = NewCheckSuite(nil, nil, nil, "snakeoil")
+	assert.Error(t, err, "a misconfigured scanner should be returned as an error, rather than stopping the server")
+
+	viper.Set("scanner", "metadata")
+	viper.Set("failon", "severe")
+	_, err = newScanner(nil, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestNewExploitScannerIgnoresThresholds(t *testing.T) {
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	viper.Set("scanner", "metadata")
+	viper.Set("failon", "critical")
+	viper.Set("failon_cvss", 9.0)
+
+	vulns := []voucher.Vulnerability{
+		{Name: "CVE-2021-44228", Severity: voucher.LowSeverity, CVSSScore: 4.0},
+	}
+
+	metadataClient := new(voucher.MockMetadataClient)
+	metadataClient.On("GetVulnerabilities", mock.Anything, mock.Anything).Return(vulns, nil)
+
+	imageData := vtesting.NewTestReference(t)
+
+	scanner, err := newScanner(nil, metadataClient, nil)
+	require.NoError(t, err)
+	filtered, err := scanner.Scan(context.Background(), imageData)
+	require.NoError(t, err)
+	assert.Empty(t, filtered)
+
+	unfilteredScanner, err := newUnfilteredScanner(nil, metadataClient, nil)
+	require.NoError(t, err)
+	unfiltered, err := unfilteredScanner.Scan(context.Background(), imageData)
+	require.NoError(t, err)
+	assert.Equal(t, vulns, unfiltered)
+}
+
+func TestNewCompositeScanner(t *testing.T) {
+	defer func() {
+		FileName = "../../../testdata/config.toml"
+		InitConfig()
+	}()
+
+	viper.SetConfigType("toml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(`
+scanner = "composite"
+
+[composite]
+scanners = ["metadata", "gca"]
+mode = "intersection"
+on_error = "degrade"
+`)))
+
+	scanner, err := newCompositeScanner(nil, nil, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &composite.Scanner{}, scanner)
+
+	viper.Set("composite.on_error", "ignore")
+	_, err = newCompositeScanner(nil, nil, nil)
+	assert.Error(t, err)
+
+	viper.Set("composite.on_error", "fail")
+	viper.Set("composite.scanners", []string{"metadata", "unknown"})
+	_, err = newCompositeScanner(nil, nil, nil)
+	assert.EqualError(t, err, "not a valid scanner: unknown")
+
+	viper.Set("composite.scanners", []string{})
+	_, err = newCompositeScanner(nil, nil, nil)
+	assert.Equal(t, composite.ErrNoScanners, err)
+}
+
+func TestNewTrivyScanner(t *testing.T) {
+	defer func() {
+		viper.Set("trivy.reports", "")
+		viper.Set("trivy.reports_url", "")
+	}()
+
+	_, err := newNamedScanner("trivy", nil, nil, nil)
+	assert.Error(t, err)
+
+	viper.Set("trivy.reports_url", "localhost:8080")
+	scanner, err := newNamedScanner("trivy", nil, nil, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &trivy.Scanner{}, scanner)
+
+	viper.Set("trivy.reports", "/var/lib/trivy/reports")
+	scanner, err = newNamedScanner("trivy", nil, nil, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &trivy.Scanner{}, scanner)
+}
+
+func TestNewClairV4Scanner(t *testing.T) {
+	_, err := newNamedScanner("clairv4", nil, nil, nil)
+	assert.Error(t, err)
+
+	scanner, err := newNamedScanner("clairv4", &Secrets{}, nil, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &clairv4.Scanner{}, scanner)
+}
+
+func TestNewOSVScanner(t *testing.T) {
+	defer func() {
+		viper.Set("osv.database", "")
+		viper.Set("osv.inventories", "")
+	}()
+
+	_, err := newNamedScanner("osv", nil, nil, nil)
+	assert.Error(t, err)
+
+	dir, err := ioutil.TempDir("", "voucher-osv")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	viper.Set("osv.database", dir)
+	scanner, err := newNamedScanner("osv", nil, nil, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &osv.Scanner{}, scanner)
+
+	viper.Set("osv.inventories", dir)
+	scanner, err = newNamedScanner("osv", nil, nil, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &osv.Scanner{}, scanner)
+}
+
+// waitForOSVReload waits for the background reload of the OSV database in the
+// passed directory to finish.
+func waitForOSVReload(t *testing.T, dir string) {
+	t.Helper()
+
+	for i := 0; i < 100; i++ {
+		osvDatabases.Lock()
+		reloading := osvDatabases.databases[dir].reloading
+		osvDatabases.Unlock()
+
+		if !reloading {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("the OSV database was not reloaded")
+}
+
+func TestGetOSVDatabaseIsReloadedAfterInterval(t *testing.T) {
+	dir, err := ioutil.TempDir("", "voucher-osv")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	osvDatabases.Lock()
+	osvDatabases.now = func() time.Time {
+		return now
+	}
+	osvDatabases.Unlock()
+
+	defer func() {
+		osvDatabases.Lock()
+		osvDatabases.now = time.Now
+		osvDatabases.Unlock()
+	}()
+
+	pkg := osv.Package{Ecosystem: "npm", Name: "left-pad", Version: "1.3.0"}
+	entry := `{"id": "OSV-2020-1", "affected": [{"package": {"ecosystem": "npm", "name": "left-pad"}, "versions": ["1.3.0"]}]}`
+
+	db, err := getOSVDatabase(dir, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, db.Match(pkg))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "OSV-2020-1.json"), []byte(entry), 0600))
+
+	db, err = getOSVDatabase(dir, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, db.Match(pkg), "the database should not be reloaded before the interval passes")
+
+	osvDatabases.Lock()
+	now = now.Add(time.Minute)
+	osvDatabases.Unlock()
+
+	db, err = getOSVDatabase(dir, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, db.Match(pkg), "the database loaded before should be used while it is reloaded")
+
+	waitForOSVReload(t, dir)
+
+	db, err = getOSVDatabase(dir, time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, db.Match(pkg), 1)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "invalid.json"), []byte("{"), 0600))
+
+	osvDatabases.Lock()
+	now = now.Add(time.Minute)
+	osvDatabases.Unlock()
+
+	_, err = getOSVDatabase(dir, time.Minute)
+	require.NoError(t, err)
+	waitForOSVReload(t, dir)
+
+	db, err = getOSVDatabase(dir, time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, db.Match(pkg), 1, "the database loaded before should be kept if it cannot be reloaded")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Shopify/ejson"
 	"github.com/spf13/viper"
 
+	"github.com/grafeas/voucher/v2/auth/registry"
 	"github.com/grafeas/voucher/v2/clair"
 	"github.com/grafeas/voucher/v2/repository"
 	"github.com/grafeas/voucher/v2/signer/pgp"
@@ -16,9 +17,10 @@ import (
 // Secrets represents the format that the ejson configuration is structured
 // in.
 type Secrets struct {
-	Keys                     map[string]string  `json:"openpgpkeys"`
-	ClairConfig              clair.Config       `json:"clair"`
-	RepositoryAuthentication repository.KeyRing `json:"repositories"`
+	Keys                     map[string]string       `json:"openpgpkeys"`
+	ClairConfig              clair.Config            `json:"clair"`
+	RepositoryAuthentication repository.KeyRing      `json:"repositories"`
+	Registries               registry.StaticKeychain `json:"registries"`
 }
 
 // ReadSecrets reads from the ejson file and populates the passed interface.
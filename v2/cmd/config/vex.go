@@ -0,0 +1,192 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// vexClient is the client used to request VEX documents from vex.url.
+var vexClient = &http.Client{Timeout: 30 * time.Second}
+
+// vexDocuments holds the VEX documents that were last loaded, so that they are
+// kept between requests and only reloaded once vex.reload_interval passes.
+// The document at vex.url is requested in the background, so that checks do
+// not wait for it.
+var vexDocuments = struct {
+	sync.Mutex
+	config    vexConfig
+	vex       voucher.VEX
+	remote    *voucher.VEXDocument
+	loaded    bool
+	loadedAt  time.Time
+	fetching  bool
+	fetchedAt time.Time
+	now       func() time.Time
+}{now: time.Now}
+
+// vexConfig is the configuration of the VEX documents.
+type vexConfig struct {
+	dir      string
+	url      string
+	interval time.Duration
+}
+
+// getVEX returns the OpenVEX documents in the directory at vex.dir, and the
+// last copy of the document at vex.url that was requested successfully. The
+// directory is read the first time this is called, and again once
+// vex.reload_interval seconds have passed. The document at vex.url is
+// requested in the background at the same times, and is not used until that
+// request succeeds. Documents that cannot be read are logged and ignored.
+func getVEX() voucher.VEX {
+	config := vexConfig{
+		dir:      viper.GetString("vex.dir"),
+		url:      viper.GetString("vex.url"),
+		interval: time.Duration(viper.GetInt("vex.reload_interval")) * time.Second,
+	}
+
+	if "" == config.dir && "" == config.url {
+		return nil
+	}
+
+	vexDocuments.Lock()
+	defer vexDocuments.Unlock()
+
+	now := vexDocuments.now()
+	if config != vexDocuments.config {
+		if config.url != vexDocuments.config.url {
+			vexDocuments.remote = nil
+			vexDocuments.fetchedAt = time.Time{}
+		}
+		vexDocuments.config = config
+		vexDocuments.loaded = false
+	}
+
+	if !vexDocuments.loaded || isVEXStale(vexDocuments.loadedAt, now, config.interval) {
+		vexDocuments.vex = readVEXDirectory(config.dir)
+		vexDocuments.loaded = true
+		vexDocuments.loadedAt = now
+	}
+
+	if "" != config.url && !vexDocuments.fetching && (vexDocuments.fetchedAt.IsZero() || isVEXStale(vexDocuments.fetchedAt, now, config.interval)) {
+		vexDocuments.fetching = true
+		go refreshVEXDocument(config.url)
+	}
+
+	vex := append(voucher.VEX{}, vexDocuments.vex...)
+	if nil != vexDocuments.remote {
+		vex = append(vex, *vexDocuments.remote)
+	}
+	return vex
+}
+
+// isVEXStale returns true if VEX documents loaded at the passed time should
+// be reloaded at the passed time, given the passed reload interval. If the
+// interval is zero, they are never reloaded.
+func isVEXStale(loadedAt time.Time, now time.Time, interval time.Duration) bool {
+	return 0 < interval && now.Sub(loadedAt) >= interval
+}
+
+// refreshVEXDocument requests the OpenVEX document at the passed URL, and
+// keeps it for getVEX to use if vex.url has not changed in the meantime. If
+// the request fails, the error is logged and the copy requested before is
+// still used.
+func refreshVEXDocument(address string) {
+	document, err := requestVEXDocument(address)
+
+	vexDocuments.Lock()
+	defer vexDocuments.Unlock()
+
+	if address != vexDocuments.config.url {
+		vexDocuments.fetching = false
+		return
+	}
+
+	vexDocuments.fetching = false
+	vexDocuments.fetchedAt = vexDocuments.now()
+
+	if nil != err {
+		log.Errorf("ignoring VEX document: %s", err)
+		return
+	}
+
+	vexDocuments.remote = &document
+}
+
+// readVEXDirectory reads the OpenVEX documents in the passed directory, if it
+// is set.
+func readVEXDirectory(dir string) voucher.VEX {
+	vex := voucher.VEX{}
+	if "" == dir {
+		return vex
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if nil != err {
+		log.Errorf("failed to load VEX documents: %s", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		document, err := readVEXDocument(filepath.Join(dir, file.Name()))
+		if nil != err {
+			log.Errorf("ignoring VEX document: %s", err)
+			continue
+		}
+		vex = append(vex, document)
+	}
+
+	return vex
+}
+
+// readVEXDocument reads the OpenVEX document in the passed file.
+func readVEXDocument(filename string) (voucher.VEXDocument, error) {
+	data, err := ioutil.ReadFile(filename)
+	if nil != err {
+		return voucher.VEXDocument{}, err
+	}
+
+	document, err := voucher.ParseVEXDocument(data)
+	if nil != err {
+		return document, fmt.Errorf("%s: %s", filename, err)
+	}
+
+	return document, nil
+}
+
+// requestVEXDocument requests the OpenVEX document at the passed URL.
+func requestVEXDocument(address string) (voucher.VEXDocument, error) {
+	resp, err := vexClient.Get(address)
+	if nil != err {
+		return voucher.VEXDocument{}, err
+	}
+	defer resp.Body.Close()
+
+	if 300 <= resp.StatusCode {
+		return voucher.VEXDocument{}, fmt.Errorf("getting %s failed: %s", address, resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if nil != err {
+		return voucher.VEXDocument{}, err
+	}
+
+	document, err := voucher.ParseVEXDocument(data)
+	if nil != err {
+		return document, fmt.Errorf("%s: %s", address, err)
+	}
+
+	return document, nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+const testVEXDocument = `{
+	"@context": "https://openvex.dev/ns/v0.2.0",
+	"@id": "https://example.com/vex/1",
+	"timestamp": "2023-01-01T00:00:00Z",
+	"statements": [
+		{
+			"vulnerability": {"name": "CVE-2020-1234"},
+			"products": [{"@id": "gcr.io/voucher-test-project/apps/app"}],
+			"status": "not_affected",
+			"justification": "component_not_present"
+		}
+	]
+}`
+
+func TestGetVEX(t *testing.T) {
+	dir, err := ioutil.TempDir("", "voucher-vex")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.json"), []byte(testVEXDocument), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "invalid.json"), []byte("{"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("VEX documents"), 0600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if "/vex.json" != r.URL.Path {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testVEXDocument))
+	}))
+	defer server.Close()
+
+	defer func() {
+		viper.Set("vex.dir", "")
+		viper.Set("vex.url", "")
+	}()
+
+	assert.Empty(t, getVEX())
+
+	viper.Set("vex.dir", dir)
+	vex := getVEX()
+	require.Len(t, vex, 1)
+	assert.Equal(t, "https://example.com/vex/1", vex[0].ID)
+	assert.Equal(t, voucher.VEXNotAffected, vex[0].Statements[0].Status)
+
+	viper.Set("vex.url", server.URL+"/vex.json")
+	assert.Len(t, getVEX(), 1, "the document at vex.url should not be used before it is requested")
+	waitForVEXRefresh(t)
+	assert.Len(t, getVEX(), 2)
+
+	viper.Set("vex.url", server.URL+"/missing.json")
+	assert.Len(t, getVEX(), 1)
+	waitForVEXRefresh(t)
+	assert.Len(t, getVEX(), 1)
+}
+
+func TestGetVEXIsReloadedAfterInterval(t *testing.T) {
+	var requests, failing int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if 0 != atomic.LoadInt32(&failing) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(testVEXDocument))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	vexDocuments.now = func() time.Time {
+		return now
+	}
+
+	defer func() {
+		vexDocuments.now = time.Now
+		viper.Set("vex.url", "")
+		viper.Set("vex.reload_interval", 0)
+	}()
+
+	viper.Set("vex.url", server.URL+"/vex.json")
+	viper.Set("vex.reload_interval", 60)
+
+	assert.Empty(t, getVEX())
+	waitForVEXRefresh(t)
+	assert.Len(t, getVEX(), 1)
+	waitForVEXRefresh(t)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "the document should not be requested for every check")
+
+	now = now.Add(time.Minute)
+	atomic.StoreInt32(&failing, 1)
+
+	assert.Len(t, getVEX(), 1, "the document loaded before should be used while it is reloaded")
+	waitForVEXRefresh(t)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Len(t, getVEX(), 1, "the document loaded before should be used if it cannot be reloaded")
+	waitForVEXRefresh(t)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "a failed request should not be retried before the interval passes")
+}
+
+// waitForVEXRefresh waits for the document at vex.url to finish being
+// requested in the background.
+func waitForVEXRefresh(t *testing.T) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		vexDocuments.Lock()
+		defer vexDocuments.Unlock()
+		return !vexDocuments.fetching
+	}, 5*time.Second, time.Millisecond)
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// getWaivers returns the waivers in the file at waivers.file. The file is read
+// each time this is called, so waivers can be added without restarting
+// Voucher. Waivers that are invalid are logged and ignored.
+func getWaivers() []voucher.Waiver {
+	filename := viper.GetString("waivers.file")
+	if "" == filename {
+		return nil
+	}
+
+	waivers, err := readWaivers(filename)
+	if nil != err {
+		log.Errorf("failed to load waivers: %s", err)
+		return nil
+	}
+
+	valid := make([]voucher.Waiver, 0, len(waivers))
+	for i, waiver := range waivers {
+		if err = waiver.Validate(); nil != err {
+			log.Errorf("ignoring waiver %d in %s: %s", i, filename, err)
+			continue
+		}
+		valid = append(valid, waiver)
+	}
+
+	return valid
+}
+
+// readWaivers reads the passed file as a JSON array of waivers.
+func readWaivers(filename string) ([]voucher.Waiver, error) {
+	data, err := ioutil.ReadFile(filename)
+	if nil != err {
+		return nil, err
+	}
+
+	var waivers []voucher.Waiver
+	if err = json.Unmarshal(data, &waivers); nil != err {
+		return nil, fmt.Errorf("could not parse %s: %s", filename, err)
+	}
+
+	return waivers, nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+const testWaivers = `[
+	{
+		"repository": "gcr.io/voucher-test-project/apps",
+		"check": "snakeoil",
+		"expires": "2030-01-02T15:04:05Z",
+		"justification": "waiting on a fixed base image",
+		"owner": "oncall@example.com",
+		"attest": true
+	},
+	{
+		"digest": "sha256:cb749360c5198a55859a7f335de3cf4e2f64b60886a2098684a2f9c7ffca81f2",
+		"check": "approved",
+		"expires": "2030-01-02T15:04:05Z"
+	}
+]`
+
+func TestGetWaivers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "voucher-waivers")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "waivers.json")
+	require.NoError(t, ioutil.WriteFile(filename, []byte(testWaivers), 0600))
+
+	defer viper.Set("waivers.file", "")
+
+	assert.Nil(t, getWaivers())
+
+	viper.Set("waivers.file", filename)
+
+	assert.Equal(t, []voucher.Waiver{
+		{
+			Repository:    "gcr.io/voucher-test-project/apps",
+			Check:         "snakeoil",
+			Expires:       time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
+			Justification: "waiting on a fixed base image",
+			Owner:         "oncall@example.com",
+			Attest:        true,
+		},
+	}, getWaivers())
+
+	viper.Set("waivers.file", filepath.Join(dir, "missing.json"))
+	assert.Nil(t, getWaivers())
+}
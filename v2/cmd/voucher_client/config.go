@@ -14,6 +14,7 @@ type config struct {
 	Password string
 	Timeout  int
 	Check    string
+	SARIF    string
 }
 
 var defaultConfig = &config{}
@@ -22,6 +23,10 @@ func getCheck() string {
 	return defaultConfig.Check
 }
 
+func getSARIFFile() string {
+	return defaultConfig.SARIF
+}
+
 func getVoucherClient() (voucher.Interface, error) {
 	newClient, err := client.NewClient(defaultConfig.Hostname)
 	if nil == err {
@@ -7,7 +7,9 @@ import (
 	"github.com/docker/distribution/reference"
 
 	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/auth"
 	"github.com/grafeas/voucher/v2/auth/google"
+	"github.com/grafeas/voucher/v2/auth/registry"
 )
 
 // lookupCanonical looks up the canonical version of the passed image path.
@@ -24,7 +26,7 @@ func lookupCanonical(ctx context.Context, image string) (reference.Canonical, er
 		return nil, fmt.Errorf("couldn't get named version of reference: %s", err)
 	}
 
-	voucherClient, err := voucher.AuthToClient(ctx, google.NewAuth(), namedRef)
+	voucherClient, err := voucher.AuthToClient(ctx, newAuth(), namedRef)
 	if nil != err {
 		return nil, fmt.Errorf("creating authenticated client failed: %s", err)
 	}
@@ -36,3 +38,13 @@ func lookupCanonical(ctx context.Context, image string) (reference.Canonical, er
 
 	return canonicalRef, err
 }
+
+// newAuth creates the voucher.Auth used to look up images. Images in Google's
+// registries are accessed with Google's Auth, and images in other registries
+// with the credentials in the Docker config.
+func newAuth() voucher.Auth {
+	return auth.NewRouter(
+		google.NewAuth(),
+		registry.NewAuth(registry.NewDockerConfigKeychain(registry.DefaultDockerConfigPath())),
+	)
+}
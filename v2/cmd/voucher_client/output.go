@@ -1,10 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 
 	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/sarif"
 )
 
 // errorf prints a formatted string to standard error.
@@ -38,3 +41,23 @@ func formatResponse(resp *voucher.Response) string {
 
 	return output
 }
+
+// writeSARIF writes the vulnerabilities in the response to the passed file as
+// a SARIF document. If no file was passed, nothing is written.
+func writeSARIF(filename string, resp *voucher.Response) error {
+	if "" == filename {
+		return nil
+	}
+
+	log := sarif.NewLog()
+	if err := log.AddResponse(*resp); nil != err {
+		return err
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if nil != err {
+		return err
+	}
+
+	return ioutil.WriteFile(filename, data, 0644)
+}
@@ -53,6 +53,8 @@ func init() {
 	viper.BindPFlag("timeout", rootCmd.Flags().Lookup("timeout"))
 	rootCmd.Flags().StringVarP(&defaultConfig.Check, "check", "c", "all", "the name of the checks to run against Voucher with")
 	viper.BindPFlag("check", rootCmd.Flags().Lookup("check"))
+	rootCmd.Flags().StringVar(&defaultConfig.SARIF, "sarif", "", "write the vulnerabilities found to this file as a SARIF document")
+	viper.BindPFlag("sarif", rootCmd.Flags().Lookup("sarif"))
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -24,6 +24,10 @@ func check(ctx context.Context, client voucher.Interface, check string, canonica
 
 	fmt.Println(formatResponse(&voucherResp))
 
+	if err = writeSARIF(getSARIFFile(), &voucherResp); nil != err {
+		return fmt.Errorf("writing SARIF document failed: %s", err)
+	}
+
 	if !voucherResp.Success {
 		return errImageCheckFailed
 	}
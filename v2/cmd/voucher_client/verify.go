@@ -21,6 +21,10 @@ func verifyImage(ctx context.Context, client voucher.Interface, check string, ca
 
 	fmt.Println(formatResponse(&voucherResp))
 
+	if err = writeSARIF(getSARIFFile(), &voucherResp); nil != err {
+		return fmt.Errorf("writing SARIF document failed: %s", err)
+	}
+
 	if !voucherResp.Success {
 		return errImageCheckFailed
 	}
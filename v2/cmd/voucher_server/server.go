@@ -22,6 +22,8 @@ var serverCmd = &cobra.Command{
 			RequireAuth: viper.GetBool("server.require_auth"),
 			Username:    viper.GetString("server.username"),
 			PassHash:    viper.GetString("server.password"),
+
+			ReuseAttestations: viper.GetBool("server.reuse_attestations"),
 		}
 
 		secrets, err := config.ReadSecrets()
@@ -42,6 +44,19 @@ var serverCmd = &cobra.Command{
 			voucherServer.SetCheckGroup(groupName, checks)
 		}
 
+		for groupName, failFast := range config.GetFailFastGroupsFromConfig() {
+			voucherServer.SetCheckGroupFailFast(groupName, failFast)
+		}
+
+		policies, err := config.GetGroupPoliciesFromConfig()
+		if err != nil {
+			log.Fatalf("Error loading check group policies: %v", err)
+		}
+
+		for groupName, policy := range policies {
+			voucherServer.SetCheckGroupPolicy(groupName, policy)
+		}
+
 		voucherServer.Serve()
 	},
 }
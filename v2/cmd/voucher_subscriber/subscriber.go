@@ -37,11 +37,18 @@ var subscriberCmd = &cobra.Command{
 
 		config.RegisterDynamicChecks()
 
+		policies, err := config.GetGroupPoliciesFromConfig()
+		if err != nil {
+			log.Fatalf("error loading check group policies: %s", err)
+		}
+
 		subscriberConfig := subscriber.Config{
 			Project:        viper.GetString("pubsub.project"),
 			Subscription:   viper.GetString("pubsub.subscription"),
 			RequiredChecks: config.GetRequiredChecksFromConfig()["all"],
 			DryRun:         viper.GetBool("dryrun"),
+			FailFast:       config.GetFailFastGroupsFromConfig()["all"],
+			Policy:         policies["all"],
 			Timeout:        viper.GetInt("pubsub.timeout"),
 		}
 		voucherSubscriber := subscriber.NewSubscriber(&subscriberConfig, secrets, metricsClient, log)
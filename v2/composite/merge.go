@@ -0,0 +1,101 @@
+package composite
+
+import (
+	"strings"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// vulnerabilityKey returns the key that vulnerabilities are de-duplicated by
+// with the passed Mode. With Union, that is their name (usually the CVE ID)
+// and the affected package. With Intersection, it is only their name, as
+// scanners name the same package differently, such as "openssl" and
+// "libssl1.1", and a vulnerability should not be dropped because of that.
+func vulnerabilityKey(vuln voucher.Vulnerability, mode Mode) string {
+	if Intersection == mode {
+		return strings.ToUpper(vuln.Name)
+	}
+	return strings.ToUpper(vuln.Name) + "\x00" + vuln.PackageName
+}
+
+// merge de-duplicates the vulnerabilities found by the passed scanners, and
+// combines them with the passed Mode. Each vulnerability records the names of
+// the scanners that found it.
+func merge(results []scanResult, mode Mode) []voucher.Vulnerability {
+	merged := make(map[string]*voucher.Vulnerability)
+	order := make([]string, 0)
+
+	for _, result := range results {
+		for _, vuln := range result.vulns {
+			key := vulnerabilityKey(vuln, mode)
+			existing, ok := merged[key]
+			if !ok {
+				existing = new(voucher.Vulnerability)
+				*existing = vuln
+				existing.Scanners = nil
+				existing.URLs = append([]string(nil), vuln.URLs...)
+				merged[key] = existing
+				order = append(order, key)
+			} else {
+				combine(existing, vuln)
+			}
+
+			if !containsString(existing.Scanners, result.name) {
+				existing.Scanners = append(existing.Scanners, result.name)
+			}
+		}
+	}
+
+	vulns := make([]voucher.Vulnerability, 0, len(order))
+	for _, key := range order {
+		vuln := merged[key]
+		if Intersection == mode && len(vuln.Scanners) != len(results) {
+			continue
+		}
+		vulns = append(vulns, *vuln)
+	}
+
+	return vulns
+}
+
+// combine fills in the details of the first vulnerability with those of the
+// second, which is the same vulnerability found by another scanner. The
+// higher of their severities and CVSS scores is kept.
+func combine(vuln *voucher.Vulnerability, other voucher.Vulnerability) {
+	if other.Severity > vuln.Severity {
+		vuln.Severity = other.Severity
+	}
+
+	if other.CVSSScore > vuln.CVSSScore {
+		vuln.CVSSScore = other.CVSSScore
+		vuln.CVSSVector = other.CVSSVector
+	}
+
+	if "" == vuln.Description {
+		vuln.Description = other.Description
+	}
+
+	if "" == vuln.FixedBy {
+		vuln.FixedBy = other.FixedBy
+	}
+
+	if "" == vuln.PackageVersion {
+		vuln.PackageVersion = other.PackageVersion
+	}
+
+	for _, url := range other.URLs {
+		if !containsString(vuln.URLs, url) {
+			vuln.URLs = append(vuln.URLs, url)
+		}
+	}
+}
+
+// containsString returns true if the passed slice contains the passed string.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// Mode describes how the results of each scanner are combined.
+type Mode string
+
+// Modes for combining the results of scanners.
+const (
+	// Union reports vulnerabilities found by any of the scanners.
+	Union Mode = "union"
+	// Intersection reports vulnerabilities found by all of the scanners.
+	Intersection Mode = "intersection"
+)
+
+// ErrNoScanners is returned when a Scanner has no scanners to run.
+var ErrNoScanners = errors.New("no scanners configured for the composite scanner")
+
+// ParseMode returns the Mode with the passed name. An empty name is Union.
+func ParseMode(name string) (Mode, error) {
+	switch Mode(strings.ToLower(name)) {
+	case Union, "":
+		return Union, nil
+	case Intersection:
+		return Intersection, nil
+	}
+	return Union, fmt.Errorf("merge mode %s doesn't exist", name)
+}
+
+// namedScanner is a VulnerabilityScanner, along with the name it is reported as.
+type namedScanner struct {
+	name    string
+	scanner voucher.VulnerabilityScanner
+}
+
+// scanResult is the result of running one of the scanners.
+type scanResult struct {
+	name  string
+	vulns []voucher.Vulnerability
+	err   error
+}
+
+// Scanner implements voucher.VulnerabilityScanner. It runs several scanners in
+// parallel, and combines their results.
+type Scanner struct {
+	scanners   []namedScanner
+	mode       Mode
+	degrade    bool
+	failOn     voucher.Severity
+	failOnCVSS float32
+	allowlist  voucher.Allowlist
+}
+
+// Add adds a scanner, which is reported under the passed name. The scanner is
+// set to report every vulnerability it finds, as the FailOn and FailOnCVSS
+// thresholds are applied to the combined results.
+func (s *Scanner) Add(name string, scanner voucher.VulnerabilityScanner) {
+	scanner.FailOn(voucher.NegligibleSeverity)
+	if cvssScanner, ok := scanner.(voucher.CVSSScanner); ok {
+		cvssScanner.FailOnCVSS(0)
+	}
+
+	s.scanners = append(s.scanners, namedScanner{name: name, scanner: scanner})
+}
+
+// FailOn sets severity level that a vulnerability must match or exheed to
+// prompt a failure. It is applied to the combined results.
+func (s *Scanner) FailOn(severity voucher.Severity) {
+	s.failOn = severity
+}
+
+// FailOnCVSS sets the CVSS score that a vulnerability must match or exceed to
+// prompt a failure. It is applied to the combined results, so it also applies
+// to vulnerabilities found by scanners which do not support it themselves.
+func (s *Scanner) FailOnCVSS(score float32) {
+	s.failOnCVSS = score
+}
+
+// SetAllowlist sets the Allowlist to suppress vulnerabilities with. It is
+// applied to the combined results.
+func (s *Scanner) SetAllowlist(allowlist voucher.Allowlist) {
+	s.allowlist = allowlist
+}
+
+// Scan runs each of the scanners against the passed image, combines their
+// results, and returns those which meet the FailOn and FailOnCVSS thresholds.
+// If a scanner fails, Scan fails, unless the Scanner degrades, in which case
+// the failure is logged and the remaining scanners' results are used.
+func (s *Scanner) Scan(ctx context.Context, i voucher.ImageData) ([]voucher.Vulnerability, error) {
+	if 0 == len(s.scanners) {
+		return []voucher.Vulnerability{}, ErrNoScanners
+	}
+
+	results := make([]scanResult, len(s.scanners))
+
+	var wg sync.WaitGroup
+	for index, named := range s.scanners {
+		wg.Add(1)
+		go func(index int, named namedScanner) {
+			defer wg.Done()
+			vulns, err := named.scanner.Scan(ctx, i)
+			results[index] = scanResult{name: named.name, vulns: vulns, err: err}
+		}(index, named)
+	}
+	wg.Wait()
+
+	succeeded := make([]scanResult, 0, len(results))
+	for _, result := range results {
+		if nil == result.err {
+			succeeded = append(succeeded, result)
+			continue
+		}
+
+		if !s.degrade {
+			return []voucher.Vulnerability{}, fmt.Errorf("scanner %s failed: %w", result.name, result.err)
+		}
+		log.Warningf("scanner %s failed, continuing without it: %s", result.name, result.err)
+	}
+
+	if 0 == len(succeeded) {
+		return []voucher.Vulnerability{}, fmt.Errorf("all scanners failed, last error: %w", results[len(results)-1].err)
+	}
+
+	vulns := make([]voucher.Vulnerability, 0)
+	for _, vuln := range merge(succeeded, s.mode) {
+		if voucher.ShouldIncludeVulnerabilityWithCVSS(vuln, s.failOn, s.failOnCVSS) {
+			vulns = append(vulns, vuln)
+		}
+	}
+
+	return s.allowlist.Suppress(vulns, i, time.Now()), nil
+}
+
+// NewScanner creates a new composite Scanner, which combines its scanners'
+// results with the passed Mode. If degrade is true, scans continue when some
+// of the scanners fail.
+func NewScanner(mode Mode, degrade bool) *Scanner {
+	scanner := new(Scanner)
+	scanner.mode = mode
+	scanner.degrade = degrade
+
+	return scanner
+}
@@ -0,0 +1,196 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+	vtesting "github.com/grafeas/voucher/v2/testing"
+)
+
+// brokenScanner is a VulnerabilityScanner which always fails.
+type brokenScanner struct{}
+
+func (brokenScanner) FailOn(voucher.Severity) {}
+
+func (brokenScanner) Scan(context.Context, voucher.ImageData) ([]voucher.Vulnerability, error) {
+	return nil, errors.New("scanner is down")
+}
+
+// filteringScanner is a CVSSScanner which only reports the vulnerabilities
+// which meet its thresholds.
+type filteringScanner struct {
+	vulns      []voucher.Vulnerability
+	failOn     voucher.Severity
+	failOnCVSS float32
+}
+
+func (s *filteringScanner) FailOn(severity voucher.Severity) {
+	s.failOn = severity
+}
+
+func (s *filteringScanner) FailOnCVSS(score float32) {
+	s.failOnCVSS = score
+}
+
+func (s *filteringScanner) Scan(context.Context, voucher.ImageData) ([]voucher.Vulnerability, error) {
+	vulns := make([]voucher.Vulnerability, 0)
+	for _, vuln := range s.vulns {
+		if voucher.ShouldIncludeVulnerabilityWithCVSS(vuln, s.failOn, s.failOnCVSS) {
+			vulns = append(vulns, vuln)
+		}
+	}
+	return vulns, nil
+}
+
+func newTestImageData(t *testing.T) voucher.ImageData {
+	t.Helper()
+
+	i, err := voucher.NewImageData("gcr.io/path/to/image@sha256:97db2bc359ccc94d3b2d6f5daa4173e9e91c513b0dcd961408adbb95ec5e5ce5")
+	require.NoError(t, err)
+	return i
+}
+
+func newTestScanner(t *testing.T, mode Mode, degrade bool) *Scanner {
+	scanner := NewScanner(mode, degrade)
+	scanner.Add("clair", vtesting.NewScanner(t,
+		voucher.Vulnerability{Name: "CVE-2020-0001", PackageName: "openssl", Severity: voucher.MediumSeverity, URLs: []string{"https://a"}},
+		voucher.Vulnerability{Name: "CVE-2020-0002", PackageName: "zlib", Severity: voucher.HighSeverity},
+	))
+	scanner.Add("metadata", vtesting.NewScanner(t,
+		voucher.Vulnerability{Name: "cve-2020-0001", PackageName: "openssl", Severity: voucher.HighSeverity, FixedBy: "1.1.1d", CVSSScore: 7.5, URLs: []string{"https://b"}},
+		voucher.Vulnerability{Name: "CVE-2020-0001", PackageName: "libssl", Severity: voucher.HighSeverity},
+	))
+	return scanner
+}
+
+func TestScannerUnion(t *testing.T) {
+	scanner := newTestScanner(t, Union, false)
+
+	vulns, err := scanner.Scan(context.Background(), newTestImageData(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, []voucher.Vulnerability{
+		{
+			Name:        "CVE-2020-0001",
+			PackageName: "openssl",
+			Severity:    voucher.HighSeverity,
+			FixedBy:     "1.1.1d",
+			CVSSScore:   7.5,
+			URLs:        []string{"https://a", "https://b"},
+			Scanners:    []string{"clair", "metadata"},
+		},
+		{Name: "CVE-2020-0002", PackageName: "zlib", Severity: voucher.HighSeverity, Scanners: []string{"clair"}},
+		{Name: "CVE-2020-0001", PackageName: "libssl", Severity: voucher.HighSeverity, Scanners: []string{"metadata"}},
+	}, vulns)
+}
+
+func TestScannerIntersection(t *testing.T) {
+	scanner := newTestScanner(t, Intersection, false)
+	scanner.SetAllowlist(voucher.Allowlist{
+		{Vulnerability: "CVE-2020-0001", Reason: "not reachable", Expires: time.Now().Add(time.Hour)},
+	})
+
+	vulns, err := scanner.Scan(context.Background(), newTestImageData(t))
+	require.NoError(t, err)
+
+	require.Len(t, vulns, 1)
+	assert.Equal(t, "CVE-2020-0001", vulns[0].Name)
+	assert.Equal(t, []string{"clair", "metadata"}, vulns[0].Scanners)
+	assert.True(t, vulns[0].Suppressed)
+}
+
+func TestScannerIntersectionWithDifferentPackageNames(t *testing.T) {
+	scanner := NewScanner(Intersection, false)
+	scanner.Add("clair", vtesting.NewScanner(t,
+		voucher.Vulnerability{Name: "CVE-2020-0001", PackageName: "openssl", Severity: voucher.MediumSeverity},
+		voucher.Vulnerability{Name: "CVE-2020-0002", PackageName: "zlib", Severity: voucher.HighSeverity},
+	))
+	scanner.Add("osv", vtesting.NewScanner(t,
+		voucher.Vulnerability{Name: "CVE-2020-0001", PackageName: "libssl1.1", Severity: voucher.HighSeverity, CVSSScore: 7.5},
+		voucher.Vulnerability{Name: "CVE-2020-0003", PackageName: "zlib1g", Severity: voucher.HighSeverity},
+	))
+
+	vulns, err := scanner.Scan(context.Background(), newTestImageData(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, []voucher.Vulnerability{
+		{
+			Name:        "CVE-2020-0001",
+			PackageName: "openssl",
+			Severity:    voucher.HighSeverity,
+			CVSSScore:   7.5,
+			Scanners:    []string{"clair", "osv"},
+		},
+	}, vulns)
+}
+
+func TestScannerAppliesThresholdsAfterMerging(t *testing.T) {
+	low := &filteringScanner{vulns: []voucher.Vulnerability{
+		{Name: "CVE-2020-0001", PackageName: "openssl", Severity: voucher.LowSeverity},
+		{Name: "CVE-2020-0002", PackageName: "zlib", Severity: voucher.LowSeverity},
+	}}
+	high := &filteringScanner{vulns: []voucher.Vulnerability{
+		{Name: "CVE-2020-0001", PackageName: "openssl", Severity: voucher.HighSeverity, CVSSScore: 8.1},
+		{Name: "CVE-2020-0002", PackageName: "zlib", Severity: voucher.HighSeverity, CVSSScore: 6.5},
+	}}
+
+	scanner := NewScanner(Intersection, false)
+	scanner.Add("low", low)
+	scanner.Add("high", high)
+	scanner.FailOn(voucher.HighSeverity)
+	scanner.FailOnCVSS(7.0)
+
+	assert.Equal(t, voucher.NegligibleSeverity, low.failOn, "scanners should report every vulnerability")
+	assert.Equal(t, float32(0), high.failOnCVSS, "scanners should report every vulnerability")
+
+	vulns, err := scanner.Scan(context.Background(), newTestImageData(t))
+	require.NoError(t, err)
+
+	require.Len(t, vulns, 1)
+	assert.Equal(t, "CVE-2020-0001", vulns[0].Name)
+	assert.Equal(t, voucher.HighSeverity, vulns[0].Severity)
+	assert.Equal(t, []string{"low", "high"}, vulns[0].Scanners)
+}
+
+func TestScannerFailsClosed(t *testing.T) {
+	scanner := newTestScanner(t, Union, false)
+	scanner.Add("broken", brokenScanner{})
+
+	_, err := scanner.Scan(context.Background(), newTestImageData(t))
+	assert.EqualError(t, err, "scanner broken failed: scanner is down")
+
+	_, err = NewScanner(Union, true).Scan(context.Background(), newTestImageData(t))
+	assert.Equal(t, ErrNoScanners, err)
+}
+
+func TestScannerDegrades(t *testing.T) {
+	scanner := newTestScanner(t, Intersection, true)
+	scanner.Add("broken", brokenScanner{})
+
+	vulns, err := scanner.Scan(context.Background(), newTestImageData(t))
+	require.NoError(t, err)
+	assert.Len(t, vulns, 1, "intersection included the broken scanner")
+
+	scanner = NewScanner(Union, true)
+	scanner.Add("broken", brokenScanner{})
+
+	_, err = scanner.Scan(context.Background(), newTestImageData(t))
+	assert.Error(t, err, "scan passed when all scanners failed")
+}
+
+func TestParseMode(t *testing.T) {
+	for name, expected := range map[string]Mode{"": Union, "union": Union, "Intersection": Intersection} {
+		mode, err := ParseMode(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, mode)
+	}
+
+	_, err := ParseMode("majority")
+	assert.Error(t, err)
+}
@@ -20,6 +20,11 @@ func OccurrenceToAttestation(checkName string, occ *grafeas.Occurrence) voucher.
 
 	signedAttestation.Body = string(attestationDetails.GetSerializedPayload())
 
+	if signatures := attestationDetails.GetSignatures(); 0 < len(signatures) {
+		signedAttestation.Signature = string(signatures[0].GetSignature())
+		signedAttestation.KeyID = signatures[0].GetPublicKeyId()
+	}
+
 	return signedAttestation
 }
 
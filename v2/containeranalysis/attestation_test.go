@@ -2,6 +2,7 @@ package containeranalysis
 
 import (
 	"github.com/stretchr/testify/assert"
+	grafeas "google.golang.org/genproto/googleapis/grafeas/v1"
 	"testing"
 )
 
@@ -29,3 +30,22 @@ func TestGetCheckNameFromNoteName(t *testing.T) {
 		assert.Equal(t, test.expected, output)
 	}
 }
+
+func TestOccurrenceToAttestation(t *testing.T) {
+	occ := &grafeas.Occurrence{
+		Details: &grafeas.Occurrence_Attestation{
+			Attestation: &grafeas.AttestationOccurrence{
+				SerializedPayload: []byte("payload"),
+				Signatures: []*grafeas.Signature{
+					{Signature: []byte("signature"), PublicKeyId: "key"},
+				},
+			},
+		},
+	}
+
+	attestation := OccurrenceToAttestation("diy", occ)
+	assert.Equal(t, "diy", attestation.CheckName)
+	assert.Equal(t, "payload", attestation.Body)
+	assert.Equal(t, "signature", attestation.Signature)
+	assert.Equal(t, "key", attestation.KeyID)
+}
@@ -3,6 +3,7 @@ package containeranalysis
 import (
 	"context"
 	"errors"
+	"sync"
 
 	containeranalysisapi "cloud.google.com/go/containeranalysis/apiv1"
 	grafeasv1 "cloud.google.com/go/grafeas/apiv1"
@@ -69,7 +70,7 @@ func (g *Client) AddAttestationToImage(ctx context.Context, ref reference.Canoni
 		signedAttestation.Signature = ""
 	}
 
-	return signedAttestation, err
+	return signedAttestation, classifyErr(err)
 }
 
 // GetAttestations returns all of the attestations associated with an image.
@@ -88,7 +89,7 @@ func (g *Client) GetAttestations(ctx context.Context, ref reference.Canonical) (
 			if iterator.Done == err {
 				return attestations, nil
 			}
-			return nil, err
+			return nil, classifyErr(err)
 		}
 
 		note, err := g.containeranalysis.GetOccurrenceNote(
@@ -98,7 +99,7 @@ func (g *Client) GetAttestations(ctx context.Context, ref reference.Canonical) (
 			},
 		)
 		if nil != err {
-			return nil, err
+			return nil, classifyErr(err)
 		}
 
 		name := getCheckNameFromNoteName(g.binauthProject, note.GetName())
@@ -136,16 +137,52 @@ func (g *Client) GetVulnerabilities(ctx context.Context, ref reference.Canonical
 				err = nil
 			}
 
+			err = classifyErr(err)
 			break
 		}
 
 		vuln := OccurrenceToVulnerability(occ)
+		vuln.CVSSVector = g.getCVSSVector(ctx, occ.GetNoteName())
 		vulnerabilities = append(vulnerabilities, vuln)
 	}
 
 	return
 }
 
+// cvssVectors holds the CVSS v3 vectors of the vulnerability Notes that have
+// been requested, keyed by their names. Notes are shared by every image with
+// the vulnerability, so each is only requested once.
+var cvssVectors = struct {
+	sync.Mutex
+	vectors map[string]string
+}{vectors: make(map[string]string)}
+
+// getCVSSVector returns the CVSS v3 vector of the vulnerability Note with the
+// passed name. If the Note cannot be requested, an empty string is returned,
+// as the vector is only informational.
+func (g *Client) getCVSSVector(ctx context.Context, noteName string) string {
+	cvssVectors.Lock()
+	vector, ok := cvssVectors.vectors[noteName]
+	cvssVectors.Unlock()
+
+	if ok {
+		return vector
+	}
+
+	note, err := g.containeranalysis.GetNote(ctx, &grafeas.GetNoteRequest{Name: noteName})
+	if nil != err {
+		return ""
+	}
+
+	vector = noteCVSSVector(note)
+
+	cvssVectors.Lock()
+	cvssVectors.vectors[noteName] = vector
+	cvssVectors.Unlock()
+
+	return vector
+}
+
 // Close closes the containeranalysis Grafeas client.
 func (g *Client) Close() {
 	if nil != g.keyring {
@@ -176,7 +213,7 @@ func (g *Client) GetBuildDetail(ctx context.Context, ref reference.Canonical) (r
 				Err:  errNoOccurrences,
 			}
 		}
-		return repository.BuildDetail{}, err
+		return repository.BuildDetail{}, classifyErr(err)
 	}
 
 	if _, err := occIterator.Next(); err != iterator.Done {
@@ -1,10 +1,13 @@
 package containeranalysis
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	voucher "github.com/grafeas/voucher/v2"
 )
@@ -18,3 +21,23 @@ func TestGrafeasHelperFunctions(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, resourceURL(imageData), testResourceAddress)
 }
+
+func TestArtifactRegistryResourceURL(t *testing.T) {
+	const image = "us-docker.pkg.dev/my-project/my-repo/alpine@sha256:297524b7375fbf09b3784f0bbd9cb2505700dd05e03ce5f5e6d262bf2f5ac51c"
+
+	imageData, err := voucher.NewImageData(image)
+	require.NoError(t, err)
+	assert.Equal(t, "resourceUrl=\"https://"+image+"\"", resourceURL(imageData))
+}
+
+func TestClassifyErr(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	assert.True(t, voucher.IsTransientError(classifyErr(unavailable)))
+	assert.Equal(t, codes.Unavailable, status.Code(errors.Unwrap(classifyErr(unavailable))))
+
+	notFound := status.Error(codes.NotFound, "not found")
+	assert.False(t, voucher.IsTransientError(classifyErr(notFound)))
+	assert.Equal(t, notFound, classifyErr(notFound))
+
+	assert.Nil(t, classifyErr(nil))
+}
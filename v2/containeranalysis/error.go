@@ -3,6 +3,8 @@ package containeranalysis
 import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	voucher "github.com/grafeas/voucher/v2"
 )
 
 // isAttestationExistsErr returns true if the passed Error is an "AlreadyExists" gRPC error.
@@ -13,3 +15,14 @@ func isAttestionExistsErr(err error) bool {
 
 	return (codes.AlreadyExists == status.Code(err))
 }
+
+// classifyErr marks the passed error as transient if it is an "Unavailable"
+// gRPC error, so that Checks which fail because Container Analysis could not
+// be reached are retried. Other errors are returned as they are.
+func classifyErr(err error) error {
+	if codes.Unavailable == status.Code(err) {
+		return voucher.NewTransientError(err)
+	}
+
+	return err
+}
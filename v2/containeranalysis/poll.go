@@ -75,7 +75,7 @@ func getVulnerabilityDiscoveries(ctx context.Context, client *grafeasv1.Client,
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, classifyErr(err)
 	}
 
 	return occurrences, nil
@@ -1,6 +1,7 @@
 package containeranalysis
 
 import (
+	"fmt"
 	"strings"
 
 	grafeas "google.golang.org/genproto/googleapis/grafeas/v1"
@@ -34,8 +35,87 @@ func getSeverity(severity string) voucher.Severity {
 func OccurrenceToVulnerability(occ *grafeas.Occurrence) voucher.Vulnerability {
 	vulnDetails := occ.GetDetails().(*grafeas.Occurrence_Vulnerability).Vulnerability
 
-	return voucher.Vulnerability{
-		Name:     strings.Replace(occ.GetNoteName(), vulProject, "", 1),
-		Severity: getSeverity(grafeas.Severity_name[int32(vulnDetails.EffectiveSeverity)]),
+	vuln := voucher.Vulnerability{
+		Name:        strings.Replace(occ.GetNoteName(), vulProject, "", 1),
+		Description: vulnDetails.GetShortDescription(),
+		Severity:    getSeverity(grafeas.Severity_name[int32(vulnDetails.EffectiveSeverity)]),
+		CVSSScore:   vulnDetails.GetCvssScore(),
 	}
+
+	if "" != vulnDetails.GetLongDescription() {
+		vuln.Description = vulnDetails.GetLongDescription()
+	}
+
+	if issue := getPackageIssue(vulnDetails); nil != issue {
+		vuln.PackageName = issue.GetAffectedPackage()
+		vuln.PackageVersion = formatVersion(issue.GetAffectedVersion())
+		vuln.FixedBy = formatVersion(issue.GetFixedVersion())
+	}
+
+	for _, relatedURL := range vulnDetails.GetRelatedUrls() {
+		if "" != relatedURL.GetUrl() {
+			vuln.URLs = append(vuln.URLs, relatedURL.GetUrl())
+		}
+	}
+
+	return vuln
+}
+
+// noteCVSSVector returns the CVSS v3 vector of the passed vulnerability Note,
+// or an empty string if it does not have a complete CVSS v3 score. Container
+// Analysis only records the CVSS v3 metrics on the Note, while Occurrences
+// only have the score.
+func noteCVSSVector(note *grafeas.Note) string {
+	cvss := note.GetVulnerability().GetCvssV3()
+	if nil == cvss {
+		return ""
+	}
+
+	return voucher.CVSSv3Metrics{
+		AttackVector:          cvss.GetAttackVector().String(),
+		AttackComplexity:      cvss.GetAttackComplexity().String(),
+		PrivilegesRequired:    cvss.GetPrivilegesRequired().String(),
+		UserInteraction:       cvss.GetUserInteraction().String(),
+		Scope:                 cvss.GetScope().String(),
+		ConfidentialityImpact: cvss.GetConfidentialityImpact().String(),
+		IntegrityImpact:       cvss.GetIntegrityImpact().String(),
+		AvailabilityImpact:    cvss.GetAvailabilityImpact().String(),
+	}.Vector()
+}
+
+// formatVersion returns the passed version in the form [epoch:]name[-revision],
+// or an empty string if it is not a normal version.
+func formatVersion(version *grafeas.Version) string {
+	if grafeas.Version_NORMAL != version.GetKind() || "" == version.GetName() {
+		return ""
+	}
+
+	if "" != version.GetFullName() {
+		return version.GetFullName()
+	}
+
+	formatted := version.GetName()
+	if 0 != version.GetEpoch() {
+		formatted = fmt.Sprintf("%d:%s", version.GetEpoch(), formatted)
+	}
+	if "" != version.GetRevision() {
+		formatted += "-" + version.GetRevision()
+	}
+	return formatted
+}
+
+// getPackageIssue returns the first package issue which has a fix available,
+// or the first package issue if none of them do.
+func getPackageIssue(vulnDetails *grafeas.VulnerabilityOccurrence) *grafeas.VulnerabilityOccurrence_PackageIssue {
+	issues := vulnDetails.GetPackageIssue()
+	for _, issue := range issues {
+		if "" != formatVersion(issue.GetFixedVersion()) {
+			return issue
+		}
+	}
+
+	if 0 < len(issues) {
+		return issues[0]
+	}
+	return nil
 }
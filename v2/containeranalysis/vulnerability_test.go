@@ -0,0 +1,84 @@
+package containeranalysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	grafeas "google.golang.org/genproto/googleapis/grafeas/v1"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+func TestOccurrenceToVulnerability(t *testing.T) {
+	occ := &grafeas.Occurrence{
+		NoteName: vulProject + "CVE-2020-1234",
+		Details: &grafeas.Occurrence_Vulnerability{
+			Vulnerability: &grafeas.VulnerabilityOccurrence{
+				ShortDescription:  "a bad one",
+				EffectiveSeverity: grafeas.Severity_HIGH,
+				CvssScore:         7.5,
+				RelatedUrls: []*grafeas.RelatedUrl{
+					{Url: "https://security-tracker.debian.org/tracker/CVE-2020-1234"},
+				},
+				PackageIssue: []*grafeas.VulnerabilityOccurrence_PackageIssue{
+					{
+						AffectedPackage: "openssl",
+						AffectedVersion: &grafeas.Version{Kind: grafeas.Version_NORMAL, Name: "1.1.1c"},
+						FixedVersion:    &grafeas.Version{Kind: grafeas.Version_MAXIMUM},
+					},
+					{
+						AffectedPackage: "libssl",
+						AffectedVersion: &grafeas.Version{Kind: grafeas.Version_NORMAL, Name: "1.1.1c", Revision: "1"},
+						FixedVersion:    &grafeas.Version{Kind: grafeas.Version_NORMAL, Epoch: 1, Name: "1.1.1d", Revision: "0+deb10u3"},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, voucher.Vulnerability{
+		Name:           "CVE-2020-1234",
+		Description:    "a bad one",
+		Severity:       voucher.HighSeverity,
+		FixedBy:        "1:1.1.1d-0+deb10u3",
+		CVSSScore:      7.5,
+		PackageName:    "libssl",
+		PackageVersion: "1.1.1c-1",
+		URLs:           []string{"https://security-tracker.debian.org/tracker/CVE-2020-1234"},
+	}, OccurrenceToVulnerability(occ))
+
+	occ.GetVulnerability().PackageIssue = occ.GetVulnerability().PackageIssue[:1]
+	vuln := OccurrenceToVulnerability(occ)
+	assert.Equal(t, "", vuln.FixedBy)
+	assert.Equal(t, "openssl", vuln.PackageName)
+	assert.Equal(t, "1.1.1c", vuln.PackageVersion)
+}
+
+func TestNoteCVSSVector(t *testing.T) {
+	note := &grafeas.Note{
+		Name: vulProject + "CVE-2020-1234",
+		Type: &grafeas.Note_Vulnerability{
+			Vulnerability: &grafeas.VulnerabilityNote{
+				CvssScore: 9.8,
+				CvssV3: &grafeas.CVSSv3{
+					BaseScore:             9.8,
+					AttackVector:          grafeas.CVSSv3_ATTACK_VECTOR_NETWORK,
+					AttackComplexity:      grafeas.CVSSv3_ATTACK_COMPLEXITY_LOW,
+					PrivilegesRequired:    grafeas.CVSSv3_PRIVILEGES_REQUIRED_NONE,
+					UserInteraction:       grafeas.CVSSv3_USER_INTERACTION_NONE,
+					Scope:                 grafeas.CVSSv3_SCOPE_UNCHANGED,
+					ConfidentialityImpact: grafeas.CVSSv3_IMPACT_HIGH,
+					IntegrityImpact:       grafeas.CVSSv3_IMPACT_HIGH,
+					AvailabilityImpact:    grafeas.CVSSv3_IMPACT_HIGH,
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", noteCVSSVector(note))
+
+	note.GetVulnerability().CvssV3.Scope = grafeas.CVSSv3_SCOPE_UNSPECIFIED
+	assert.Equal(t, "", noteCVSSVector(note), "an incomplete score has no vector")
+
+	assert.Equal(t, "", noteCVSSVector(&grafeas.Note{}))
+}
@@ -0,0 +1,66 @@
+package voucher
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cvssV3Values are the abbreviations used in CVSS v3 vectors for the last
+// word of the names of Grafeas' CVSSv3 enum values, such as "NETWORK" in
+// "ATTACK_VECTOR_NETWORK".
+var cvssV3Values = map[string]string{
+	"NETWORK":   "N",
+	"ADJACENT":  "A",
+	"LOCAL":     "L",
+	"PHYSICAL":  "P",
+	"LOW":       "L",
+	"HIGH":      "H",
+	"NONE":      "N",
+	"REQUIRED":  "R",
+	"UNCHANGED": "U",
+	"CHANGED":   "C",
+}
+
+// CVSSv3Metrics are the base metrics of a CVSS v3 score, as the names of the
+// values of Grafeas' CVSSv3 enums, such as "ATTACK_VECTOR_NETWORK" or
+// "IMPACT_HIGH".
+type CVSSv3Metrics struct {
+	AttackVector          string
+	AttackComplexity      string
+	PrivilegesRequired    string
+	UserInteraction       string
+	Scope                 string
+	ConfidentialityImpact string
+	IntegrityImpact       string
+	AvailabilityImpact    string
+}
+
+// Vector returns the CVSS v3 vector for the metrics, such as
+// "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H". If any of the metrics is
+// unspecified or unknown, an empty string is returned.
+func (metrics CVSSv3Metrics) Vector() string {
+	values := []struct {
+		abbreviation string
+		value        string
+	}{
+		{"AV", metrics.AttackVector},
+		{"AC", metrics.AttackComplexity},
+		{"PR", metrics.PrivilegesRequired},
+		{"UI", metrics.UserInteraction},
+		{"S", metrics.Scope},
+		{"C", metrics.ConfidentialityImpact},
+		{"I", metrics.IntegrityImpact},
+		{"A", metrics.AvailabilityImpact},
+	}
+
+	vector := "CVSS:3.0"
+	for _, metric := range values {
+		value, ok := cvssV3Values[metric.value[strings.LastIndex(metric.value, "_")+1:]]
+		if !ok {
+			return ""
+		}
+		vector += fmt.Sprintf("/%s:%s", metric.abbreviation, value)
+	}
+
+	return vector
+}
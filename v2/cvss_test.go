@@ -0,0 +1,27 @@
+package voucher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCVSSv3MetricsVector(t *testing.T) {
+	metrics := CVSSv3Metrics{
+		AttackVector:          "ATTACK_VECTOR_NETWORK",
+		AttackComplexity:      "ATTACK_COMPLEXITY_LOW",
+		PrivilegesRequired:    "PRIVILEGES_REQUIRED_NONE",
+		UserInteraction:       "USER_INTERACTION_REQUIRED",
+		Scope:                 "SCOPE_CHANGED",
+		ConfidentialityImpact: "IMPACT_HIGH",
+		IntegrityImpact:       "IMPACT_LOW",
+		AvailabilityImpact:    "IMPACT_NONE",
+	}
+
+	assert.Equal(t, "CVSS:3.0/AV:N/AC:L/PR:N/UI:R/S:C/C:H/I:L/A:N", metrics.Vector())
+
+	metrics.Scope = "SCOPE_UNSPECIFIED"
+	assert.Equal(t, "", metrics.Vector())
+
+	assert.Equal(t, "", CVSSv3Metrics{}.Vector())
+}
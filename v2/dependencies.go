@@ -0,0 +1,132 @@
+package voucher
+
+import (
+	"sort"
+)
+
+// ErrDependencyFailed is the error reported for a Check that was skipped
+// because a Check it depends on did not pass.
+var ErrDependencyFailed = NewCheckError(ErrorCodeDependencyFailed, "skipped: dependency failed")
+
+// ErrDependencyCycle is the error reported for a Check that was not run
+// because its dependencies form a cycle.
+var ErrDependencyCycle = NewCheckError(ErrorCodeDependencyCycle, "check is part of a dependency cycle")
+
+// dependencyGraph tracks which Checks in a Suite are waiting on other Checks
+// to pass before they can run.
+type dependencyGraph struct {
+	waiting    map[string]map[string]bool
+	dependents map[string][]string
+}
+
+// newDependencyGraph creates a dependencyGraph for the Checks in the Suite.
+// Dependencies on Checks that are not in the Suite are ignored.
+func (cs *Suite) newDependencyGraph() *dependencyGraph {
+	graph := &dependencyGraph{
+		waiting:    make(map[string]map[string]bool, len(cs.checks)),
+		dependents: make(map[string][]string, len(cs.checks)),
+	}
+
+	for name := range cs.checks {
+		graph.waiting[name] = make(map[string]bool)
+		for _, dependency := range cs.dependencies[name] {
+			if cs.Has(dependency) && dependency != name {
+				graph.waiting[name][dependency] = true
+				graph.dependents[dependency] = append(graph.dependents[dependency], name)
+			}
+		}
+	}
+
+	return graph
+}
+
+// ready returns the names of the Checks which are no longer waiting on any
+// other Checks, and stops tracking them.
+func (graph *dependencyGraph) ready() []string {
+	names := make([]string, 0, len(graph.waiting))
+	for name, dependencies := range graph.waiting {
+		if 0 == len(dependencies) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		delete(graph.waiting, name)
+	}
+
+	return names
+}
+
+// complete marks the Check with the passed name as finished. If it did not
+// pass, every Check that depends on it (directly or not) is removed from the
+// graph, and returned mapped to the name of the dependency that stopped it.
+func (graph *dependencyGraph) complete(name string, passed bool) map[string]string {
+	skipped := make(map[string]string)
+	graph.finish(name, passed, skipped)
+	return skipped
+}
+
+// finish implements complete, adding any skipped Checks to the passed map.
+func (graph *dependencyGraph) finish(name string, passed bool, skipped map[string]string) {
+	for _, dependent := range graph.dependents[name] {
+		dependencies, ok := graph.waiting[dependent]
+		if !ok {
+			continue
+		}
+
+		if passed {
+			delete(dependencies, name)
+			continue
+		}
+
+		delete(graph.waiting, dependent)
+		skipped[dependent] = name
+		graph.finish(dependent, false, skipped)
+	}
+}
+
+// remaining returns the names of the Checks that are still waiting. Once no
+// Checks are running, these are the Checks that are part of a cycle.
+func (graph *dependencyGraph) remaining() []string {
+	names := make([]string, 0, len(graph.waiting))
+	for name := range graph.waiting {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// order returns the names of the Checks in the Suite sorted so that every
+// Check comes after the Checks it depends on. Checks that are part of a cycle
+// come last.
+func (cs *Suite) order() []string {
+	graph := cs.newDependencyGraph()
+	names := make([]string, 0, len(cs.checks))
+
+	for ready := graph.ready(); 0 != len(ready); ready = graph.ready() {
+		for _, name := range ready {
+			graph.complete(name, true)
+		}
+		names = append(names, ready...)
+	}
+
+	return append(names, graph.remaining()...)
+}
+
+// newSkippedResult creates a CheckResult for a Check that was not run because
+// the named dependency did not pass.
+func newSkippedResult(name, dependency string, imageData ImageData) CheckResult {
+	return CheckResult{
+		Name:      name,
+		ImageData: imageData,
+		Err:       ErrDependencyFailed.Error() + ": " + dependency,
+		ErrCode:   ErrorCodeOf(ErrDependencyFailed),
+		ErrDetails: map[string]string{
+			"dependency": dependency,
+		},
+		Success: false,
+		Skipped: true,
+	}
+}
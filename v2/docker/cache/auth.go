@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/docker/distribution/reference"
+	"golang.org/x/oauth2"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/metrics"
+)
+
+// cachedAuth is a voucher.Auth whose clients read manifests and blobs from a
+// Cache, rather than requesting them from the registry each time.
+type cachedAuth struct {
+	auth    voucher.Auth
+	cache   *Cache
+	metrics metrics.Client
+}
+
+// GetTokenSource returns the oauth2.TokenSource of the wrapped Auth.
+func (a *cachedAuth) GetTokenSource(ctx context.Context, image reference.Named) (oauth2.TokenSource, error) {
+	return a.auth.GetTokenSource(ctx, image)
+}
+
+// ToClient returns the http.Client of the wrapped Auth, with its Transport
+// wrapped so that manifests and blobs requested by digest use the Cache.
+func (a *cachedAuth) ToClient(ctx context.Context, image reference.Named) (*http.Client, error) {
+	client, err := a.auth.ToClient(ctx, image)
+	if nil != err {
+		return nil, err
+	}
+
+	base := client.Transport
+	if nil == base {
+		base = http.DefaultTransport
+	}
+
+	cachedClient := *client
+	cachedClient.Transport = &transport{
+		base:    base,
+		cache:   a.cache,
+		metrics: a.metrics,
+	}
+
+	return &cachedClient, nil
+}
+
+// IsForDomain returns true if the wrapped Auth is for the passed image's
+// domain.
+func (a *cachedAuth) IsForDomain(image reference.Named) bool {
+	return a.auth.IsForDomain(image)
+}
+
+// NewAuth wraps the passed Auth, so that the manifests and blobs requested
+// with its clients are read from the passed Cache when they are in it, and
+// added to it when they are not. Cache hits and misses are reported to the
+// passed metrics.Client.
+func NewAuth(auth voucher.Auth, cache *Cache, metricsClient metrics.Client) voucher.Auth {
+	return &cachedAuth{
+		auth:    auth,
+		cache:   cache,
+		metrics: metricsClient,
+	}
+}
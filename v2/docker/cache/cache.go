@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// MaxEntrySize is the size of the largest response that is cached, in bytes.
+// This is large enough for manifests and image configurations, which
+// registries limit to 4 MiB, but keeps image layers out of the Cache.
+const MaxEntrySize = 4 << 20
+
+// key identifies a cached artifact by its digest, and the registry and
+// repository it was requested from. Registries grant access per repository, so
+// an artifact is not served to requests for other repositories, even though
+// its content would be the same.
+type key struct {
+	repository string // Registry host and repository path, such as "gcr.io/path/to/image".
+	digest     digest.Digest
+}
+
+// entry is a cached registry response.
+type entry struct {
+	key         key
+	contentType string
+	body        []byte
+}
+
+// call is a request for an artifact which is in progress. Other requests for
+// the same artifact wait for it to finish, rather than requesting it again.
+type call struct {
+	done chan struct{}
+}
+
+// Cache is a least recently used cache of registry artifacts, such as image
+// manifests and configurations, keyed by their digests and the repositories
+// they were requested from. As the content of an artifact is addressed by its
+// digest, a Cache can be shared between requests, but an artifact is only
+// served again for the repository that the registry returned it for. It is
+// safe for concurrent use.
+type Cache struct {
+	maxEntries int
+
+	mu       sync.Mutex
+	entries  map[key]*list.Element
+	order    *list.List
+	inflight map[key]*call
+}
+
+// getLocked returns the entry for the passed key, marking it as recently
+// used. The Cache must be locked.
+func (c *Cache) getLocked(k key) (*entry, bool) {
+	element, ok := c.entries[k]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*entry), true
+}
+
+// add adds the passed entry to the Cache, removing the least recently used
+// entry if the Cache is full.
+func (c *Cache) add(e *entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[e.key]; ok {
+		element.Value = e
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[e.key] = c.order.PushFront(e)
+
+	if 0 < c.maxEntries && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+}
+
+// start returns the entry for the passed key if it is cached. Otherwise, if
+// another request for the artifact is in progress, it waits for it to finish
+// (or for the passed context to be done) and tries again. If not, it records
+// that the caller is requesting the artifact, and returns false; the caller
+// must then call finish.
+func (c *Cache) start(ctx context.Context, k key) (*entry, bool, error) {
+	for {
+		c.mu.Lock()
+		if e, ok := c.getLocked(k); ok {
+			c.mu.Unlock()
+			return e, true, nil
+		}
+
+		inflight, ok := c.inflight[k]
+		if !ok {
+			c.inflight[k] = &call{done: make(chan struct{})}
+			c.mu.Unlock()
+			return nil, false, nil
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-inflight.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+}
+
+// finish records that the caller's request for the passed key is done,
+// adding the passed entry to the Cache if it is not nil, and wakes up the
+// requests waiting for it. If the request failed, one of them requests the
+// artifact itself.
+func (c *Cache) finish(k key, e *entry) {
+	if nil != e {
+		c.add(e)
+	}
+
+	c.mu.Lock()
+	inflight := c.inflight[k]
+	delete(c.inflight, k)
+	c.mu.Unlock()
+
+	if nil != inflight {
+		close(inflight.done)
+	}
+}
+
+// Len returns the number of entries in the Cache.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// New creates a new Cache which holds up to the passed number of entries. If
+// maxEntries is 0, the Cache is not limited, which is suitable for a Cache
+// that is only used for a single request.
+func New(maxEntries int) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		entries:    make(map[key]*list.Element),
+		order:      list.New(),
+		inflight:   make(map[key]*call),
+	}
+}
@@ -0,0 +1,294 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/docker/distribution/reference"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafeas/voucher/v2/docker"
+	"github.com/grafeas/voucher/v2/metrics"
+	vtesting "github.com/grafeas/voucher/v2/testing"
+)
+
+// countingMetrics is a metrics.Client which counts cache hits and misses.
+type countingMetrics struct {
+	metrics.NoopClient
+
+	mu     sync.Mutex
+	hits   map[string]int
+	misses map[string]int
+}
+
+func (m *countingMetrics) RegistryCacheHit(kind string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hits[kind]++
+}
+
+func (m *countingMetrics) RegistryCacheMiss(kind string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.misses[kind]++
+}
+
+func newCountingMetrics() *countingMetrics {
+	return &countingMetrics{
+		hits:   make(map[string]int),
+		misses: make(map[string]int),
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	cache := New(2)
+
+	first := &entry{key: key{repository: "gcr.io/path/to/image", digest: digest.FromString("first")}}
+	second := &entry{key: key{repository: "gcr.io/path/to/image", digest: digest.FromString("second")}}
+	third := &entry{key: key{repository: "gcr.io/path/to/image", digest: digest.FromString("third")}}
+
+	cache.add(first)
+	cache.add(second)
+
+	_, ok := cache.getLocked(first.key)
+	require.True(t, ok)
+
+	cache.add(third)
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok = cache.getLocked(second.key)
+	assert.False(t, ok, "the least recently used entry should be removed")
+
+	_, ok = cache.getLocked(first.key)
+	assert.True(t, ok)
+}
+
+func TestCachedAuth(t *testing.T) {
+	ref := vtesting.NewTestReference(t)
+
+	server := vtesting.NewTestDockerServer(t)
+	defer server.Close()
+
+	m := newCountingMetrics()
+	cache := New(0)
+	auth := NewAuth(vtesting.NewAuth(server), cache, m)
+
+	for i := 0; i < 2; i++ {
+		client, err := auth.ToClient(context.Background(), ref)
+		require.NoError(t, err)
+
+		config, err := docker.RequestImageConfig(client, ref)
+		require.NoError(t, err)
+		assert.False(t, config.RunsAsRoot())
+	}
+
+	assert.Equal(t, map[string]int{manifestKind: 1, blobKind: 1}, m.misses)
+	assert.Equal(t, map[string]int{manifestKind: 1, blobKind: 1}, m.hits)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestCachedAuthDoesNotCacheTamperedContent(t *testing.T) {
+	ref := vtesting.NewTamperedManifestTestReference(t)
+
+	server := vtesting.NewTestDockerServer(t)
+	defer server.Close()
+
+	m := newCountingMetrics()
+	cache := New(0)
+	auth := NewAuth(vtesting.NewAuth(server), cache, m)
+
+	for i := 0; i < 2; i++ {
+		client, err := auth.ToClient(context.Background(), ref)
+		require.NoError(t, err)
+
+		_, err = docker.RequestManifest(client, ref)
+		assert.True(t, docker.IsDigestMismatchError(err))
+	}
+
+	assert.Equal(t, 2, m.misses[manifestKind])
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestCachedAuthFollowsRedirects(t *testing.T) {
+	const blob = `{"container_config":{"User":"nobody"}}`
+	blobDigest := digest.FromString(blob)
+
+	var requests int32
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if "/storage" == req.URL.Path {
+			fmt.Fprint(writer, blob)
+			return
+		}
+		http.Redirect(writer, req, server.URL+"/storage", http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	m := newCountingMetrics()
+	client := server.Client()
+	client.Transport = &transport{
+		base:    client.Transport,
+		cache:   New(0),
+		metrics: m,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			resp, err := client.Get(server.URL + "/v2/path/to/image/blobs/" + blobDigest.String())
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			b, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, blob, string(b))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "concurrent requests should wait for the first one")
+	assert.Equal(t, 1, m.misses[blobKind])
+	assert.Equal(t, 4, m.hits[blobKind])
+}
+
+// failingBody is a response body which fails to be read.
+type failingBody struct {
+	closed bool
+}
+
+func (b *failingBody) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func (b *failingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+// roundTripperFunc is an http.RoundTripper which calls itself.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransportBodyReadFailure(t *testing.T) {
+	body := new(failingBody)
+
+	cache := New(0)
+	tr := &transport{
+		base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				Header:        make(http.Header),
+				Body:          body,
+				ContentLength: -1,
+				Request:       req,
+			}, nil
+		}),
+		cache:   cache,
+		metrics: &metrics.NoopClient{},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://gcr.io/v2/path/to/image/blobs/"+digest.FromString("blob").String(), nil)
+	require.NoError(t, err)
+
+	resp, err := tr.RoundTrip(req)
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	assert.True(t, body.closed, "the body should be closed")
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestTransportIsKeyedByRepository(t *testing.T) {
+	const blob = `{"container_config":{"User":"nobody"}}`
+	blobDigest := digest.FromString(blob)
+
+	var requests int32
+	m := newCountingMetrics()
+	tr := &transport{
+		base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&requests, 1)
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				Header:        make(http.Header),
+				Body:          ioutil.NopCloser(strings.NewReader(blob)),
+				ContentLength: int64(len(blob)),
+				Request:       req,
+			}, nil
+		}),
+		cache:   New(0),
+		metrics: m,
+	}
+
+	urls := []string{
+		"https://gcr.io/v2/path/to/image/blobs/",
+		"https://gcr.io/v2/path/to/other/blobs/",
+		"https://ghcr.io/v2/path/to/image/blobs/",
+		"https://gcr.io/v2/path/to/image/blobs/",
+	}
+
+	for _, url := range urls {
+		req, err := http.NewRequest(http.MethodGet, url+blobDigest.String(), nil)
+		require.NoError(t, err)
+
+		resp, err := tr.RoundTrip(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests), "artifacts should only be served from the cache for the repository they were requested from")
+	assert.Equal(t, 3, m.misses[blobKind])
+	assert.Equal(t, 1, m.hits[blobKind])
+}
+
+func TestArtifactKey(t *testing.T) {
+	d := "sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9"
+
+	cases := map[string]string{
+		"https://gcr.io/v2/path/to/image/manifests/" + d:   manifestKind,
+		"https://gcr.io/v2/path/to/image/blobs/" + d:       blobKind,
+		"https://gcr.io/v2/path/to/image/manifests/latest": "",
+		"https://gcr.io/v2/path/to/image/tags/list":        "",
+		"https://storage.example.com/blobs/" + d:           "",
+	}
+
+	for url, expected := range cases {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+
+		kind, k, ok := artifactKey(req)
+		assert.Equal(t, "" != expected, ok, url)
+		assert.Equal(t, expected, kind, url)
+		if ok {
+			assert.Equal(t, key{repository: "gcr.io/path/to/image", digest: digest.Digest(d)}, k, url)
+		}
+	}
+}
+
+func TestCachedAuthIsForDomain(t *testing.T) {
+	server := vtesting.NewTestDockerServer(t)
+	defer server.Close()
+
+	auth := NewAuth(vtesting.NewAuth(server), New(0), &metrics.NoopClient{})
+
+	other, err := reference.ParseNamed("ghcr.io/path/to/image")
+	require.NoError(t, err)
+
+	assert.True(t, auth.IsForDomain(vtesting.NewTestReference(t)))
+	assert.False(t, auth.IsForDomain(other))
+}
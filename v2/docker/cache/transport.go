@@ -0,0 +1,216 @@
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	digest "github.com/opencontainers/go-digest"
+
+	"github.com/grafeas/voucher/v2/metrics"
+)
+
+// Kinds of registry artifacts, as reported to the metrics client.
+const (
+	manifestKind = "manifest"
+	blobKind     = "blob"
+)
+
+// maxRedirects is the number of redirects that are followed when requesting
+// an artifact, which is the same as http.Client's default.
+const maxRedirects = 10
+
+// errTooManyRedirects is returned when a registry redirects a request for an
+// artifact too many times.
+var errTooManyRedirects = errors.New("stopped after 10 redirects")
+
+// transport is an http.RoundTripper which answers requests for manifests and
+// blobs by digest from a Cache, and adds the responses to others to it.
+type transport struct {
+	base    http.RoundTripper
+	cache   *Cache
+	metrics metrics.Client
+}
+
+// RoundTrip implements http.RoundTripper. Requests which are not for an
+// artifact by digest are passed to the base http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	kind, k, ok := artifactKey(req)
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+
+	e, hit, err := t.cache.start(req.Context(), k)
+	if nil != err {
+		return nil, err
+	}
+
+	if hit {
+		t.metrics.RegistryCacheHit(kind)
+		return e.response(req), nil
+	}
+
+	t.metrics.RegistryCacheMiss(kind)
+
+	resp, err := t.fetch(req)
+	if nil == err {
+		e, err = readEntry(k, resp)
+	}
+
+	t.cache.finish(k, e)
+
+	// readEntry closes the response's body if it could not be read.
+	if nil != err {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// fetch requests the artifact, following redirects itself so that blobs which
+// are served from other hosts, such as a storage bucket, can be cached.
+func (t *transport) fetch(req *http.Request) (*http.Response, error) {
+	for i := 0; ; i++ {
+		resp, err := t.base.RoundTrip(req)
+		if nil != err {
+			return nil, err
+		}
+
+		location, err := resp.Location()
+		if !isRedirect(resp.StatusCode) || nil != err {
+			return resp, nil
+		}
+
+		if maxRedirects <= i {
+			resp.Body.Close()
+			return nil, errTooManyRedirects
+		}
+
+		redirect, err := http.NewRequest(http.MethodGet, location.String(), nil)
+		if nil != err {
+			resp.Body.Close()
+			return nil, err
+		}
+
+		redirect = redirect.WithContext(req.Context())
+		redirect.Header.Set("Accept", req.Header.Get("Accept"))
+
+		resp.Body.Close()
+		req = redirect
+	}
+}
+
+// isRedirect returns true if the passed status code is a redirect with a
+// location to follow.
+func isRedirect(statusCode int) bool {
+	switch statusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// readEntry reads the body of the passed response, and returns it as an entry
+// for the passed key if it can be cached: if the request succeeded, is no
+// larger than MaxEntrySize, and hashes to the requested digest. The
+// response's body is replaced so that it can still be read by the caller.
+func readEntry(k key, resp *http.Response) (*entry, error) {
+	d := k.digest
+
+	if http.StatusOK != resp.StatusCode || MaxEntrySize < resp.ContentLength {
+		return nil, nil
+	}
+
+	body := resp.Body
+
+	b, err := ioutil.ReadAll(io.LimitReader(body, MaxEntrySize+1))
+	if nil != err {
+		body.Close()
+		return nil, err
+	}
+
+	if MaxEntrySize < len(b) {
+		resp.Body = &multiReadCloser{
+			Reader: io.MultiReader(bytes.NewReader(b), body),
+			Closer: body,
+		}
+		return nil, nil
+	}
+
+	body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	if !d.Algorithm().Available() || d != d.Algorithm().FromBytes(b) {
+		return nil, nil
+	}
+
+	return &entry{
+		key:         k,
+		contentType: resp.Header.Get("Content-Type"),
+		body:        b,
+	}, nil
+}
+
+// response creates a response to the passed request from the entry.
+func (e *entry) response(req *http.Request) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", e.contentType)
+	header.Set("Content-Length", strconv.Itoa(len(e.body)))
+	header.Set("Docker-Content-Digest", e.key.digest.String())
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// artifactKey returns the kind and key of the artifact that the passed
+// request is for, and false if it is not a request for a manifest or blob by
+// digest, such as "/v2/path/to/image/manifests/sha256:<hex>". The key's
+// repository is the request's host and repository path, such as
+// "gcr.io/path/to/image".
+func artifactKey(req *http.Request) (string, key, bool) {
+	if http.MethodGet != req.Method || !strings.HasPrefix(req.URL.Path, "/v2/") {
+		return "", key{}, false
+	}
+
+	segments := strings.Split(req.URL.Path, "/")
+	if 5 > len(segments) {
+		return "", key{}, false
+	}
+
+	var kind string
+	switch segments[len(segments)-2] {
+	case "manifests":
+		kind = manifestKind
+	case "blobs":
+		kind = blobKind
+	default:
+		return "", key{}, false
+	}
+
+	d, err := digest.Parse(segments[len(segments)-1])
+	if nil != err {
+		return "", key{}, false
+	}
+
+	repository := req.URL.Host + "/" + strings.Join(segments[2:len(segments)-2], "/")
+	return kind, key{repository: repository, digest: d}, true
+}
+
+// multiReadCloser reads from its Reader, and closes its Closer.
+type multiReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -4,28 +4,124 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/docker/distribution"
 	"github.com/docker/distribution/reference"
 	dockerTypes "github.com/docker/docker/api/types"
+	digest "github.com/opencontainers/go-digest"
 
+	"github.com/grafeas/voucher/v2/docker/ocischema"
 	"github.com/grafeas/voucher/v2/docker/schema1"
 	"github.com/grafeas/voucher/v2/docker/schema2"
 )
 
+// PlatformImageConfig is the ImageConfig of the image for one Platform of an
+// image index.
+type PlatformImageConfig struct {
+	ImageConfig
+	Platform Platform
+	Digest   digest.Digest
+}
+
 // RequestImageConfig requests an image configuration from the server, based on the passed
-// reference. Returns an ImageConfig or an error.
-func RequestImageConfig(client *http.Client, ref reference.Canonical) (ImageConfig, error) {
+// reference. Returns an ImageConfig or an error. If the reference is to an image index,
+// the configuration of the image for the first of the passed Platforms that the index
+// has is returned. If no Platforms are passed, DefaultPlatform is used, or the first
+// image in the index if it doesn't have one.
+func RequestImageConfig(client *http.Client, ref reference.Canonical, platforms ...Platform) (ImageConfig, error) {
+	manifest, err := RequestManifest(client, ref)
+	if nil != err {
+		return nil, err
+	}
+
+	if IsIndex(manifest) {
+		platformManifest, err := resolveManifest(manifest, platforms)
+		if nil != err {
+			return nil, NewConfigError(err)
+		}
+
+		ref, manifest, err = requestPlatformManifest(client, ref, platformManifest)
+		if nil != err {
+			return nil, err
+		}
+	}
+
+	return requestConfig(client, ref, manifest)
+}
+
+// RequestImageConfigs requests the image configuration of each image in an image index
+// that runs on one of the passed Platforms, or of every image in the index if no
+// Platforms are passed. If the reference is not to an image index, its configuration
+// is returned with an empty Platform.
+func RequestImageConfigs(client *http.Client, ref reference.Canonical, platforms ...Platform) ([]PlatformImageConfig, error) {
 	manifest, err := RequestManifest(client, ref)
 	if nil != err {
 		return nil, err
 	}
 
+	if !IsIndex(manifest) {
+		config, err := requestConfig(client, ref, manifest)
+		if nil != err {
+			return nil, err
+		}
+		return []PlatformImageConfig{{ImageConfig: config, Digest: ref.Digest()}}, nil
+	}
+
+	platformManifests, err := selectManifests(manifest, platforms)
+	if nil != err {
+		return nil, NewConfigError(err)
+	}
+
+	configs := make([]PlatformImageConfig, 0, len(platformManifests))
+	for _, platformManifest := range platformManifests {
+		platformRef, platformImageManifest, err := requestPlatformManifest(client, ref, platformManifest)
+		if nil != err {
+			return nil, err
+		}
+
+		config, err := requestConfig(client, platformRef, platformImageManifest)
+		if nil != err {
+			return nil, err
+		}
+
+		configs = append(configs, PlatformImageConfig{
+			ImageConfig: config,
+			Platform:    platformManifest.Platform,
+			Digest:      platformManifest.Digest,
+		})
+	}
+
+	return configs, nil
+}
+
+// requestPlatformManifest requests the manifest of the passed image from an
+// image index, which is in the same repository as the index.
+func requestPlatformManifest(client *http.Client, ref reference.Canonical, platformManifest PlatformManifest) (reference.Canonical, distribution.Manifest, error) {
+	platformRef, err := reference.WithDigest(reference.TrimNamed(ref), platformManifest.Digest)
+	if nil != err {
+		return nil, nil, err
+	}
+
+	manifest, err := RequestManifest(client, platformRef)
+	if nil != err {
+		return nil, nil, err
+	}
+
+	return platformRef, manifest, nil
+}
+
+// requestConfig requests the image configuration described by the passed
+// image manifest.
+func requestConfig(client *http.Client, ref reference.Canonical, manifest distribution.Manifest) (ImageConfig, error) {
 	var config *dockerTypes.ExecConfig
+	var err error
 
 	switch {
 	case schema1.IsManifest(manifest):
 		config, err = schema1.RequestConfig(client, ref, manifest)
 	case schema2.IsManifest(manifest):
 		config, err = schema2.RequestConfig(client, ref, manifest)
+	case ocischema.IsManifest(manifest):
+		config, err = ocischema.RequestConfig(client, ref, manifest)
 	default:
 		err = errors.New("image does not have any configuration")
 	}
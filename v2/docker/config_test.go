@@ -3,6 +3,8 @@ package docker
 import (
 	"testing"
 
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	vtesting "github.com/grafeas/voucher/v2/testing"
@@ -18,3 +20,84 @@ func TestRequestConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, config.RunsAsRoot())
 }
+
+func TestRequestOCIConfig(t *testing.T) {
+	ref := vtesting.NewTestOCIReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	config, err := RequestImageConfig(client, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "nobody", config.Config().User)
+}
+
+func TestRequestIndexConfig(t *testing.T) {
+	ref := vtesting.NewTestIndexReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	config, err := RequestImageConfig(client, ref)
+	require.NoError(t, err)
+	assert.False(t, config.RunsAsRoot(), "the default platform should be linux/amd64")
+
+	config, err = RequestImageConfig(client, ref, Platform{OS: "linux", Architecture: "arm64"})
+	require.NoError(t, err)
+	assert.True(t, config.RunsAsRoot())
+
+	config, err = RequestImageConfig(client, ref, Platform{OS: "linux", Architecture: "s390x"}, Platform{OS: "linux", Architecture: "amd64"})
+	require.NoError(t, err)
+	assert.False(t, config.RunsAsRoot(), "the first platform the index has should be used")
+
+	_, err = RequestImageConfig(client, ref, Platform{OS: "windows", Architecture: "amd64"})
+	assert.EqualError(t, err, "failed to load config: image index does not have an image for windows/amd64")
+}
+
+func TestRequestIndexConfigs(t *testing.T) {
+	ref := vtesting.NewTestIndexReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	configs, err := RequestImageConfigs(client, ref)
+	require.NoError(t, err)
+	require.Len(t, configs, 2, "the attestation manifest should be skipped")
+
+	assert.Equal(t, Platform{OS: "linux", Architecture: "amd64"}, configs[0].Platform)
+	assert.Equal(t, digest.Digest("sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9"), configs[0].Digest)
+	assert.False(t, configs[0].RunsAsRoot())
+	assert.Equal(t, Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}, configs[1].Platform)
+	assert.True(t, configs[1].RunsAsRoot())
+
+	configs, err = RequestImageConfigs(client, ref, Platform{OS: "linux", Architecture: "arm64", Variant: "v8"})
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.True(t, configs[0].RunsAsRoot())
+}
+
+func TestRequestImageConfigsWithoutIndex(t *testing.T) {
+	ref := vtesting.NewTestReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	configs, err := RequestImageConfigs(client, ref)
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, Platform{}, configs[0].Platform)
+	assert.Equal(t, ref.Digest(), configs[0].Digest)
+	assert.False(t, configs[0].RunsAsRoot())
+}
+
+func TestRequestTamperedConfig(t *testing.T) {
+	ref := vtesting.NewTamperedConfigTestReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	_, err := RequestImageConfig(client, ref)
+	require.Error(t, err, "should have failed to get a config with the wrong digest, but didn't")
+	assert.True(t, IsDigestMismatchError(err))
+	assert.Contains(t, err.Error(), "config digest mismatch: expected sha256:971e93cb33faeed22cb48192a7e6d0b41ddd252f3acb27514a53157442be661d")
+}
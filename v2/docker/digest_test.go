@@ -23,7 +23,7 @@ func TestGetDigestFromTagged(t *testing.T) {
 	imageDigest, err := GetDigestFromTagged(client, taggedRef)
 	require.NoErrorf(t, err, "failed to get digest reference: %s", err)
 
-	assert.Equal(t, digest.Digest("sha256:b148c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da"), imageDigest)
+	assert.Equal(t, digest.Digest("sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9"), imageDigest)
 }
 
 func TestGetBadDigestFromTagged(t *testing.T) {
@@ -53,5 +53,5 @@ func TestRequestV1Digest(t *testing.T) {
 	imageDigest, err := GetDigestFromTagged(client, taggedRef)
 	require.NoErrorf(t, err, "failed to get digest reference: %s", err)
 
-	assert.Equal(t, digest.Digest("sha256:18e6e7971438ab792d13563dcd8972acf4445bc0dcfdff84a6374d63a9c3ed62"), imageDigest)
+	assert.Equal(t, digest.Digest("sha256:af0ddbf967bc9796ddb1cec96a1c30d626c65842a2c9dc1672ee358cc452f836"), imageDigest)
 }
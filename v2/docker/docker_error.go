@@ -1,6 +1,10 @@
 package docker
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/grafeas/voucher/v2/docker/verify"
+)
 
 const (
 	manifestType = "manifest"
@@ -25,6 +29,21 @@ func (err *APIError) Error() string {
 	return fmt.Sprintf("failed to load %s: %s", err.callType, err.err)
 }
 
+// Unwrap returns the error wrapped by the APIError, if there is one.
+func (err *APIError) Unwrap() error {
+	return err.err
+}
+
+// DigestMismatchError is returned when a manifest or image configuration
+// does not hash to the digest that it was requested by.
+type DigestMismatchError = verify.DigestMismatchError
+
+// IsDigestMismatchError returns true if the passed error is a
+// DigestMismatchError, or wraps one.
+func IsDigestMismatchError(err error) bool {
+	return verify.IsDigestMismatchError(err)
+}
+
 // NewManifestError creates a new APIError specific to docker manifest requests.
 // This version wraps the passed error.
 func NewManifestError(err error) error {
@@ -4,11 +4,14 @@ import (
 	dockerTypes "github.com/docker/docker/api/types"
 )
 
-// ImageConfig represents an Docker image configuration. This presently just
-// allows us to verify if an image runs as root or not.
+// ImageConfig represents an Docker image configuration.
 type ImageConfig interface {
 	// RunsAsRoot returns true if the passed image will run as the root user.
 	RunsAsRoot() bool
+
+	// Config returns the execution configuration of the image, such as the
+	// user it runs as and its environment.
+	Config() dockerTypes.ExecConfig
 }
 
 type imageConfig struct {
@@ -21,3 +24,8 @@ func (config *imageConfig) RunsAsRoot() bool {
 
 	return ("" == user || "root" == user || "0:0" == user || "0" == user)
 }
+
+// Config returns the execution configuration of the image.
+func (config *imageConfig) Config() dockerTypes.ExecConfig {
+	return config.ExecConfig
+}
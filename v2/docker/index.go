@@ -0,0 +1,122 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// ErrEmptyIndex is returned when an image index has no images that run on a
+// platform.
+var ErrEmptyIndex = errors.New("image index does not have any images")
+
+// PlatformManifest is an image in an image index, and the Platform it runs
+// on.
+type PlatformManifest struct {
+	Platform Platform
+	Digest   digest.Digest
+}
+
+// IsIndex returns true if the passed manifest is an OCI image index or a
+// Docker manifest list, which lists an image for each Platform.
+func IsIndex(manifest distribution.Manifest) bool {
+	_, ok := manifest.(*manifestlist.DeserializedManifestList)
+	return ok
+}
+
+// IndexManifests returns the images in the passed image index. Entries that
+// are not images for a Platform, such as the attestation manifests that
+// BuildKit adds to indexes, are skipped.
+func IndexManifests(manifest distribution.Manifest) []PlatformManifest {
+	list, ok := manifest.(*manifestlist.DeserializedManifestList)
+	if !ok {
+		return nil
+	}
+
+	manifests := make([]PlatformManifest, 0, len(list.Manifests))
+	for _, descriptor := range list.Manifests {
+		if "" == descriptor.Platform.OS || "unknown" == descriptor.Platform.OS {
+			continue
+		}
+
+		manifests = append(manifests, PlatformManifest{
+			Platform: toPlatform(descriptor.Platform),
+			Digest:   descriptor.Digest,
+		})
+	}
+
+	return manifests
+}
+
+// selectManifests returns the images in the passed image index that run on
+// one of the passed Platforms, in the order of the index. If no Platforms are
+// passed, every image is returned.
+func selectManifests(manifest distribution.Manifest, platforms []Platform) ([]PlatformManifest, error) {
+	manifests := IndexManifests(manifest)
+	if 0 == len(manifests) {
+		return nil, ErrEmptyIndex
+	}
+
+	if 0 == len(platforms) {
+		return manifests, nil
+	}
+
+	selected := make([]PlatformManifest, 0, len(platforms))
+	for _, m := range manifests {
+		for _, platform := range platforms {
+			if platform.matches(m.Platform) {
+				selected = append(selected, m)
+				break
+			}
+		}
+	}
+
+	if 0 == len(selected) {
+		return nil, fmt.Errorf("image index does not have an image for %s", platformList(platforms))
+	}
+
+	return selected, nil
+}
+
+// resolveManifest returns the image in the passed image index for the first
+// of the passed Platforms that it has one for. If no Platforms are passed,
+// the image for DefaultPlatform is returned, or the first image in the index
+// if it doesn't have one.
+func resolveManifest(manifest distribution.Manifest, platforms []Platform) (PlatformManifest, error) {
+	manifests := IndexManifests(manifest)
+	if 0 == len(manifests) {
+		return PlatformManifest{}, ErrEmptyIndex
+	}
+
+	if 0 == len(platforms) {
+		for _, m := range manifests {
+			if DefaultPlatform.matches(m.Platform) {
+				return m, nil
+			}
+		}
+		return manifests[0], nil
+	}
+
+	for _, platform := range platforms {
+		for _, m := range manifests {
+			if platform.matches(m.Platform) {
+				return m, nil
+			}
+		}
+	}
+
+	return PlatformManifest{}, fmt.Errorf("image index does not have an image for %s", platformList(platforms))
+}
+
+// platformList returns the passed Platforms as a comma separated list.
+func platformList(platforms []Platform) string {
+	names := make([]string, 0, len(platforms))
+	for _, platform := range platforms {
+		names = append(names, platform.String())
+	}
+	return strings.Join(names, ", ")
+}
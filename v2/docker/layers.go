@@ -0,0 +1,74 @@
+package docker
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/reference"
+	digest "github.com/opencontainers/go-digest"
+
+	"github.com/grafeas/voucher/v2/docker/ocischema"
+	"github.com/grafeas/voucher/v2/docker/schema1"
+	"github.com/grafeas/voucher/v2/docker/schema2"
+)
+
+// EmptyLayer is the digest of the empty layer that schema 1 manifests list
+// for Dockerfile commands which do not change the filesystem, such as CMD or
+// EXPOSE.
+const EmptyLayer = digest.Digest("sha256:a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4")
+
+// ErrUnsupportedManifest is returned when the layers of a manifest which is
+// not an image manifest, such as an image index, are requested.
+var ErrUnsupportedManifest = errors.New("manifests that are not schema 1, schema 2, or OCI image manifests are unsupported")
+
+// RequestImageManifest requests the image manifest for the passed reference.
+// If the reference is to an image index, the manifest of the image for the
+// first of the passed Platforms that the index has is requested instead, in
+// the same way as RequestImageConfig. The returned reference is to the image
+// whose manifest was returned.
+func RequestImageManifest(client *http.Client, ref reference.Canonical, platforms ...Platform) (reference.Canonical, distribution.Manifest, error) {
+	manifest, err := RequestManifest(client, ref)
+	if nil != err {
+		return nil, nil, err
+	}
+
+	if !IsIndex(manifest) {
+		return ref, manifest, nil
+	}
+
+	platformManifest, err := resolveManifest(manifest, platforms)
+	if nil != err {
+		return nil, nil, NewManifestError(err)
+	}
+
+	return requestPlatformManifest(client, ref, platformManifest)
+}
+
+// LayerDigests returns the digests of the layers in the passed image
+// manifest, from the base layer up, skipping empty schema 1 layers.
+func LayerDigests(manifest distribution.Manifest) ([]digest.Digest, error) {
+	digests := []digest.Digest{}
+
+	switch {
+	case schema2.IsManifest(manifest):
+		for _, layer := range schema2.ToManifest(manifest).Layers {
+			digests = append(digests, layer.Digest)
+		}
+	case ocischema.IsManifest(manifest):
+		for _, layer := range ocischema.ToManifest(manifest).Layers {
+			digests = append(digests, layer.Digest)
+		}
+	case schema1.IsManifest(manifest):
+		descriptors := schema1.ToManifest(manifest).References()
+		for i := len(descriptors) - 1; i >= 0; i-- {
+			if descriptors[i].Digest != EmptyLayer {
+				digests = append(digests, descriptors[i].Digest)
+			}
+		}
+	default:
+		return nil, ErrUnsupportedManifest
+	}
+
+	return digests, nil
+}
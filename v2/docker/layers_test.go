@@ -0,0 +1,50 @@
+package docker
+
+import (
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vtesting "github.com/grafeas/voucher/v2/testing"
+)
+
+func TestRequestImageManifest(t *testing.T) {
+	ref := vtesting.NewTestIndexReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	image, manifest, err := RequestImageManifest(client, ref)
+	require.NoError(t, err)
+	assert.False(t, IsIndex(manifest))
+	assert.Equal(t, digest.Digest("sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9"), image.Digest(), "the image for the default platform should be returned")
+
+	image, _, err = RequestImageManifest(client, ref, Platform{OS: "linux", Architecture: "arm64", Variant: "v8"})
+	require.NoError(t, err)
+	assert.Equal(t, digest.Digest("sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2"), image.Digest())
+
+	_, _, err = RequestImageManifest(client, ref, Platform{OS: "windows", Architecture: "amd64"})
+	assert.Error(t, err)
+}
+
+func TestLayerDigests(t *testing.T) {
+	ref := vtesting.NewTestOCIReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	manifest, err := RequestManifest(client, ref)
+	require.NoError(t, err)
+
+	digests, err := LayerDigests(manifest)
+	require.NoError(t, err)
+	assert.Equal(t, []digest.Digest{"sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f"}, digests)
+
+	index, err := RequestManifest(client, vtesting.NewTestIndexReference(t))
+	require.NoError(t, err)
+
+	_, err = LayerDigests(index)
+	assert.Equal(t, ErrUnsupportedManifest, err)
+}
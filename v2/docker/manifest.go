@@ -4,15 +4,21 @@ import (
 	"net/http"
 
 	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/distribution/reference"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	// Registers the OCI image manifest with distribution.UnmarshalManifest.
+	_ "github.com/docker/distribution/manifest/ocischema"
 
 	"github.com/grafeas/voucher/v2/docker/uri"
 )
 
 // RequestManifest requests an Manifest for the passed canonical image reference (an image URL
-// with a digest specifying the built image). Returns a schema2.Manifest, or an error if
+// with a digest specifying the built image). Returns a schema2, schema1, or OCI
+// image manifest, an OCI image index or Docker manifest list, or an error if
 // there's an issue.
 func RequestManifest(client *http.Client, ref reference.Canonical) (distribution.Manifest, error) {
 	var manifest distribution.Manifest
@@ -22,11 +28,14 @@ func RequestManifest(client *http.Client, ref reference.Canonical) (distribution
 		return nil, err
 	}
 
+	request.Header.Add("Accept", v1.MediaTypeImageIndex)
+	request.Header.Add("Accept", manifestlist.MediaTypeManifestList)
+	request.Header.Add("Accept", v1.MediaTypeImageManifest)
 	request.Header.Add("Accept", schema2.MediaTypeManifest)
 	request.Header.Add("Accept", schema1.MediaTypeManifest)
 	request.Header.Add("Accept", schema1.MediaTypeSignedManifest)
 
-	manifest, err = getDockerManifest(client, request)
+	manifest, err = getDockerManifest(client, request, ref.Digest())
 	if nil != err {
 		return nil, err
 	}
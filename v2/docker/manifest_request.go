@@ -5,11 +5,16 @@ import (
 	"net/http"
 
 	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/schema1"
+	digest "github.com/opencontainers/go-digest"
+
+	"github.com/grafeas/voucher/v2/docker/verify"
 )
 
 // getDockerManifest executes an API call to Docker using the passed http.Client, and unmarshals
-// the resulting data into the passed interface, or returns an error if there's an issue.
-func getDockerManifest(client *http.Client, request *http.Request) (distribution.Manifest, error) {
+// the resulting data into the passed interface, or returns an error if there's an issue. The
+// manifest must hash to the expected digest, or a DigestMismatchError is returned.
+func getDockerManifest(client *http.Client, request *http.Request, expected digest.Digest) (distribution.Manifest, error) {
 	resp, err := client.Do(request)
 	if nil != err {
 		return nil, NewManifestError(err)
@@ -36,9 +41,23 @@ func getDockerManifest(client *http.Client, request *http.Request) (distribution
 		return nil, NewManifestError(err)
 	}
 
+	if err = verify.Digest(manifestType, expected, manifestContent(manifest, b)); nil != err {
+		return nil, NewManifestError(err)
+	}
+
 	return manifest, nil
 }
 
+// manifestContent returns the content of the passed manifest that its digest
+// is calculated from. The digest of a signed schema1 manifest does not cover
+// its signatures, so only its payload is used.
+func manifestContent(manifest distribution.Manifest, b []byte) []byte {
+	if signed, ok := manifest.(*schema1.SignedManifest); ok {
+		return signed.Canonical
+	}
+	return b
+}
+
 // isValidManifest ensures that we don't try to unmarshal an invalid manifest.
 func isValidManifest(contentType string) bool {
 	for _, mediaType := range distribution.ManifestMediaTypes() {
@@ -1,8 +1,10 @@
 package docker
 
 import (
+	"errors"
 	"testing"
 
+	digest "github.com/opencontainers/go-digest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -51,7 +53,56 @@ func TestRateLimitedBadManifest(t *testing.T) {
 	_, err := RequestManifest(client, ref)
 	assert.NotNilf(t, err, "should have failed to get manifest, but didn't")
 	assert.Equal(t,
-		NewManifestErrorWithRequest("200 OK", []byte(vtesting.RateLimitOutput+"\n")),
+		NewManifestErrorWithRequest("200 OK", []byte(vtesting.RateLimitOutput)),
 		err,
 	)
 }
+
+func TestRequestIndex(t *testing.T) {
+	ref := vtesting.NewTestIndexReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	manifest, err := RequestManifest(client, ref)
+	require.NoError(t, err)
+	require.True(t, IsIndex(manifest))
+
+	assert.Equal(t, []PlatformManifest{
+		{
+			Platform: Platform{OS: "linux", Architecture: "amd64"},
+			Digest:   "sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9",
+		},
+		{
+			Platform: Platform{OS: "linux", Architecture: "arm64", Variant: "v8"},
+			Digest:   "sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2",
+		},
+	}, IndexManifests(manifest))
+}
+
+func TestRequestTamperedManifest(t *testing.T) {
+	ref := vtesting.NewTamperedManifestTestReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	_, err := RequestManifest(client, ref)
+	require.Error(t, err, "should have failed to get a manifest with the wrong digest, but didn't")
+	assert.True(t, IsDigestMismatchError(err))
+
+	var mismatch *DigestMismatchError
+	require.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, "manifest", mismatch.Kind)
+	assert.Equal(t, ref.Digest(), mismatch.Expected)
+	assert.Equal(t, digest.Digest("sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9"), mismatch.Actual)
+}
+
+func TestRequestSignedSchema1Manifest(t *testing.T) {
+	ref := vtesting.NewTestSchema1SignedReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	_, err := RequestManifest(client, ref)
+	assert.NoError(t, err, "the digest of a signed manifest should not include its signatures")
+}
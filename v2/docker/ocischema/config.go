@@ -0,0 +1,70 @@
+package ocischema
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/reference"
+	dockerTypes "github.com/docker/docker/api/types"
+
+	"github.com/grafeas/voucher/v2/docker/uri"
+	"github.com/grafeas/voucher/v2/docker/verify"
+)
+
+// ociBlob is an OCI image configuration. Unlike Docker's image
+// configuration, the execution configuration is in "config".
+type ociBlob struct {
+	Config dockerTypes.ExecConfig `json:"config"`
+}
+
+// RequestConfig requests an image configuration from the server, based on the passed digest.
+// Returns an ImageConfig or an error.
+func RequestConfig(client *http.Client, ref reference.Canonical, manifest distribution.Manifest) (*dockerTypes.ExecConfig, error) {
+	if !IsManifest(manifest) {
+		return nil, errors.New("cannot request OCI config for non-OCI manifest")
+	}
+
+	ociManifest := ToManifest(manifest)
+
+	var wrapper ociBlob
+
+	request, err := http.NewRequest(
+		http.MethodGet,
+		uri.GetBlobURI(ref, ociManifest.Config.Digest),
+		nil,
+	)
+	if nil != err {
+		return nil, err
+	}
+
+	request.Header.Add("Accept", ociManifest.Config.MediaType)
+
+	resp, err := client.Do(request)
+	if nil != err {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, errors.New("failed to load OCI config with status \"" + resp.Status + "\"")
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if nil != err {
+		return nil, err
+	}
+
+	if err = verify.Digest("config", ociManifest.Config.Digest, b); nil != err {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(b, &wrapper); nil != err {
+		return nil, err
+	}
+
+	return &wrapper.Config, nil
+}
@@ -0,0 +1,23 @@
+package ocischema
+
+import (
+	"github.com/docker/distribution"
+	oci "github.com/docker/distribution/manifest/ocischema"
+)
+
+// IsManifest returns true if the passed manifest is an OCI image manifest.
+func IsManifest(m distribution.Manifest) bool {
+	_, ok := m.(*oci.DeserializedManifest)
+	return ok
+}
+
+// ToManifest casts a distribution.Manifest to an ocischema.Manifest. It panics
+// if it passed anything other than an ocischema.DeserializedManifest.
+func ToManifest(manifest distribution.Manifest) oci.Manifest {
+	ociManifest, ok := manifest.(*oci.DeserializedManifest)
+	if !ok {
+		panic("ocischema.ToManifest was passed a non-ocischema.DeserializedManifest")
+	}
+
+	return ociManifest.Manifest
+}
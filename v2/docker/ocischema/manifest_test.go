@@ -0,0 +1,17 @@
+package ocischema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	vtesting "github.com/grafeas/voucher/v2/testing"
+)
+
+func TestToManifest(t *testing.T) {
+	newManifest := vtesting.NewTestOCIManifest()
+	manifest := ToManifest(newManifest)
+	assert.NotNil(t, manifest)
+	assert.True(t, IsManifest(newManifest))
+	assert.False(t, IsManifest(vtesting.NewTestManifest()))
+}
@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/distribution/manifest/manifestlist"
+)
+
+// Platform is the operating system and CPU architecture that an image in an
+// image index runs on.
+type Platform struct {
+	OS           string
+	Architecture string
+	Variant      string
+}
+
+// DefaultPlatform is the Platform that is used for image indexes when no
+// Platform is requested.
+var DefaultPlatform = Platform{OS: "linux", Architecture: "amd64"}
+
+// ParsePlatform parses a Platform in the form "os/architecture", or
+// "os/architecture/variant", such as "linux/arm64/v8".
+func ParsePlatform(platform string) (Platform, error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 || "" == parts[0] || "" == parts[1] {
+		return Platform{}, fmt.Errorf("platform \"%s\" is not in the form os/architecture[/variant]", platform)
+	}
+
+	parsed := Platform{
+		OS:           parts[0],
+		Architecture: parts[1],
+	}
+	if 3 == len(parts) {
+		parsed.Variant = parts[2]
+	}
+
+	return parsed, nil
+}
+
+// ParsePlatforms parses each of the passed platforms with ParsePlatform.
+func ParsePlatforms(platforms []string) ([]Platform, error) {
+	parsed := make([]Platform, 0, len(platforms))
+	for _, platform := range platforms {
+		p, err := ParsePlatform(platform)
+		if nil != err {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
+
+// String returns the Platform in the form accepted by ParsePlatform.
+func (p Platform) String() string {
+	if "" == p.Variant {
+		return p.OS + "/" + p.Architecture
+	}
+	return p.OS + "/" + p.Architecture + "/" + p.Variant
+}
+
+// matches returns true if the passed Platform, from an image index, is this
+// Platform. If this Platform has no variant, it matches any variant.
+func (p Platform) matches(other Platform) bool {
+	return p.OS == other.OS &&
+		p.Architecture == other.Architecture &&
+		("" == p.Variant || p.Variant == other.Variant)
+}
+
+// toPlatform converts a platform from an image index into a Platform.
+func toPlatform(spec manifestlist.PlatformSpec) Platform {
+	return Platform{
+		OS:           spec.OS,
+		Architecture: spec.Architecture,
+		Variant:      spec.Variant,
+	}
+}
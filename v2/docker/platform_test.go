@@ -0,0 +1,35 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlatform(t *testing.T) {
+	platform, err := ParsePlatform("linux/amd64")
+	require.NoError(t, err)
+	assert.Equal(t, Platform{OS: "linux", Architecture: "amd64"}, platform)
+	assert.Equal(t, "linux/amd64", platform.String())
+
+	platform, err = ParsePlatform("linux/arm64/v8")
+	require.NoError(t, err)
+	assert.Equal(t, Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}, platform)
+	assert.Equal(t, "linux/arm64/v8", platform.String())
+
+	for _, bad := range []string{"", "linux", "linux/", "/amd64", "linux/arm64/v8/extra"} {
+		_, err = ParsePlatform(bad)
+		assert.Errorf(t, err, "%q should not be a valid platform", bad)
+	}
+}
+
+func TestPlatformMatches(t *testing.T) {
+	arm64 := Platform{OS: "linux", Architecture: "arm64"}
+
+	assert.True(t, arm64.matches(Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}))
+	assert.False(t, arm64.matches(Platform{OS: "linux", Architecture: "amd64"}))
+
+	v7 := Platform{OS: "linux", Architecture: "arm", Variant: "v7"}
+	assert.False(t, v7.matches(Platform{OS: "linux", Architecture: "arm", Variant: "v6"}))
+}
@@ -3,6 +3,7 @@ package schema2
 import (
 	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/docker/distribution"
@@ -10,6 +11,7 @@ import (
 	dockerTypes "github.com/docker/docker/api/types"
 
 	"github.com/grafeas/voucher/v2/docker/uri"
+	"github.com/grafeas/voucher/v2/docker/verify"
 )
 
 type v2Blob struct {
@@ -45,12 +47,22 @@ func RequestConfig(client *http.Client, ref reference.Canonical, manifest distri
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode < 300 {
-		err = json.NewDecoder(resp.Body).Decode(&wrapper)
-		if nil == err {
-			return &wrapper.Config, nil
-		}
+	if resp.StatusCode >= 300 {
+		return nil, errors.New("failed to load schema2 config with status \"" + resp.Status + "\"")
 	}
 
-	return nil, err
+	b, err := ioutil.ReadAll(resp.Body)
+	if nil != err {
+		return nil, err
+	}
+
+	if err = verify.Digest("config", v2Manifest.Config.Digest, b); nil != err {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(b, &wrapper); nil != err {
+		return nil, err
+	}
+
+	return &wrapper.Config, nil
 }
@@ -0,0 +1,212 @@
+package docker
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/reference"
+	digest "github.com/opencontainers/go-digest"
+
+	"github.com/grafeas/voucher/v2/docker/ocischema"
+	"github.com/grafeas/voucher/v2/docker/schema2"
+	"github.com/grafeas/voucher/v2/docker/uri"
+	"github.com/grafeas/voucher/v2/docker/verify"
+)
+
+// RepositoryImage is an image in a repository, as listed by ListImages.
+type RepositoryImage struct {
+	Digest   digest.Digest
+	Tags     []string
+	Uploaded time.Time // When the image was uploaded, or created if the registry does not report upload times.
+}
+
+// tagListManifest is an image in the "manifest" extension to the tag list
+// returned by Google Container Registry and Artifact Registry.
+type tagListManifest struct {
+	Tag            []string `json:"tag"`
+	TimeUploadedMs string   `json:"timeUploadedMs"`
+}
+
+// tagList is the response to a tag list request.
+type tagList struct {
+	Tags     []string                   `json:"tags"`
+	Manifest map[string]tagListManifest `json:"manifest"`
+}
+
+// createdConfig is the part of an image configuration which records when the
+// image was created.
+type createdConfig struct {
+	Created time.Time `json:"created"`
+}
+
+// ListImages lists the images in the passed repository, with the most
+// recently uploaded images first. Upload times are reported by registries
+// such as Google Container Registry and Artifact Registry, which list every
+// image in a single request.
+//
+// For other registries, each tag has to be resolved separately, so only the
+// last maxTags tags in the tag list are resolved, or all of them if maxTags
+// is 0. Registries list tags in lexical order, so these are not necessarily
+// the most recent images. Those images are ordered by the creation time in
+// their configurations instead, and tags that cannot be resolved are skipped.
+func ListImages(client *http.Client, repository reference.Named, maxTags int) ([]RepositoryImage, error) {
+	resp, err := client.Get(uri.GetTagListURI(repository))
+	if nil != err {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, responseToError(resp)
+	}
+
+	var list tagList
+	if err = json.NewDecoder(resp.Body).Decode(&list); nil != err {
+		return nil, err
+	}
+
+	if 0 != len(list.Manifest) {
+		return listedImages(list.Manifest), nil
+	}
+
+	tags := list.Tags
+	if 0 < maxTags && maxTags < len(tags) {
+		tags = tags[len(tags)-maxTags:]
+	}
+
+	return resolveImages(client, repository, tags), nil
+}
+
+// resolveImages resolves the passed tags to images, skipping those that
+// cannot be resolved, and returns them sorted with the most recently created
+// first.
+func resolveImages(client *http.Client, repository reference.Named, tags []string) []RepositoryImage {
+	images := make([]RepositoryImage, 0, len(tags))
+	byDigest := make(map[digest.Digest]int, len(tags))
+
+	for _, tag := range tags {
+		tagged, err := reference.WithTag(repository, tag)
+		if nil != err {
+			continue
+		}
+
+		imageDigest, err := GetDigestFromTagged(client, tagged)
+		if nil != err {
+			continue
+		}
+
+		if i, ok := byDigest[imageDigest]; ok {
+			images[i].Tags = append(images[i].Tags, tag)
+			continue
+		}
+
+		image := RepositoryImage{Digest: imageDigest, Tags: []string{tag}}
+		if canonical, err := reference.WithDigest(repository, imageDigest); nil == err {
+			image.Uploaded, _ = requestCreated(client, canonical)
+		}
+
+		byDigest[imageDigest] = len(images)
+		images = append(images, image)
+	}
+
+	sortImages(images)
+	return images
+}
+
+// requestCreated returns the creation time in the configuration of the passed
+// image, or of the image for DefaultPlatform if it is an image index. Images
+// with schema1 manifests have no configuration, so a zero time is returned
+// for them.
+func requestCreated(client *http.Client, ref reference.Canonical) (time.Time, error) {
+	manifest, err := RequestManifest(client, ref)
+	if nil != err {
+		return time.Time{}, err
+	}
+
+	if IsIndex(manifest) {
+		platformManifest, err := resolveManifest(manifest, nil)
+		if nil != err {
+			return time.Time{}, err
+		}
+
+		ref, manifest, err = requestPlatformManifest(client, ref, platformManifest)
+		if nil != err {
+			return time.Time{}, err
+		}
+	}
+
+	var descriptor distribution.Descriptor
+	switch {
+	case schema2.IsManifest(manifest):
+		descriptor = schema2.ToManifest(manifest).Config
+	case ocischema.IsManifest(manifest):
+		descriptor = ocischema.ToManifest(manifest).Config
+	default:
+		return time.Time{}, nil
+	}
+
+	resp, err := client.Get(uri.GetBlobURI(ref, descriptor.Digest))
+	if nil != err {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return time.Time{}, responseToError(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if nil != err {
+		return time.Time{}, err
+	}
+
+	if err = verify.Digest("config", descriptor.Digest, b); nil != err {
+		return time.Time{}, err
+	}
+
+	var config createdConfig
+	if err = json.Unmarshal(b, &config); nil != err {
+		return time.Time{}, err
+	}
+
+	return config.Created.UTC(), nil
+}
+
+// listedImages converts the manifests listed by Google Container Registry or
+// Artifact Registry into RepositoryImages, sorted with the most recently
+// uploaded first.
+func listedImages(manifests map[string]tagListManifest) []RepositoryImage {
+	images := make([]RepositoryImage, 0, len(manifests))
+
+	for imageDigest, manifest := range manifests {
+		image := RepositoryImage{
+			Digest: digest.Digest(imageDigest),
+			Tags:   manifest.Tag,
+		}
+
+		if uploaded, err := strconv.ParseInt(manifest.TimeUploadedMs, 10, 64); nil == err {
+			image.Uploaded = time.Unix(0, uploaded*int64(time.Millisecond)).UTC()
+		}
+
+		images = append(images, image)
+	}
+
+	sortImages(images)
+	return images
+}
+
+// sortImages sorts the passed images with the most recently uploaded first.
+// Images without upload times are last.
+func sortImages(images []RepositoryImage) {
+	sort.SliceStable(images, func(i, j int) bool {
+		if !images[i].Uploaded.Equal(images[j].Uploaded) {
+			return images[i].Uploaded.After(images[j].Uploaded)
+		}
+		return images[i].Digest < images[j].Digest
+	})
+}
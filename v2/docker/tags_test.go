@@ -0,0 +1,170 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/distribution/reference"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vtesting "github.com/grafeas/voucher/v2/testing"
+)
+
+func TestListImages(t *testing.T) {
+	ref := vtesting.NewTestReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	images, err := ListImages(client, ref, 0)
+	require.NoErrorf(t, err, "failed to list images: %s", err)
+
+	assert.Equal(t, []RepositoryImage{
+		{
+			Digest:   digest.Digest("sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9"),
+			Tags:     []string{"latest"},
+			Uploaded: time.Unix(1600000200, 0).UTC(),
+		},
+		{
+			Digest:   digest.Digest("sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2"),
+			Tags:     []string{"previous"},
+			Uploaded: time.Unix(1600000100, 0).UTC(),
+		},
+	}, images)
+}
+
+func TestListImagesWithoutUploadTimes(t *testing.T) {
+	ref := vtesting.NewTestSchema1Reference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	images, err := ListImages(client, ref, 0)
+	require.NoErrorf(t, err, "failed to list images: %s", err)
+
+	assert.Equal(t, []RepositoryImage{
+		{
+			Digest: digest.Digest("sha256:03f65aeeb2e8e8db022b297cae4cdce9248633f551452e63ba520d1f9ef2eca0"),
+			Tags:   []string{"latest"},
+		},
+	}, images)
+}
+
+func TestListImagesWithBadRepository(t *testing.T) {
+	ref := vtesting.NewBadTestReference(t)
+
+	client, server := vtesting.PrepareDockerTest(t, ref)
+	defer server.Close()
+
+	_, err := ListImages(client, ref, 0)
+	assert.Error(t, err)
+}
+
+// tagsRegistryMock mocks a registry which does not report when images were
+// uploaded. Each of its tags is an image created at the time in created,
+// except for tags which are missing from it.
+type tagsRegistryMock struct {
+	tags     []string
+	created  map[string]time.Time
+	requests []string
+}
+
+func (mock *tagsRegistryMock) image(t *testing.T, tag string) (digest.Digest, []byte, digest.Digest, []byte) {
+	config := []byte(fmt.Sprintf(`{"created":%q}`, mock.created[tag].Format(time.RFC3339)))
+	configDigest := digest.FromBytes(config)
+
+	manifest, err := schema2.FromStruct(schema2.Manifest{
+		Versioned: schema2.SchemaVersion,
+		Config: distribution.Descriptor{
+			MediaType: schema2.MediaTypeImageConfig,
+			Size:      int64(len(config)),
+			Digest:    configDigest,
+		},
+	})
+	require.NoError(t, err)
+
+	_, payload, err := manifest.Payload()
+	require.NoError(t, err)
+
+	return digest.FromBytes(payload), payload, configDigest, config
+}
+
+func (mock *tagsRegistryMock) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		mock.requests = append(mock.requests, req.URL.Path)
+
+		if "/v2/repo/tags/list" == req.URL.Path {
+			jsonRespond(t, writer, map[string]interface{}{"name": "repo", "tags": mock.tags})
+			return
+		}
+
+		for tag := range mock.created {
+			imageDigest, manifest, configDigest, config := mock.image(t, tag)
+			switch req.URL.Path {
+			case "/v2/repo/manifests/" + tag, "/v2/repo/manifests/" + imageDigest.String():
+				writer.Header().Set("Docker-Content-Digest", imageDigest.String())
+				writer.Header().Set("Content-Type", schema2.MediaTypeManifest)
+				_, _ = writer.Write(manifest)
+				return
+			case "/v2/repo/blobs/" + configDigest.String():
+				_, _ = writer.Write(config)
+				return
+			}
+		}
+
+		http.NotFound(writer, req)
+	})
+}
+
+func jsonRespond(t *testing.T, writer http.ResponseWriter, v interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(writer).Encode(v))
+}
+
+func TestListImagesWithCreationTimes(t *testing.T) {
+	first := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock := &tagsRegistryMock{
+		tags: []string{"1.0", "1.1", "1.2", "broken", "latest"},
+		created: map[string]time.Time{
+			"1.0":    first,
+			"1.1":    first.Add(2 * time.Hour),
+			"1.2":    first.Add(time.Hour),
+			"latest": first.Add(2 * time.Hour),
+		},
+	}
+
+	server := httptest.NewTLSServer(mock.handler(t))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	ref, err := reference.ParseNamed(serverURL.Host + "/repo")
+	require.NoError(t, err)
+
+	images, err := ListImages(server.Client(), ref, 4)
+	require.NoError(t, err, "a tag which cannot be resolved should be skipped")
+
+	latestDigest, _, _, _ := mock.image(t, "latest")
+	olderDigest, _, _, _ := mock.image(t, "1.2")
+
+	assert.Equal(t, []RepositoryImage{
+		{Digest: latestDigest, Tags: []string{"1.1", "latest"}, Uploaded: first.Add(2 * time.Hour)},
+		{Digest: olderDigest, Tags: []string{"1.2"}, Uploaded: first.Add(time.Hour)},
+	}, images)
+
+	for _, path := range mock.requests {
+		assert.False(t, strings.HasSuffix(path, "/manifests/1.0"), "only the last tags should be resolved")
+	}
+}
@@ -7,6 +7,14 @@ import (
 	"github.com/docker/distribution/reference"
 )
 
+// containerRegistryDomain is the global Google Container Registry host. The
+// regional hosts, such as "eu.gcr.io", are subdomains of it.
+const containerRegistryDomain = "gcr.io"
+
+// artifactRegistrySuffix is the suffix of the Artifact Registry hosts for
+// Docker repositories, such as "us-docker.pkg.dev".
+const artifactRegistrySuffix = "-docker.pkg.dev"
+
 type ErrNoProjectInReference struct {
 	ref reference.Reference
 }
@@ -15,15 +23,40 @@ func (err *ErrNoProjectInReference) Error() string {
 	return fmt.Sprintf("could not find project path in reference \"%s\"", err.ref)
 }
 
+// IsContainerRegistryDomain returns true if the passed domain is a Google
+// Container Registry host, either "gcr.io" or a regional host such as
+// "eu.gcr.io".
+func IsContainerRegistryDomain(domain string) bool {
+	return containerRegistryDomain == domain || strings.HasSuffix(domain, "."+containerRegistryDomain)
+}
+
+// IsArtifactRegistryDomain returns true if the passed domain is a Google
+// Artifact Registry host for Docker repositories, such as
+// "us-docker.pkg.dev" or "europe-west1-docker.pkg.dev".
+func IsArtifactRegistryDomain(domain string) bool {
+	return strings.HasSuffix(domain, artifactRegistrySuffix) && len(domain) > len(artifactRegistrySuffix)
+}
+
+// IsGoogleDomain returns true if the passed domain is a Google Container
+// Registry or Artifact Registry host.
+func IsGoogleDomain(domain string) bool {
+	return IsContainerRegistryDomain(domain) || IsArtifactRegistryDomain(domain)
+}
+
 // ReferenceToProjectName returns what should be the GCR project name for an
 // image reference.
 //
 // For example, if an image is in the project "my-cool-project" the image path
-// should start with `gcr.io/my-cool-project`.
+// should start with `gcr.io/my-cool-project` (or a regional host such as
+// `eu.gcr.io/my-cool-project`), or with
+// `us-docker.pkg.dev/my-cool-project/<repository>` in Artifact Registry.
 func ReferenceToProjectName(ref reference.Reference) (string, error) {
 	values := strings.Split(ref.String(), "/")
 	if 2 < len(values) {
-		if values[0] == "gcr.io" {
+		if IsContainerRegistryDomain(values[0]) {
+			return values[1], nil
+		}
+		if 3 < len(values) && IsArtifactRegistryDomain(values[0]) {
 			return values[1], nil
 		}
 	}
@@ -28,3 +28,46 @@ func TestReferenceToProjectNameWithOtherReference(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "", project)
 }
+
+func TestReferenceToProjectNameWithGoogleRegistries(t *testing.T) {
+	cases := map[string]string{
+		"eu.gcr.io/my-project/image@sha256:297524b7375fbf09b3784f0bbd9cb2505700dd05e03ce5f5e6d262bf2f5ac51c":                 "my-project",
+		"asia.gcr.io/my-project/path/to/image:latest":                                                                        "my-project",
+		"us-docker.pkg.dev/my-project/my-repo/image@sha256:297524b7375fbf09b3784f0bbd9cb2505700dd05e03ce5f5e6d262bf2f5ac51c": "my-project",
+		"europe-west1-docker.pkg.dev/my-project/my-repo/path/to/image:latest":                                                "my-project",
+	}
+
+	for image, expected := range cases {
+		ref, err := reference.Parse(image)
+		require.NoError(t, err)
+
+		project, err := ReferenceToProjectName(ref)
+		assert.NoError(t, err, image)
+		assert.Equal(t, expected, project, image)
+	}
+}
+
+func TestReferenceToProjectNameWithOtherRegistries(t *testing.T) {
+	for _, image := range []string{
+		"us-docker.pkg.dev/my-project/image:latest",
+		"docker.pkg.dev/my-project/my-repo/image:latest",
+		"notgcr.io/my-project/image:latest",
+		"ghcr.io/my-org/my-project/image:latest",
+	} {
+		ref, err := reference.Parse(image)
+		require.NoError(t, err)
+
+		_, err = ReferenceToProjectName(ref)
+		assert.Error(t, err, image)
+	}
+}
+
+func TestIsGoogleDomain(t *testing.T) {
+	for _, domain := range []string{"gcr.io", "us.gcr.io", "eu.gcr.io", "asia.gcr.io", "us-docker.pkg.dev", "europe-west1-docker.pkg.dev"} {
+		assert.True(t, IsGoogleDomain(domain), domain)
+	}
+
+	for _, domain := range []string{"docker.io", "ghcr.io", "notgcr.io", "-docker.pkg.dev", "us-npm.pkg.dev"} {
+		assert.False(t, IsGoogleDomain(domain), domain)
+	}
+}
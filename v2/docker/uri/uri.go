@@ -51,8 +51,31 @@ func GetDigestManifestURI(ref reference.Canonical) string {
 	return u.String()
 }
 
+// GetTagListURI gets the URI which lists the tags in the passed repository.
+func GetTagListURI(ref reference.Named) string {
+	u := createURL(ref, reference.Path(ref), "tags", "list")
+	return u.String()
+}
+
+// dockerHubDomain is the domain of images in Docker Hub, such as
+// "docker.io/library/alpine".
+const dockerHubDomain = "docker.io"
+
+// dockerHubHost is the host that serves the Docker Hub registry API.
+const dockerHubHost = "registry-1.docker.io"
+
+// RegistryHost returns the host which serves the registry API for images
+// with the passed domain. This is the domain itself, except for Docker Hub,
+// whose images are named "docker.io" but are served by another host.
+func RegistryHost(domain string) string {
+	if dockerHubDomain == domain {
+		return dockerHubHost
+	}
+	return domain
+}
+
 func createURL(ref reference.Named, pathSegments ...string) url.URL {
-	hostname := reference.Domain(ref)
+	hostname := RegistryHost(reference.Domain(ref))
 
 	var path bytes.Buffer
 	path.WriteString("/v2")
@@ -33,3 +33,14 @@ func TestGetBaseURI(t *testing.T) {
 	assert.Equal(t, testBlobURL, GetBlobURI(canonicalRef, canonicalRef.Digest()))
 	assert.Equal(t, testManifestURL, GetManifestURI(canonicalRef))
 }
+
+func TestDockerHubURI(t *testing.T) {
+	named, err := reference.ParseNormalizedNamed("alpine:latest")
+	require.NoError(t, err)
+
+	tagged, ok := named.(reference.NamedTagged)
+	require.True(t, ok)
+
+	assert.Equal(t, "https://registry-1.docker.io/v2/library/alpine/manifests/latest", GetTagManifestURI(tagged))
+	assert.Equal(t, "ghcr.io", RegistryHost("ghcr.io"))
+}
@@ -0,0 +1,49 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// DigestMismatchError is returned when content received from a registry does
+// not hash to the digest it was requested by, which means the registry
+// returned something other than what was asked for.
+type DigestMismatchError struct {
+	Kind     string
+	Expected digest.Digest
+	Actual   digest.Digest
+}
+
+// Error returns the error message for the DigestMismatchError.
+func (err *DigestMismatchError) Error() string {
+	return fmt.Sprintf("%s digest mismatch: expected %s, got %s", err.Kind, err.Expected, err.Actual)
+}
+
+// IsDigestMismatchError returns true if the passed error is a
+// DigestMismatchError, or wraps one.
+func IsDigestMismatchError(err error) bool {
+	var mismatch *DigestMismatchError
+	return errors.As(err, &mismatch)
+}
+
+// Digest verifies that the passed content hashes to the expected digest,
+// using the expected digest's algorithm. It returns a DigestMismatchError
+// describing the kind of content if it does not.
+func Digest(kind string, expected digest.Digest, content []byte) error {
+	if err := expected.Validate(); nil != err {
+		return fmt.Errorf("cannot verify %s: %s", kind, err)
+	}
+
+	actual := expected.Algorithm().FromBytes(content)
+	if actual != expected {
+		return &DigestMismatchError{
+			Kind:     kind,
+			Expected: expected,
+			Actual:   actual,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package verify
+
+import (
+	"fmt"
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigest(t *testing.T) {
+	content := []byte("{}")
+	expected := digest.FromBytes(content)
+
+	assert.NoError(t, Digest("manifest", expected, content))
+	assert.NoError(t, Digest("manifest", digest.SHA512.FromBytes(content), content))
+
+	err := Digest("manifest", expected, []byte("{ }"))
+	assert.Equal(t, &DigestMismatchError{
+		Kind:     "manifest",
+		Expected: expected,
+		Actual:   digest.FromBytes([]byte("{ }")),
+	}, err)
+	assert.EqualError(t, err, "manifest digest mismatch: expected "+expected.String()+", got "+digest.FromBytes([]byte("{ }")).String())
+	assert.True(t, IsDigestMismatchError(fmt.Errorf("wrapped: %w", err)))
+
+	err = Digest("manifest", "sha256:abc", content)
+	assert.Error(t, err)
+	assert.False(t, IsDigestMismatchError(err))
+}
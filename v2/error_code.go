@@ -0,0 +1,99 @@
+package voucher
+
+import "errors"
+
+// ErrorCode is a stable, machine-readable identifier for the reason a Check
+// failed. Unlike error messages, ErrorCodes will not change between releases.
+type ErrorCode string
+
+// ErrorCodes returned by the built-in Checks and by Suites.
+const (
+	ErrorCodeNoCheck            ErrorCode = "NO_CHECK"
+	ErrorCodeNoBuildData        ErrorCode = "NO_BUILD_DATA"
+	ErrorCodeNoRepositoryClient ErrorCode = "NO_REPOSITORY_CLIENT"
+	ErrorCodeNoAuth             ErrorCode = "NO_AUTH"
+	ErrorCodeNoScanner          ErrorCode = "NO_SCANNER"
+	ErrorCodeNotSigned          ErrorCode = "NOT_SIGNED"
+	ErrorCodeNotOnDefaultBranch ErrorCode = "NOT_ON_DEFAULT_BRANCH"
+	ErrorCodeNotMergeCommit     ErrorCode = "NOT_MERGE_COMMIT"
+	ErrorCodeMissingApprovals   ErrorCode = "MISSING_APPROVALS"
+	ErrorCodeNotPassedCI        ErrorCode = "NOT_PASSED_CI"
+	ErrorCodeRepoNotAllowed     ErrorCode = "REPO_NOT_ALLOWED"
+	ErrorCodeUntrustedBuilder   ErrorCode = "UNTRUSTED_BUILDER"
+	ErrorCodeUntrustedProject   ErrorCode = "UNTRUSTED_PROJECT"
+	ErrorCodeRunsAsRoot         ErrorCode = "RUNS_AS_ROOT"
+	ErrorCodeVulnerable         ErrorCode = "VULNERABLE"
+	ErrorCodeExploited          ErrorCode = "EXPLOITED"
+	ErrorCodeNoExploitData      ErrorCode = "NO_EXPLOIT_DATA"
+	ErrorCodePolicyDenied       ErrorCode = "POLICY_DENIED"
+	ErrorCodeTimedOut           ErrorCode = "TIMED_OUT"
+	ErrorCodeCancelled          ErrorCode = "CANCELLED"
+	ErrorCodeDependencyFailed   ErrorCode = "DEPENDENCY_FAILED"
+	ErrorCodeDependencyCycle    ErrorCode = "DEPENDENCY_CYCLE"
+	ErrorCodeUnknown            ErrorCode = "UNKNOWN"
+)
+
+// CodedError is an error which describes itself with an ErrorCode, and
+// optionally with structured details.
+type CodedError interface {
+	error
+	ErrorCode() ErrorCode
+	ErrorDetails() interface{}
+}
+
+// CheckError is a CodedError with a fixed message. It is used for the
+// sentinel errors returned by Checks.
+type CheckError struct {
+	Code    ErrorCode
+	Message string
+	Details interface{}
+}
+
+// Error returns the message of the CheckError.
+func (err *CheckError) Error() string {
+	return err.Message
+}
+
+// ErrorCode returns the ErrorCode of the CheckError.
+func (err *CheckError) ErrorCode() ErrorCode {
+	return err.Code
+}
+
+// ErrorDetails returns the details of the CheckError, or nil if it has none.
+func (err *CheckError) ErrorDetails() interface{} {
+	return err.Details
+}
+
+// NewCheckError creates a new CheckError with the passed ErrorCode and
+// message, and no details.
+func NewCheckError(code ErrorCode, message string) error {
+	return &CheckError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// ErrorCodeOf returns the ErrorCode of the passed error, or of the first
+// CodedError it wraps. It returns ErrorCodeUnknown for other errors, and an
+// empty ErrorCode if the error is nil.
+func ErrorCodeOf(err error) ErrorCode {
+	if nil == err {
+		return ""
+	}
+
+	var coded CodedError
+	if errors.As(err, &coded) {
+		return coded.ErrorCode()
+	}
+	return ErrorCodeUnknown
+}
+
+// ErrorDetailsOf returns the details of the passed error, or of the first
+// CodedError it wraps. It returns nil if there are no details.
+func ErrorDetailsOf(err error) interface{} {
+	var coded CodedError
+	if errors.As(err, &coded) {
+		return coded.ErrorDetails()
+	}
+	return nil
+}
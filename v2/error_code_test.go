@@ -0,0 +1,51 @@
+package voucher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	errNotSigned := NewCheckError(ErrorCodeNotSigned, "commit was not signed by a valid key")
+
+	assert.Equal(t, ErrorCode(""), ErrorCodeOf(nil))
+	assert.Equal(t, ErrorCodeUnknown, ErrorCodeOf(errors.New("something broke")))
+	assert.Equal(t, ErrorCodeNotSigned, ErrorCodeOf(errNotSigned))
+	assert.Equal(t, ErrorCodeNotSigned, ErrorCodeOf(fmt.Errorf("approved: %w", errNotSigned)))
+	assert.Equal(t, ErrorCodeNotSigned, ErrorCodeOf(NewTransientError(errNotSigned)))
+	assert.Equal(t, ErrorCodeVulnerable, ErrorCodeOf(NewVulnerabilityError(makeTestVulns())))
+
+	assert.Equal(t, "commit was not signed by a valid key", errNotSigned.Error())
+	assert.Nil(t, ErrorDetailsOf(errNotSigned))
+	assert.Nil(t, ErrorDetailsOf(errors.New("something broke")))
+}
+
+func TestVulnerabilitiesErrorDetails(t *testing.T) {
+	imageData := newTestImageData(t)
+	vulns := makeTestVulns()
+
+	result := CheckResult{Name: "snakeoil", ImageData: imageData}
+	result.setError(NewVulnerabilityError(vulns))
+
+	assert.Equal(t, ErrorCodeVulnerable, result.ErrCode)
+	assert.Equal(t, VulnerabilitiesDetails{Vulnerabilities: vulns}, result.ErrDetails)
+
+	encoded, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var decoded struct {
+		Code    string `json:"error_code"`
+		Details struct {
+			Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+		} `json:"error_details"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+
+	assert.Equal(t, "VULNERABLE", decoded.Code)
+	assert.Equal(t, vulns, decoded.Details.Vulnerabilities)
+}
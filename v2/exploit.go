@@ -0,0 +1,91 @@
+package voucher
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KnownExploitedVulnerability is an entry in a Known Exploited
+// Vulnerabilities catalog, such as the one published by CISA.
+type KnownExploitedVulnerability struct {
+	CVEID             string `json:"cve_id"`
+	VendorProject     string `json:"vendor_project,omitempty"`
+	Product           string `json:"product,omitempty"`
+	VulnerabilityName string `json:"vulnerability_name,omitempty"`
+	DateAdded         string `json:"date_added,omitempty"`
+	RequiredAction    string `json:"required_action,omitempty"`
+	DueDate           string `json:"due_date,omitempty"`
+	RansomwareUse     string `json:"known_ransomware_campaign_use,omitempty"`
+}
+
+// ExploitDatabase describes which vulnerabilities are known to be exploited,
+// and how likely others are to be exploited.
+type ExploitDatabase interface {
+	// KnownExploited returns the catalog entry for the vulnerability with the
+	// passed CVE ID, if it is known to be exploited.
+	KnownExploited(cve string) (KnownExploitedVulnerability, bool)
+
+	// ExploitProbability returns the EPSS probability that the vulnerability
+	// with the passed CVE ID will be exploited, if it has one.
+	ExploitProbability(cve string) (float32, bool)
+}
+
+// ExploitSource loads the current ExploitDatabase.
+type ExploitSource interface {
+	Load() (ExploitDatabase, error)
+}
+
+// ExploitedVulnerability is a Vulnerability which is known to be exploited,
+// or has an EPSS probability above the threshold.
+type ExploitedVulnerability struct {
+	Vulnerability
+	KnownExploited *KnownExploitedVulnerability `json:"known_exploited,omitempty"`
+	EPSS           float32                      `json:"epss,omitempty"`
+}
+
+// describe returns the name of the ExploitedVulnerability, and why it was
+// reported.
+func (vuln ExploitedVulnerability) describe() string {
+	reasons := []string{}
+	if nil != vuln.KnownExploited {
+		reasons = append(reasons, "known exploited")
+	}
+	if 0 < vuln.EPSS {
+		reasons = append(reasons, fmt.Sprintf("EPSS %.3f", vuln.EPSS))
+	}
+	return fmt.Sprintf("%s (%s)", vuln.Name, strings.Join(reasons, ", "))
+}
+
+// ExploitedVulnerabilitiesError is the error returned when an image has
+// vulnerabilities that are known to be exploited, or are likely to be.
+type ExploitedVulnerabilitiesError struct {
+	Vulnerabilities []ExploitedVulnerability
+}
+
+// Error returns the error message for the ExploitedVulnerabilitiesError.
+func (err ExploitedVulnerabilitiesError) Error() string {
+	names := make([]string, 0, len(err.Vulnerabilities))
+	for _, vuln := range err.Vulnerabilities {
+		names = append(names, vuln.describe())
+	}
+	return fmt.Sprintf("%d vulnerabilities are exploited or likely to be exploited: %s", len(err.Vulnerabilities), strings.Join(names, ", "))
+}
+
+// ErrorCode returns ErrorCodeExploited.
+func (err ExploitedVulnerabilitiesError) ErrorCode() ErrorCode {
+	return ErrorCodeExploited
+}
+
+// ErrorDetails returns the exploited Vulnerabilities, so they can be reported
+// as a structured list.
+func (err ExploitedVulnerabilitiesError) ErrorDetails() interface{} {
+	return ExploitedVulnerabilitiesDetails{
+		Vulnerabilities: err.Vulnerabilities,
+	}
+}
+
+// ExploitedVulnerabilitiesDetails are the details of an
+// ExploitedVulnerabilitiesError.
+type ExploitedVulnerabilitiesDetails struct {
+	Vulnerabilities []ExploitedVulnerability `json:"vulnerabilities"`
+}
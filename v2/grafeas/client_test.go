@@ -166,8 +166,10 @@ func TestGetAttestations(t *testing.T) {
 				Attestation: voucher.Attestation{
 					CheckName: "notename",
 					Body:      string(objects.AttestationUnspecified),
-				}},
-			},
+				},
+				Signature: "signature",
+				KeyID:     "key",
+			}},
 		},
 		"no data": {
 			returnOccs: objects.ListOccurrencesResponse{
@@ -195,7 +197,7 @@ func TestGetVulnerabilities(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	occs := createAllOccurrences()
-	errRef := getCanonicalRef(t, "us.gcr.io/grafeas/grafeas-server@sha256:c7303bdd6e36868d54b5b00dee125445a8d0f667c366420ccbe41dcf3b1c7733")
+	errRef := getCanonicalRef(t, "ghcr.io/grafeas/grafeas-server@sha256:c7303bdd6e36868d54b5b00dee125445a8d0f667c366420ccbe41dcf3b1c7733")
 	validRef := getCanonicalRef(t, imgPath)
 	successStatus := objects.DiscoveredAnalysisStatusFinishedSuccess
 	noteKindD := objects.NoteKindDiscovery
@@ -213,8 +215,11 @@ func TestGetVulnerabilities(t *testing.T) {
 			},
 			ref: validRef,
 			expectedResult: []voucher.Vulnerability{{
-				Name:     "notename",
-				Severity: voucher.NegligibleSeverity,
+				Name:           "notename",
+				Severity:       voucher.NegligibleSeverity,
+				FixedBy:        "1:v0.0.1-r",
+				PackageName:    "package_test",
+				PackageVersion: "v0.0.0-r",
 			}},
 		},
 		"no vulnerability data": {
@@ -263,13 +268,71 @@ func TestGetVulnerabilities(t *testing.T) {
 	defaultPollOptions()
 }
 
+func TestGetVulnerabilitiesInGoogleRegistries(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setPollOptions(1, 0)
+	defer defaultPollOptions()
+
+	for _, img := range []string{
+		"eu.gcr.io/grafeas/grafeas-server@sha256:c7303bdd6e36868d54b5b00dee125445a8d0f667c366420ccbe41dcf3b1c7733",
+		"us-docker.pkg.dev/grafeas/images/grafeas-server@sha256:c7303bdd6e36868d54b5b00dee125445a8d0f667c366420ccbe41dcf3b1c7733",
+	} {
+		grafeasMock := mocks.NewMockGrafeasAPIService(ctrl)
+		client, _ := NewClient(ctx, "project", "project", pgp.NewKeyRing(), grafeasMock)
+		grafeasMock.EXPECT().ListOccurrences(gomock.Any(), "projects/grafeas", gomock.Any()).Return(objects.ListOccurrencesResponse{
+			Occurrences: createAllOccurrences(),
+		}, nil).AnyTimes()
+
+		vulns, err := client.GetVulnerabilities(ctx, getCanonicalRef(t, img))
+		require.NoError(t, err, img)
+		assert.Len(t, vulns, 1, img)
+		client.Close()
+	}
+}
+
+func TestGetVulnerabilitiesWithCVSS(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setPollOptions(1, 0)
+	defer defaultPollOptions()
+
+	occs := createAllOccurrences()
+	occs[0].Vulnerability.CvssV3 = &objects.CVSSv3{
+		BaseScore:             9.8,
+		AttackVector:          "ATTACK_VECTOR_NETWORK",
+		AttackComplexity:      "ATTACK_COMPLEXITY_LOW",
+		PrivilegesRequired:    "PRIVILEGES_REQUIRED_NONE",
+		UserInteraction:       "USER_INTERACTION_NONE",
+		Scope:                 "SCOPE_UNCHANGED",
+		ConfidentialityImpact: "IMPACT_HIGH",
+		IntegrityImpact:       "IMPACT_HIGH",
+		AvailabilityImpact:    "IMPACT_HIGH",
+	}
+
+	grafeasMock := mocks.NewMockGrafeasAPIService(ctrl)
+	client, _ := NewClient(ctx, "project", "project", pgp.NewKeyRing(), grafeasMock)
+	grafeasMock.EXPECT().ListOccurrences(gomock.Any(), gomock.Any(), gomock.Any()).Return(objects.ListOccurrencesResponse{
+		Occurrences: occs,
+	}, nil).AnyTimes()
+
+	vulns, err := client.GetVulnerabilities(ctx, getCanonicalRef(t, imgPath))
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	assert.Equal(t, float32(9.8), vulns[0].CVSSScore)
+	assert.Equal(t, "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", vulns[0].CVSSVector)
+	client.Close()
+}
+
 func TestGetBuildDetail(t *testing.T) {
 	ctx := context.Background()
 	project := "project"
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	occs := createAllOccurrences()
-	errRef := getCanonicalRef(t, "us.gcr.io/grafeas/grafeas-server@sha256:c7303bdd6e36868d54b5b00dee125445a8d0f667c366420ccbe41dcf3b1c7733")
+	errRef := getCanonicalRef(t, "ghcr.io/grafeas/grafeas-server@sha256:c7303bdd6e36868d54b5b00dee125445a8d0f667c366420ccbe41dcf3b1c7733")
 	validRef := getCanonicalRef(t, imgPath)
 	tcs := map[string]struct {
 		returnOccs       objects.ListOccurrencesResponse
@@ -345,12 +408,15 @@ func createAllOccurrences() []objects.Occurrence {
 			    EffectiveSeverity: &vulnEffectiveSeverity,
 					PackageIssue: []objects.VulnerabilityPackageIssue{{
 						AffectedLocation: &objects.VulnerabilityLocation{CpeURI: "uri", Package: "package_test",
-							Version: &objects.PackageVersion{Name: "v0.0.0", Kind: &packageKind, Revision: "r"}}}}}},
+							Version: &objects.PackageVersion{Name: "v0.0.0", Kind: &packageKind, Revision: "r"}},
+						FixedLocation: &objects.VulnerabilityLocation{CpeURI: "uri", Package: "package_test",
+							Version: &objects.PackageVersion{Epoch: 1, Name: "v0.0.1", Kind: &packageKind, Revision: "r"}}}}}},
 
 		{Name: "name2", Resource: &objects.Resource{URI: "https://gcr.io/project/image@sha256:foo"},
 			NoteName: "notename", Kind: &noteKindAtt,
 			Attestation: &objects.AttestationDetails{Attestation: &objects.Attestation{
-				GenericSignedAttestation: &objects.AttestationGenericSigned{ContentType: &contentType}}}},
+				GenericSignedAttestation: &objects.AttestationGenericSigned{ContentType: &contentType,
+					Signatures: []objects.Signature{{Signature: []byte("signature"), PublicKeyID: "key"}}}}}},
 
 		{Name: "name3", Resource: &objects.Resource{URI: "https://gcr.io/project/image@sha256:foo"},
 			NoteName: "notename", Kind: &noteKindB,
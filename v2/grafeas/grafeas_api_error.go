@@ -1,6 +1,9 @@
 package grafeas
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+)
 
 //APIError to store grafeas API errors
 type APIError struct {
@@ -27,3 +30,9 @@ func NewAPIError(statusCode int, url, method string, data []byte) error {
 		requestData: string(data),
 	}
 }
+
+// Temporary returns true if the request failed with a server error or was
+// rate limited, so that Checks which query Grafeas are retried.
+func (err *APIError) Temporary() bool {
+	return http.StatusInternalServerError <= err.statusCode || http.StatusTooManyRequests == err.statusCode
+}
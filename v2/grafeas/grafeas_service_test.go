@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/antihax/optional"
+	voucher "github.com/grafeas/voucher/v2"
 	"github.com/grafeas/voucher/v2/grafeas/objects"
 	"github.com/stretchr/testify/assert"
 )
@@ -244,3 +245,18 @@ func TestListOccurrences(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIErrorIsTransient(t *testing.T) {
+	statuses := map[int]bool{
+		http.StatusBadRequest:          false,
+		http.StatusNotFound:            false,
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusServiceUnavailable:  true,
+	}
+
+	for status, transient := range statuses {
+		err := NewAPIError(status, "/grafeastest/occurrences", http.MethodGet, nil)
+		assert.Equal(t, transient, voucher.IsTransientError(err), "status %d", status)
+	}
+}
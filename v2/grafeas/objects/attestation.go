@@ -31,6 +31,11 @@ func (ad *AttestationDetails) AsVoucherAttestation(checkName string) voucher.Sig
 
 	signedAttestation.Body = string(*ad.Attestation.GenericSignedAttestation.ContentType)
 
+	if signatures := ad.Attestation.GenericSignedAttestation.Signatures; 0 < len(signatures) {
+		signedAttestation.Signature = string(signatures[0].Signature)
+		signedAttestation.KeyID = signatures[0].PublicKeyID
+	}
+
 	return signedAttestation
 }
 
@@ -1,5 +1,7 @@
 package objects
 
+import "fmt"
+
 //VersionKind based on
 //https://github.com/grafeas/client-go/blob/master/0.1.0/model_version_version_kind.go
 type VersionKind string
@@ -26,3 +28,21 @@ type PackageVersion struct {
 	Revision string       `json:"revision,omitempty"`
 	Kind     *VersionKind `json:"kind,omitempty"` //required
 }
+
+// String returns the version in the form [epoch:]name[-revision]. It returns
+// an empty string if the version is not a normal version, such as the maximum
+// version used for vulnerabilities without a fix.
+func (v *PackageVersion) String() string {
+	if nil == v.Kind || VersionKindNormal != *v.Kind || "" == v.Name {
+		return ""
+	}
+
+	version := v.Name
+	if 0 != v.Epoch {
+		version = fmt.Sprintf("%d:%s", v.Epoch, version)
+	}
+	if "" != v.Revision {
+		version += "-" + v.Revision
+	}
+	return version
+}
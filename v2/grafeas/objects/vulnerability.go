@@ -24,9 +24,43 @@ const (
 //https://github.com/grafeas/client-go/blob/master/0.1.0/model_vulnerability_vulnerability.go
 type Vulnerability struct {
 	CvssScore float32                `json:"cvssScore,omitempty"`
+	CvssV3    *CVSSv3                `json:"cvssV3,omitempty"`
 	Severity  *VulnerabilitySeverity `json:"severity,omitempty"`
 }
 
+//CVSSv3 based on
+//https://github.com/grafeas/client-go/blob/master/0.1.0/model_vulnerability_cvs_sv3.go
+type CVSSv3 struct {
+	BaseScore             float32 `json:"baseScore,omitempty"`
+	AttackVector          string  `json:"attackVector,omitempty"`
+	AttackComplexity      string  `json:"attackComplexity,omitempty"`
+	PrivilegesRequired    string  `json:"privilegesRequired,omitempty"`
+	UserInteraction       string  `json:"userInteraction,omitempty"`
+	Scope                 string  `json:"scope,omitempty"`
+	ConfidentialityImpact string  `json:"confidentialityImpact,omitempty"`
+	IntegrityImpact       string  `json:"integrityImpact,omitempty"`
+	AvailabilityImpact    string  `json:"availabilityImpact,omitempty"`
+}
+
+// vector returns the CVSS v3 vector of the score, or an empty string if it is
+// not complete. It is safe to call on a nil CVSSv3.
+func (cvss *CVSSv3) vector() string {
+	if nil == cvss {
+		return ""
+	}
+
+	return voucher.CVSSv3Metrics{
+		AttackVector:          cvss.AttackVector,
+		AttackComplexity:      cvss.AttackComplexity,
+		PrivilegesRequired:    cvss.PrivilegesRequired,
+		UserInteraction:       cvss.UserInteraction,
+		Scope:                 cvss.Scope,
+		ConfidentialityImpact: cvss.ConfidentialityImpact,
+		IntegrityImpact:       cvss.IntegrityImpact,
+		AvailabilityImpact:    cvss.AvailabilityImpact,
+	}.Vector()
+}
+
 //vulnerability for occurrence
 
 //VulnerabilityDetails based on
@@ -35,21 +69,67 @@ type VulnerabilityDetails struct {
 	Type              string                      `json:"type,omitempty"`
 	Severity          *VulnerabilitySeverity      `json:"severity,omitempty"`         //output only
 	CvssScore         float32                     `json:"cvssScore,omitempty"`        //output only
+	CvssV3            *CVSSv3                     `json:"cvssV3,omitempty"`           //output only
 	PackageIssue      []VulnerabilityPackageIssue `json:"packageIssue,omitempty"`     //required
 	ShortDescription  string                      `json:"shortDescription,omitempty"` //output only
 	LongDescription   string                      `json:"longDescription,omitempty"`  //output only
+	RelatedUrls       []RelatedURL                `json:"relatedUrls,omitempty"`      //output only
 	EffectiveSeverity *VulnerabilitySeverity      `json:"effectiveSeverity,omitempty"`
 }
 
+//RelatedURL based on
+//https://github.com/grafeas/client-go/blob/master/0.1.0/model_v1beta1_related_url.go
+type RelatedURL struct {
+	URL   string `json:"url,omitempty"`
+	Label string `json:"label,omitempty"`
+}
+
 // AsVoucherVulnerability converts an VulnerabilityDetails to a Vulnerability.
 func (vd *VulnerabilityDetails) AsVoucherVulnerability(noteName, vulProject string) (vul voucher.Vulnerability) {
 	vul.Name = strings.Replace(noteName, vulProject, "", 1)
 
 	vul.Severity = getSeverity(vd.EffectiveSeverity)
 
+	vul.Description = vd.ShortDescription
+	if "" != vd.LongDescription {
+		vul.Description = vd.LongDescription
+	}
+
+	vul.CVSSScore = vd.CvssScore
+	vul.CVSSVector = vd.CvssV3.vector()
+	if 0 == vul.CVSSScore && nil != vd.CvssV3 {
+		vul.CVSSScore = vd.CvssV3.BaseScore
+	}
+
+	if issue := vd.getPackageIssue(); nil != issue {
+		vul.PackageName, vul.PackageVersion = issue.AffectedLocation.packageAndVersion()
+		_, vul.FixedBy = issue.FixedLocation.packageAndVersion()
+	}
+
+	for _, relatedURL := range vd.RelatedUrls {
+		if "" != relatedURL.URL {
+			vul.URLs = append(vul.URLs, relatedURL.URL)
+		}
+	}
+
 	return
 }
 
+// getPackageIssue returns the first package issue which has been fixed, or
+// the first package issue if none of them have a fix.
+func (vd *VulnerabilityDetails) getPackageIssue() *VulnerabilityPackageIssue {
+	for i := range vd.PackageIssue {
+		if _, version := vd.PackageIssue[i].FixedLocation.packageAndVersion(); "" != version {
+			return &vd.PackageIssue[i]
+		}
+	}
+
+	if 0 < len(vd.PackageIssue) {
+		return &vd.PackageIssue[0]
+	}
+	return nil
+}
+
 // getSeverity translates the client-fo grafeas Severity to a Voucher Severity.
 func getSeverity(severity *VulnerabilitySeverity) voucher.Severity {
 	if severity == nil {
@@ -85,3 +165,15 @@ type VulnerabilityLocation struct {
 	Package string          `json:"package,omitempty"` //required
 	Version *PackageVersion `json:"version,omitempty"` //required
 }
+
+// packageAndVersion returns the package and version of the location. It is
+// safe to call on a nil VulnerabilityLocation.
+func (vl *VulnerabilityLocation) packageAndVersion() (string, string) {
+	if nil == vl {
+		return "", ""
+	}
+	if nil == vl.Version {
+		return vl.Package, ""
+	}
+	return vl.Package, vl.Version.String()
+}
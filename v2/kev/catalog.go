@@ -0,0 +1,57 @@
+package kev
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// catalogEntry is a vulnerability in CISA's Known Exploited Vulnerabilities
+// catalog.
+type catalogEntry struct {
+	CVEID                      string `json:"cveID"`
+	VendorProject              string `json:"vendorProject"`
+	Product                    string `json:"product"`
+	VulnerabilityName          string `json:"vulnerabilityName"`
+	DateAdded                  string `json:"dateAdded"`
+	RequiredAction             string `json:"requiredAction"`
+	DueDate                    string `json:"dueDate"`
+	KnownRansomwareCampaignUse string `json:"knownRansomwareCampaignUse"`
+}
+
+// catalog is CISA's Known Exploited Vulnerabilities catalog.
+type catalog struct {
+	CatalogVersion  string         `json:"catalogVersion"`
+	Vulnerabilities []catalogEntry `json:"vulnerabilities"`
+}
+
+// ParseCatalog parses a Known Exploited Vulnerabilities catalog in CISA's JSON
+// format, and returns its entries keyed by their CVE IDs.
+func ParseCatalog(data []byte) (map[string]voucher.KnownExploitedVulnerability, error) {
+	var parsed catalog
+	if err := json.Unmarshal(data, &parsed); nil != err {
+		return nil, fmt.Errorf("could not parse KEV catalog: %s", err)
+	}
+
+	entries := make(map[string]voucher.KnownExploitedVulnerability, len(parsed.Vulnerabilities))
+	for _, entry := range parsed.Vulnerabilities {
+		if "" == entry.CVEID {
+			continue
+		}
+
+		entries[strings.ToUpper(entry.CVEID)] = voucher.KnownExploitedVulnerability{
+			CVEID:             entry.CVEID,
+			VendorProject:     entry.VendorProject,
+			Product:           entry.Product,
+			VulnerabilityName: entry.VulnerabilityName,
+			DateAdded:         entry.DateAdded,
+			RequiredAction:    entry.RequiredAction,
+			DueDate:           entry.DueDate,
+			RansomwareUse:     entry.KnownRansomwareCampaignUse,
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,65 @@
+package kev
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// Database implements voucher.ExploitDatabase, using a Known Exploited
+// Vulnerabilities catalog and, optionally, EPSS scores.
+type Database struct {
+	catalog map[string]voucher.KnownExploitedVulnerability
+	epss    map[string]float32
+}
+
+// KnownExploited returns the catalog entry for the vulnerability with the
+// passed CVE ID, if it is in the catalog.
+func (db *Database) KnownExploited(cve string) (voucher.KnownExploitedVulnerability, bool) {
+	entry, ok := db.catalog[strings.ToUpper(cve)]
+	return entry, ok
+}
+
+// ExploitProbability returns the EPSS probability of the vulnerability with
+// the passed CVE ID, if it has one.
+func (db *Database) ExploitProbability(cve string) (float32, bool) {
+	score, ok := db.epss[strings.ToUpper(cve)]
+	return score, ok
+}
+
+// LoadDatabase loads the Known Exploited Vulnerabilities catalog in the
+// passed file, and the EPSS scores in the passed CSV file. Either file may
+// be empty, in which case that data is not used.
+func LoadDatabase(catalogFile, epssFile string) (*Database, error) {
+	db := &Database{
+		catalog: map[string]voucher.KnownExploitedVulnerability{},
+		epss:    map[string]float32{},
+	}
+
+	if "" != catalogFile {
+		data, err := ioutil.ReadFile(catalogFile)
+		if nil != err {
+			return nil, err
+		}
+
+		if db.catalog, err = ParseCatalog(data); nil != err {
+			return nil, err
+		}
+	}
+
+	if "" != epssFile {
+		file, err := os.Open(epssFile)
+		if nil != err {
+			return nil, err
+		}
+		defer file.Close()
+
+		if db.epss, err = ParseEPSS(file); nil != err {
+			return nil, err
+		}
+	}
+
+	return db, nil
+}
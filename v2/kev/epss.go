@@ -0,0 +1,60 @@
+package kev
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseEPSS parses EPSS scores in the CSV format published by FIRST, and
+// returns the probability of each vulnerability being exploited, keyed by
+// CVE ID. The comment line that starts the file, which describes the model
+// version, is skipped.
+func ParseEPSS(r io.Reader) (map[string]float32, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if nil != err {
+		return nil, fmt.Errorf("could not parse EPSS scores: %s", err)
+	}
+
+	cveColumn, epssColumn := -1, -1
+	for i, name := range header {
+		switch strings.TrimSpace(strings.ToLower(name)) {
+		case "cve":
+			cveColumn = i
+		case "epss":
+			epssColumn = i
+		}
+	}
+
+	if -1 == cveColumn || -1 == epssColumn {
+		return nil, fmt.Errorf("could not parse EPSS scores: missing cve or epss column")
+	}
+
+	scores := make(map[string]float32)
+	for {
+		record, err := reader.Read()
+		if io.EOF == err {
+			return scores, nil
+		}
+		if nil != err {
+			return nil, fmt.Errorf("could not parse EPSS scores: %s", err)
+		}
+
+		if len(record) <= cveColumn || len(record) <= epssColumn {
+			continue
+		}
+
+		score, err := strconv.ParseFloat(strings.TrimSpace(record[epssColumn]), 32)
+		if nil != err {
+			return nil, fmt.Errorf("could not parse EPSS score for %s: %s", record[cveColumn], err)
+		}
+
+		scores[strings.ToUpper(strings.TrimSpace(record[cveColumn]))] = float32(score)
+	}
+}
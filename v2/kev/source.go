@@ -0,0 +1,65 @@
+package kev
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// Source implements voucher.ExploitSource. It loads a Database from files,
+// and reloads it once the reload interval has passed, so that updated files
+// are used without restarting.
+type Source struct {
+	catalogFile string
+	epssFile    string
+	interval    time.Duration
+	now         func() time.Time
+
+	mu       sync.Mutex
+	db       *Database
+	loadedAt time.Time
+}
+
+// Load returns the Database, loading it if it has not been loaded yet, or if
+// it was loaded longer ago than the reload interval. If reloading fails, the
+// error is logged and the previously loaded Database is returned.
+func (source *Source) Load() (voucher.ExploitDatabase, error) {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+
+	now := source.now()
+	if nil != source.db && (0 >= source.interval || now.Sub(source.loadedAt) < source.interval) {
+		return source.db, nil
+	}
+
+	db, err := LoadDatabase(source.catalogFile, source.epssFile)
+	if nil != err {
+		if nil == source.db {
+			return nil, err
+		}
+
+		log.Errorf("failed to reload exploit data, using data from %s: %s", source.loadedAt.Format(time.RFC3339), err)
+		source.loadedAt = now
+		return source.db, nil
+	}
+
+	source.db = db
+	source.loadedAt = now
+	return source.db, nil
+}
+
+// NewSource creates a new Source, which loads the Known Exploited
+// Vulnerabilities catalog and EPSS scores in the passed files, and reloads
+// them after the passed interval. If the interval is zero, they are only
+// loaded once.
+func NewSource(catalogFile, epssFile string, interval time.Duration) *Source {
+	return &Source{
+		catalogFile: catalogFile,
+		epssFile:    epssFile,
+		interval:    interval,
+		now:         time.Now,
+	}
+}
@@ -0,0 +1,131 @@
+package kev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+const testCatalog = `{
+	"title": "CISA Catalog of Known Exploited Vulnerabilities",
+	"catalogVersion": "2023.01.01",
+	"count": 1,
+	"vulnerabilities": [
+		{
+			"cveID": "CVE-2021-44228",
+			"vendorProject": "Apache",
+			"product": "Log4j2",
+			"vulnerabilityName": "Apache Log4j2 Remote Code Execution Vulnerability",
+			"dateAdded": "2021-12-10",
+			"shortDescription": "Apache Log4j2 contains a vulnerability where JNDI features do not protect against attacker-controlled JNDI-related endpoints.",
+			"requiredAction": "Apply updates per vendor instructions.",
+			"dueDate": "2021-12-24",
+			"knownRansomwareCampaignUse": "Known"
+		}
+	]
+}`
+
+const testEPSS = `#model_version:v2023.03.01,score_date:2023-06-01T00:00:00+0000
+cve,epss,percentile
+CVE-2021-44228,0.97565,0.99996
+CVE-2020-1967,0.01234,0.80000
+`
+
+func TestParseCatalog(t *testing.T) {
+	entries, err := ParseCatalog([]byte(testCatalog))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]voucher.KnownExploitedVulnerability{
+		"CVE-2021-44228": {
+			CVEID:             "CVE-2021-44228",
+			VendorProject:     "Apache",
+			Product:           "Log4j2",
+			VulnerabilityName: "Apache Log4j2 Remote Code Execution Vulnerability",
+			DateAdded:         "2021-12-10",
+			RequiredAction:    "Apply updates per vendor instructions.",
+			DueDate:           "2021-12-24",
+			RansomwareUse:     "Known",
+		},
+	}, entries)
+
+	_, err = ParseCatalog([]byte("{"))
+	assert.Error(t, err)
+}
+
+func TestParseEPSS(t *testing.T) {
+	scores, err := ParseEPSS(strings.NewReader(testEPSS))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]float32{
+		"CVE-2021-44228": 0.97565,
+		"CVE-2020-1967":  0.01234,
+	}, scores)
+
+	_, err = ParseEPSS(strings.NewReader("name,score\nCVE-2021-44228,0.9\n"))
+	assert.Error(t, err)
+
+	_, err = ParseEPSS(strings.NewReader("cve,epss\nCVE-2021-44228,high\n"))
+	assert.Error(t, err)
+}
+
+func TestSourceReloads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "voucher-kev")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	catalogFile := filepath.Join(dir, "known_exploited_vulnerabilities.json")
+	epssFile := filepath.Join(dir, "epss_scores.csv")
+
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	source := NewSource(catalogFile, epssFile, time.Hour)
+	source.now = func() time.Time {
+		return now
+	}
+
+	_, err = source.Load()
+	assert.Error(t, err, "loaded missing files")
+
+	require.NoError(t, ioutil.WriteFile(catalogFile, []byte(`{"vulnerabilities": []}`), 0600))
+	require.NoError(t, ioutil.WriteFile(epssFile, []byte(testEPSS), 0600))
+
+	db, err := source.Load()
+	require.NoError(t, err)
+
+	_, ok := db.KnownExploited("CVE-2021-44228")
+	assert.False(t, ok)
+
+	probability, ok := db.ExploitProbability("cve-2021-44228")
+	assert.True(t, ok)
+	assert.Equal(t, float32(0.97565), probability)
+
+	require.NoError(t, ioutil.WriteFile(catalogFile, []byte(testCatalog), 0600))
+
+	db, err = source.Load()
+	require.NoError(t, err)
+	_, ok = db.KnownExploited("CVE-2021-44228")
+	assert.False(t, ok, "reloaded before the interval passed")
+
+	now = now.Add(time.Hour)
+
+	db, err = source.Load()
+	require.NoError(t, err)
+	_, ok = db.KnownExploited("CVE-2021-44228")
+	assert.True(t, ok, "did not reload after the interval passed")
+
+	require.NoError(t, ioutil.WriteFile(catalogFile, []byte("{"), 0600))
+	now = now.Add(time.Hour)
+
+	db, err = source.Load()
+	require.NoError(t, err, "failed reload did not keep the previous data")
+	_, ok = db.KnownExploited("CVE-2021-44228")
+	assert.True(t, ok)
+}
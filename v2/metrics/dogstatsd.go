@@ -71,6 +71,18 @@ func (d *DogStatsdClient) PubSubTotalLatency(duration time.Duration) {
 	_ = d.client.Timing("auto_voucher.latency", duration, []string{}, d.samplingRate)
 }
 
+// RegistryCacheHit tracks the number of registry artifacts, such as manifests
+// or blobs, that were read from the registry cache
+func (d *DogStatsdClient) RegistryCacheHit(kind string) {
+	_ = d.client.Incr("voucher.registry.cache.hit", []string{"kind:" + kind}, d.samplingRate)
+}
+
+// RegistryCacheMiss tracks the number of registry artifacts, such as manifests
+// or blobs, that were not in the registry cache and were requested
+func (d *DogStatsdClient) RegistryCacheMiss(kind string) {
+	_ = d.client.Incr("voucher.registry.cache.miss", []string{"kind:" + kind}, d.samplingRate)
+}
+
 func createDataDogErrorEvent(check, title string, err error) *statsd.Event {
 	event := statsd.NewEvent(title, err.Error())
 	event.AlertType = statsd.Error
@@ -16,4 +16,6 @@ type Client interface {
 	CheckAttestationSuccess(string)
 	PubSubMessageReceived()
 	PubSubTotalLatency(time.Duration)
+	RegistryCacheHit(string)
+	RegistryCacheMiss(string)
 }
@@ -17,3 +17,5 @@ func (*NoopClient) CheckAttestationError(string, error)           {}
 func (*NoopClient) CheckAttestationSuccess(string)                {}
 func (*NoopClient) PubSubMessageReceived()                        {}
 func (*NoopClient) PubSubTotalLatency(time.Duration)              {}
+func (*NoopClient) RegistryCacheHit(string)                       {}
+func (*NoopClient) RegistryCacheMiss(string)                      {}
@@ -0,0 +1,94 @@
+package osv
+
+import (
+	"math"
+	"strings"
+)
+
+// cvssV3Weights are the weights of the CVSS v3 base metric values. The
+// weights of privileges required when the scope is changed are in
+// cvssV3ChangedPrivileges.
+var cvssV3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// cvssV3ChangedPrivileges are the weights of privileges required when the
+// scope is changed.
+var cvssV3ChangedPrivileges = map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}
+
+// roundUp rounds the passed score up to one decimal place, as defined by
+// CVSS v3.1.
+func roundUp(score float64) float64 {
+	scaled := int64(math.Round(score * 100000))
+	if 0 == scaled%10000 {
+		return float64(scaled) / 100000
+	}
+	return float64(scaled/10000+1) / 10
+}
+
+// cvssV3Score calculates the base score of the passed CVSS v3 vector, such as
+// "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H". It returns false if the
+// vector is not valid.
+func cvssV3Score(vector string) (float32, bool) {
+	parts := strings.Split(vector, "/")
+	if 0 == len(parts) || !strings.HasPrefix(parts[0], "CVSS:3.") {
+		return 0, false
+	}
+
+	values := make(map[string]string, len(parts)-1)
+	for _, part := range parts[1:] {
+		metric := strings.SplitN(part, ":", 2)
+		if 2 != len(metric) {
+			return 0, false
+		}
+		values[metric[0]] = metric[1]
+	}
+
+	changed := false
+	switch values["S"] {
+	case "C":
+		changed = true
+	case "U":
+	default:
+		return 0, false
+	}
+
+	weights := make(map[string]float64, len(cvssV3Weights))
+	for metric, metricWeights := range cvssV3Weights {
+		weight, ok := metricWeights[values[metric]]
+		if !ok {
+			return 0, false
+		}
+		weights[metric] = weight
+	}
+
+	if changed {
+		weights["PR"] = cvssV3ChangedPrivileges[values["PR"]]
+	}
+
+	impactSubScore := 1 - (1-weights["C"])*(1-weights["I"])*(1-weights["A"])
+
+	impact := 6.42 * impactSubScore
+	if changed {
+		impact = 7.52*(impactSubScore-0.029) - 3.25*math.Pow(impactSubScore-0.02, 15)
+	}
+
+	if 0 >= impact {
+		return 0, true
+	}
+
+	exploitability := 8.22 * weights["AV"] * weights["AC"] * weights["PR"] * weights["UI"]
+
+	score := impact + exploitability
+	if changed {
+		score *= 1.08
+	}
+
+	return float32(roundUp(math.Min(score, 10))), true
+}
@@ -0,0 +1,122 @@
+package osv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Database is a set of OSV entries, indexed by the packages they affect.
+type Database struct {
+	packages map[string]map[string][]*Entry
+}
+
+// Match is an Entry which affects a package.
+type Match struct {
+	Entry   *Entry
+	FixedBy string
+}
+
+// Add adds the passed Entry to the Database. Withdrawn entries are ignored.
+func (db *Database) Add(entry Entry) {
+	if "" != entry.Withdrawn {
+		return
+	}
+
+	for _, affected := range entry.Affected {
+		ecosystem, _ := splitEcosystem(affected.Package.Ecosystem)
+		name := normalizeName(ecosystem, affected.Package.Name)
+
+		if nil == db.packages[ecosystem] {
+			db.packages[ecosystem] = make(map[string][]*Entry)
+		}
+
+		entries := db.packages[ecosystem][name]
+		if 0 < len(entries) && entries[len(entries)-1].ID == entry.ID {
+			continue
+		}
+
+		db.packages[ecosystem][name] = append(entries, &entry)
+	}
+}
+
+// Match returns the entries which affect the passed package, sorted by their
+// IDs.
+func (db *Database) Match(pkg Package) []Match {
+	ecosystem, release := splitEcosystem(pkg.Ecosystem)
+	name, version := pkg.matchNameAndVersion()
+
+	entries := db.packages[ecosystem][normalizeName(ecosystem, name)]
+	matches := make([]Match, 0)
+
+	for _, entry := range entries {
+		for _, affected := range entry.Affected {
+			affectedEcosystem, affectedRelease := splitEcosystem(affected.Package.Ecosystem)
+			if ecosystem != affectedEcosystem || !sameRelease(release, affectedRelease) {
+				continue
+			}
+
+			if normalizeName(ecosystem, affected.Package.Name) != normalizeName(ecosystem, name) {
+				continue
+			}
+
+			if ok, fixedBy := affected.affects(version); ok {
+				matches = append(matches, Match{Entry: entry, FixedBy: fixedBy})
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Entry.ID < matches[j].Entry.ID
+	})
+
+	return matches
+}
+
+// NewDatabase creates an empty Database.
+func NewDatabase() *Database {
+	return &Database{
+		packages: make(map[string]map[string][]*Entry),
+	}
+}
+
+// LoadDatabase creates a Database from the OSV JSON files in the passed
+// directory and its subdirectories, such as a mirror of the OSV data exports.
+// Files which do not end in ".json" are ignored.
+func LoadDatabase(dir string) (*Database, error) {
+	db := NewDatabase()
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if nil != err {
+			return err
+		}
+
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".json") {
+			return nil
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if nil != err {
+			return err
+		}
+
+		var entry Entry
+		if err = json.Unmarshal(data, &entry); nil != err {
+			return fmt.Errorf("failed to parse OSV entry %s: %s", path, err)
+		}
+
+		db.Add(entry)
+		return nil
+	})
+
+	if nil != err {
+		return nil, err
+	}
+
+	return db, nil
+}
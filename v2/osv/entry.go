@@ -0,0 +1,99 @@
+package osv
+
+import (
+	"strings"
+)
+
+// Entry is a vulnerability in the Open Source Vulnerability format, as
+// described at https://ossf.github.io/osv-schema/.
+type Entry struct {
+	ID               string                 `json:"id"`
+	Aliases          []string               `json:"aliases"`
+	Withdrawn        string                 `json:"withdrawn"`
+	Summary          string                 `json:"summary"`
+	Details          string                 `json:"details"`
+	Severity         []Severity             `json:"severity"`
+	Affected         []Affected             `json:"affected"`
+	References       []Reference            `json:"references"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific"`
+}
+
+// Severity is a severity score of an Entry, such as a CVSS vector.
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Affected describes the versions of a package that an Entry affects.
+type Affected struct {
+	Package           AffectedPackage        `json:"package"`
+	Ranges            []Range                `json:"ranges"`
+	Versions          []string               `json:"versions"`
+	EcosystemSpecific map[string]interface{} `json:"ecosystem_specific"`
+	DatabaseSpecific  map[string]interface{} `json:"database_specific"`
+}
+
+// AffectedPackage identifies the package that an Affected describes.
+type AffectedPackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl"`
+}
+
+// Range is a range of affected versions, described as a list of events.
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event is a version at which a package becomes, or stops being, affected.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Reference is a link to more information about an Entry.
+type Reference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// splitEcosystem splits an ecosystem such as "Debian:10" into its name and
+// release. The release is empty if the ecosystem does not have one.
+func splitEcosystem(ecosystem string) (string, string) {
+	parts := strings.SplitN(ecosystem, ":", 2)
+	if 2 == len(parts) {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+// sameRelease returns true if the passed releases do not conflict. Releases
+// only conflict if both are set, and their first components differ, so that
+// "22.04:LTS" and "22.04" are the same release.
+func sameRelease(a, b string) bool {
+	if "" == a || "" == b {
+		return true
+	}
+
+	a, _ = splitEcosystem(a)
+	b, _ = splitEcosystem(b)
+
+	return a == b
+}
+
+// normalizeName normalizes the passed package name in the passed ecosystem.
+// Python package names are case insensitive, and treat runs of "-", "_" and
+// "." as equal, so they are lowercased and those runs replaced with a "-".
+func normalizeName(ecosystem, name string) string {
+	if "PyPI" != ecosystem {
+		return name
+	}
+
+	name = strings.ToLower(name)
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return '-' == r || '_' == r || '.' == r
+	}), "-")
+}
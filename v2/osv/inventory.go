@@ -0,0 +1,87 @@
+package osv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// ErrNoInventory is returned when there is no package inventory for an image.
+var ErrNoInventory = errors.New("no package inventory for image")
+
+// Package is a package installed in an image.
+type Package struct {
+	Ecosystem     string `json:"ecosystem"`
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	Source        string `json:"source,omitempty"`
+	SourceVersion string `json:"source_version,omitempty"`
+}
+
+// matchNameAndVersion returns the name and version used to match the Package
+// against OSV entries. Operating system vulnerabilities are tracked against
+// source packages, so if the Package was built from a source package, that
+// source package's name and version are returned.
+func (pkg Package) matchNameAndVersion() (string, string) {
+	name, version := pkg.Name, pkg.Version
+
+	if "" != pkg.Source {
+		name = pkg.Source
+	}
+
+	if "" != pkg.SourceVersion {
+		version = pkg.SourceVersion
+	}
+
+	return name, version
+}
+
+// Inventory is the list of packages installed in an image.
+type Inventory struct {
+	Packages []Package `json:"packages"`
+}
+
+// InventorySource gets the package Inventory for an image.
+type InventorySource interface {
+	GetInventory(ctx context.Context, i voucher.ImageData) (Inventory, error)
+}
+
+// directoryInventory is an InventorySource which reads inventories from a
+// directory.
+type directoryInventory struct {
+	dir string
+}
+
+// GetInventory reads the inventory for the passed image from the directory.
+func (source *directoryInventory) GetInventory(ctx context.Context, i voucher.ImageData) (Inventory, error) {
+	var inventory Inventory
+
+	name := fmt.Sprintf("%s-%s.json", i.Digest().Algorithm(), i.Digest().Hex())
+
+	data, err := ioutil.ReadFile(filepath.Join(source.dir, name))
+	if os.IsNotExist(err) {
+		return inventory, ErrNoInventory
+	}
+	if nil != err {
+		return inventory, err
+	}
+
+	if err = json.Unmarshal(data, &inventory); nil != err {
+		return inventory, fmt.Errorf("failed to parse package inventory %s: %s", name, err)
+	}
+
+	return inventory, nil
+}
+
+// NewDirectoryInventory creates an InventorySource which reads inventories
+// from the passed directory. Inventories are named after the image digest,
+// such as "sha256-<hex>.json".
+func NewDirectoryInventory(dir string) InventorySource {
+	return &directoryInventory{dir: dir}
+}
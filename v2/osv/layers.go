@@ -0,0 +1,209 @@
+package osv
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+
+	digest "github.com/opencontainers/go-digest"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/docker"
+	dockerURI "github.com/grafeas/voucher/v2/docker/uri"
+)
+
+// maxFileSize is the largest package database file that is read from a
+// layer. Larger files are skipped.
+const maxFileSize = 32 << 20
+
+// imageFiles holds the contents of the package database files in an image,
+// keyed by their paths.
+type imageFiles map[string][]byte
+
+// isPackageFile returns true if the file at the passed path is used to find
+// the packages installed in an image.
+func isPackageFile(filePath string) bool {
+	dir, name := path.Split(filePath)
+
+	switch {
+	case "etc/os-release" == filePath, "usr/lib/os-release" == filePath:
+		return true
+	case "var/lib/dpkg/status" == filePath, "var/lib/dpkg/status.d/" == dir:
+		return true
+	case "lib/apk/db/installed" == filePath:
+		return true
+	case "package.json" == name:
+		return isNodeModule(dir)
+	case "METADATA" == name:
+		return strings.HasSuffix(dir, ".dist-info/")
+	case "PKG-INFO" == name:
+		return strings.HasSuffix(dir, ".egg-info/")
+	case strings.HasSuffix(name, ".gemspec"):
+		return strings.HasSuffix(dir, "/specifications/")
+	case isJavaArchive(name):
+		return true
+	}
+
+	return false
+}
+
+// isNodeModule returns true if the passed directory is a package in a
+// node_modules directory, such as "node_modules/left-pad/" or
+// "node_modules/@scope/name/".
+func isNodeModule(dir string) bool {
+	parent := path.Dir(path.Dir(dir))
+	if strings.HasPrefix(path.Base(parent), "@") {
+		parent = path.Dir(parent)
+	}
+
+	return "node_modules" == path.Base(parent)
+}
+
+// remove removes the file at the passed path, as well as any files in it if
+// it is a directory.
+func (files imageFiles) remove(filePath string) {
+	for name := range files {
+		if name == filePath || strings.HasPrefix(name, filePath+"/") {
+			delete(files, name)
+		}
+	}
+}
+
+// addLayer applies the passed layer, which is a tar archive that may be
+// compressed with gzip, to the files. Whiteout files in the layer remove
+// files added by earlier layers.
+func (files imageFiles) addLayer(layer io.Reader) error {
+	buffered := bufio.NewReader(layer)
+
+	var reader io.Reader = buffered
+	if magic, err := buffered.Peek(2); nil == err && 0x1f == magic[0] && 0x8b == magic[1] {
+		gzipReader, err := gzip.NewReader(buffered)
+		if nil != err {
+			return err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if io.EOF == err {
+			return nil
+		}
+		if nil != err {
+			return err
+		}
+
+		filePath := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		dir, name := path.Split(filePath)
+
+		if ".wh..wh..opq" == name {
+			files.remove(strings.TrimSuffix(dir, "/"))
+			continue
+		}
+
+		if strings.HasPrefix(name, ".wh.") {
+			files.remove(dir + strings.TrimPrefix(name, ".wh."))
+			continue
+		}
+
+		delete(files, filePath)
+
+		if tar.TypeReg != header.Typeflag && tar.TypeRegA != header.Typeflag {
+			continue
+		}
+
+		executable := 0 != header.Mode&0111
+		if (!isPackageFile(filePath) && !executable) || maxFileSize < header.Size {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(archive)
+		if nil != err {
+			return err
+		}
+
+		// Other executables may be Go binaries, which are large, so only
+		// their module information is kept.
+		if !isPackageFile(filePath) {
+			if data, ok := goModInfo(data); ok {
+				files[filePath] = data
+			}
+			continue
+		}
+		files[filePath] = data
+	}
+}
+
+// layerInventory is an InventorySource which finds the packages installed in
+// an image by reading its layers.
+type layerInventory struct {
+	auth voucher.Auth
+}
+
+// addLayer downloads the layer with the passed digest, and applies it to the
+// passed files.
+func addLayer(ctx context.Context, client *http.Client, i voucher.ImageData, layer digest.Digest, files imageFiles) error {
+	request, err := http.NewRequest(http.MethodGet, dockerURI.GetBlobURI(i, layer), nil)
+	if nil != err {
+		return err
+	}
+
+	resp, err := client.Do(request.WithContext(ctx))
+	if nil != err {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if 300 <= resp.StatusCode {
+		return fmt.Errorf("getting layer %s failed: %s", layer, resp.Status)
+	}
+
+	return files.addLayer(resp.Body)
+}
+
+// GetInventory downloads the layers of the passed image, and returns the
+// packages installed in it. If the image is an image index, the layers of the
+// image in it for docker.DefaultPlatform are downloaded.
+func (source *layerInventory) GetInventory(ctx context.Context, i voucher.ImageData) (Inventory, error) {
+	client, err := source.auth.ToClient(ctx, i)
+	if nil != err {
+		return Inventory{}, err
+	}
+
+	_, manifest, err := docker.RequestImageManifest(client, i)
+	if nil != err {
+		return Inventory{}, err
+	}
+
+	layers, err := docker.LayerDigests(manifest)
+	if nil != err {
+		return Inventory{}, err
+	}
+
+	files := make(imageFiles)
+	for _, layer := range layers {
+		if err = addLayer(ctx, client, i, layer, files); nil != err {
+			return Inventory{}, err
+		}
+	}
+
+	return files.inventory(), nil
+}
+
+// NewLayerInventory creates an InventorySource which finds the packages
+// installed in images by reading their layers, using the passed Auth to
+// download them. Debian and Alpine packages are found, as well as npm, PyPI,
+// and RubyGems packages, Maven packages in Java archives, and the modules
+// that Go binaries were built with.
+func NewLayerInventory(auth voucher.Auth) InventorySource {
+	return &layerInventory{auth: auth}
+}
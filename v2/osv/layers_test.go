@@ -0,0 +1,205 @@
+package osv
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOSRelease = `PRETTY_NAME="Debian GNU/Linux 10 (buster)"
+NAME="Debian GNU/Linux"
+VERSION_ID="10"
+ID=debian
+`
+
+const testDpkgStatus = `Package: libssl1.1
+Status: install ok installed
+Version: 1.1.1d-0+deb10u2
+Source: openssl
+Description: Secure Sockets Layer toolkit
+ This package is part of the OpenSSL project.
+
+Package: bash
+Status: install ok installed
+Version: 5.0-4
+
+Package: removed
+Status: deinstall ok config-files
+Version: 1.0-1
+`
+
+func newTestLayer(t *testing.T, compress bool, files map[string]string) []byte {
+	t.Helper()
+
+	var layer bytes.Buffer
+	var archive *tar.Writer
+	var gzipWriter *gzip.Writer
+
+	if compress {
+		gzipWriter = gzip.NewWriter(&layer)
+		archive = tar.NewWriter(gzipWriter)
+	} else {
+		archive = tar.NewWriter(&layer)
+	}
+
+	for name, content := range files {
+		require.NoError(t, archive.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := archive.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, archive.Close())
+	if compress {
+		require.NoError(t, gzipWriter.Close())
+	}
+
+	return layer.Bytes()
+}
+
+func TestImageFilesInventory(t *testing.T) {
+	files := make(imageFiles)
+
+	require.NoError(t, files.addLayer(bytes.NewReader(newTestLayer(t, true, map[string]string{
+		"etc/os-release":      testOSRelease,
+		"var/lib/dpkg/status": testDpkgStatus,
+		"usr/bin/bash":        "#!",
+	}))))
+
+	require.NoError(t, files.addLayer(bytes.NewReader(newTestLayer(t, false, map[string]string{
+		"./app/node_modules/lodash/package.json":                               `{"name": "lodash", "version": "4.17.15"}`,
+		"app/node_modules/@babel/core/package.json":                            `{"name": "@babel/core", "version": "7.9.0"}`,
+		"app/node_modules/lodash/lib/package.json":                             `{"name": "internal", "version": "1.0.0"}`,
+		"app/node_modules/left-pad/package.json":                               `{"name": "left-pad", "version": "1.3.0"}`,
+		"usr/lib/python3/dist-packages/PyYAML-5.3.dist-info/METADATA":          "Metadata-Version: 2.1\nName: PyYAML\nVersion: 5.3\n\nDescription",
+		"usr/local/bundle/specifications/nokogiri-1.10.9-x86_64-linux.gemspec": "",
+	}))))
+
+	require.NoError(t, files.addLayer(bytes.NewReader(newTestLayer(t, false, map[string]string{
+		"app/node_modules/.wh.left-pad": "",
+	}))))
+
+	assert.Equal(t, []Package{
+		{Ecosystem: "Debian:10", Name: "bash", Version: "5.0-4"},
+		{Ecosystem: "Debian:10", Name: "libssl1.1", Version: "1.1.1d-0+deb10u2", Source: "openssl"},
+		{Ecosystem: "PyPI", Name: "PyYAML", Version: "5.3"},
+		{Ecosystem: "RubyGems", Name: "nokogiri", Version: "1.10.9"},
+		{Ecosystem: "npm", Name: "@babel/core", Version: "7.9.0"},
+		{Ecosystem: "npm", Name: "lodash", Version: "4.17.15"},
+	}, files.inventory().Packages)
+
+	require.NoError(t, files.addLayer(bytes.NewReader(newTestLayer(t, false, map[string]string{
+		"app/.wh..wh..opq": "",
+	}))))
+
+	assert.Len(t, files.inventory().Packages, 4)
+}
+
+func TestAlpineInventory(t *testing.T) {
+	files := make(imageFiles)
+
+	require.NoError(t, files.addLayer(bytes.NewReader(newTestLayer(t, true, map[string]string{
+		"etc/os-release":       "ID=alpine\nVERSION_ID=3.11.5\n",
+		"lib/apk/db/installed": "C:Q1\nP:libssl1.1\nV:1.1.1d-r3\no:openssl\n\nP:musl\nV:1.1.24-r2\n",
+	}))))
+
+	assert.Equal(t, []Package{
+		{Ecosystem: "Alpine:v3.11", Name: "libssl1.1", Version: "1.1.1d-r3", Source: "openssl"},
+		{Ecosystem: "Alpine:v3.11", Name: "musl", Version: "1.1.24-r2"},
+	}, files.inventory().Packages)
+}
+
+// newTestJar returns a Java archive with the passed files.
+func newTestJar(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var jar bytes.Buffer
+	archive := zip.NewWriter(&jar)
+	for name, content := range files {
+		writer, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = writer.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	return jar.Bytes()
+}
+
+// newTestGoBinary returns a file with the build information that the Go
+// linker embeds in binaries, with the passed version and module information.
+func newTestGoBinary(version, modInfo string) []byte {
+	const sentinel = "0123456789abcdef"
+
+	data := []byte("\x7fELF")
+	data = append(data, goBuildInfoMagic...)
+	data = append(data, 8, 0x2)
+	data = append(data, make([]byte, 32-len(goBuildInfoMagic)-2)...)
+	data = appendGoString(data, version)
+	data = appendGoString(data, sentinel+modInfo+sentinel)
+	return append(data, "\x00\x00"...)
+}
+
+// appendGoString appends the passed string to the passed data, prefixed with
+// its length as a varint.
+func appendGoString(data []byte, s string) []byte {
+	length := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(length, uint64(len(s)))
+	return append(append(data, length[:n]...), s...)
+}
+
+func TestGoAndMavenInventory(t *testing.T) {
+	log4j := newTestJar(t, map[string][]byte{
+		"META-INF/maven/org.apache.logging.log4j/log4j-core/pom.properties": []byte("#Created by Apache Maven\ngroupId=org.apache.logging.log4j\nartifactId=log4j-core\nversion=2.14.1\n"),
+	})
+	app := newTestJar(t, map[string][]byte{
+		"META-INF/maven/com.example/app/pom.properties": []byte("version=1.0.0\ngroupId=com.example\nartifactId=app\n"),
+		"BOOT-INF/lib/log4j-core-2.14.1.jar":             log4j,
+	})
+
+	goBinary := newTestGoBinary("go1.21.0", "path\tgithub.com/example/app\nmod\tgithub.com/example/app\t(devel)\t\ndep\tgolang.org/x/text\tv0.3.5\th1:abc=\ndep\tgithub.com/old/module\tv1.0.0\n=>\tgithub.com/new/module\tv1.2.0\th1:def=\n")
+
+	var layer bytes.Buffer
+	archive := tar.NewWriter(&layer)
+	for _, file := range []struct {
+		name    string
+		mode    int64
+		content []byte
+	}{
+		{"app/app.jar", 0644, app},
+		{"usr/local/bin/app", 0755, goBinary},
+		{"usr/local/bin/script", 0755, []byte("#!/bin/sh\n")},
+		{"usr/local/share/data", 0644, goBinary},
+	} {
+		require.NoError(t, archive.WriteHeader(&tar.Header{
+			Name:     file.name,
+			Mode:     file.mode,
+			Size:     int64(len(file.content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := archive.Write(file.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	files := make(imageFiles)
+	require.NoError(t, files.addLayer(&layer))
+
+	assert.Equal(t, []Package{
+		{Ecosystem: "Go", Name: "github.com/new/module", Version: "1.2.0"},
+		{Ecosystem: "Go", Name: "golang.org/x/text", Version: "0.3.5"},
+		{Ecosystem: "Go", Name: "stdlib", Version: "1.21.0"},
+		{Ecosystem: "Maven", Name: "com.example:app", Version: "1.0.0"},
+		{Ecosystem: "Maven", Name: "org.apache.logging.log4j:log4j-core", Version: "2.14.1"},
+	}, files.inventory().Packages)
+}
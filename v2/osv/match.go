@@ -0,0 +1,108 @@
+package osv
+
+import (
+	"sort"
+)
+
+// eventVersion returns the version of the passed event.
+func eventVersion(event Event) string {
+	switch {
+	case "" != event.Introduced:
+		return event.Introduced
+	case "" != event.Fixed:
+		return event.Fixed
+	case "" != event.LastAffected:
+		return event.LastAffected
+	}
+	return event.Limit
+}
+
+// sortEvents returns a copy of the passed events, sorted by their versions.
+// An "introduced" event of "0" sorts before all other events.
+func sortEvents(events []Event, compare compareFunc) []Event {
+	sorted := append([]Event{}, events...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if "0" == sorted[j].Introduced {
+			return false
+		}
+		if "0" == sorted[i].Introduced {
+			return true
+		}
+		return 0 > compare(eventVersion(sorted[i]), eventVersion(sorted[j]))
+	})
+
+	return sorted
+}
+
+// affects returns true if the passed version is within the Range. If it is,
+// the version that fixes it is returned as well, or an empty string if there
+// is no fix.
+func (r Range) affects(version string, compare compareFunc) (bool, string) {
+	affected := false
+
+	for _, event := range sortEvents(r.Events, compare) {
+		switch {
+		case "" != event.Introduced:
+			if "0" == event.Introduced || 0 <= compare(version, event.Introduced) {
+				affected = true
+			}
+		case "" != event.Fixed:
+			if 0 <= compare(version, event.Fixed) {
+				affected = false
+			} else if affected {
+				return true, event.Fixed
+			}
+		case "" != event.LastAffected:
+			if 0 < compare(version, event.LastAffected) {
+				affected = false
+			}
+		case "" != event.Limit:
+			if 0 <= compare(version, event.Limit) {
+				affected = false
+			}
+		}
+	}
+
+	return affected, ""
+}
+
+// affects returns true if the passed version of the package is affected. If
+// it is, the version that fixes it is returned as well, or an empty string if
+// there is no fix. Ranges of git commits are ignored, as installed packages
+// are identified by their versions.
+func (affected Affected) affects(version string) (bool, string) {
+	compare := comparatorFor(affected.Package.Ecosystem)
+
+	isAffected := false
+	fixedBy := ""
+
+	for _, r := range affected.Ranges {
+		if "ECOSYSTEM" != r.Type && "SEMVER" != r.Type {
+			continue
+		}
+
+		rangeCompare := compare
+		if "SEMVER" == r.Type {
+			rangeCompare = compareGeneric
+		}
+
+		if ok, fixed := r.affects(version, rangeCompare); ok {
+			isAffected = true
+			if "" != fixed {
+				fixedBy = fixed
+			}
+		}
+	}
+
+	if !isAffected {
+		for _, affectedVersion := range affected.Versions {
+			if 0 == compare(version, affectedVersion) {
+				isAffected = true
+				break
+			}
+		}
+	}
+
+	return isAffected, fixedBy
+}
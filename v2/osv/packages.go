@@ -0,0 +1,410 @@
+package osv
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+)
+
+// goBuildInfoMagic starts the build information that the Go linker embeds in
+// binaries.
+var goBuildInfoMagic = []byte("\xff Go buildinf:")
+
+// maxJavaArchiveDepth is the number of levels of nested Java archives that
+// are searched for Maven packages, such as the libraries in a Spring Boot jar
+// or a war.
+const maxJavaArchiveDepth = 1
+
+// distributions maps the IDs in os-release files to OSV ecosystems.
+var distributions = map[string]string{
+	"alpine": "Alpine",
+	"debian": "Debian",
+	"ubuntu": "Ubuntu",
+}
+
+// parseFields parses "Key: value" fields from the passed data into
+// paragraphs, which are separated by blank lines. Continuation lines, which
+// start with whitespace, are ignored.
+func parseFields(data []byte, separator string) []map[string]string {
+	paragraphs := []map[string]string{}
+	paragraph := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if "" == strings.TrimSpace(line) {
+			if 0 < len(paragraph) {
+				paragraphs = append(paragraphs, paragraph)
+				paragraph = map[string]string{}
+			}
+			continue
+		}
+
+		if ' ' == line[0] || '\t' == line[0] {
+			continue
+		}
+
+		if parts := strings.SplitN(line, separator, 2); 2 == len(parts) {
+			paragraph[parts[0]] = strings.TrimSpace(parts[1])
+		}
+	}
+
+	if 0 < len(paragraph) {
+		paragraphs = append(paragraphs, paragraph)
+	}
+
+	return paragraphs
+}
+
+// ecosystem returns the OSV ecosystem of the distribution described by the
+// image's os-release file, such as "Debian:10" or "Alpine:v3.12". If the
+// distribution is unknown, the passed fallback is returned without a release.
+func (files imageFiles) ecosystem(fallback string) string {
+	data, ok := files["etc/os-release"]
+	if !ok {
+		data, ok = files["usr/lib/os-release"]
+	}
+	if !ok {
+		return fallback
+	}
+
+	release := map[string]string{}
+	for _, paragraph := range parseFields(data, "=") {
+		for key, value := range paragraph {
+			release[key] = strings.Trim(value, `"'`)
+		}
+	}
+
+	ecosystem, ok := distributions[release["ID"]]
+	if !ok {
+		return fallback
+	}
+
+	version := release["VERSION_ID"]
+	if "Alpine" == ecosystem && "" != version {
+		parts := strings.SplitN(version, ".", 3)
+		if 2 <= len(parts) {
+			version = "v" + parts[0] + "." + parts[1]
+		}
+	}
+
+	if "" == version {
+		return ecosystem
+	}
+
+	return ecosystem + ":" + version
+}
+
+// dpkgPackages returns the Debian packages in the passed dpkg status file.
+func dpkgPackages(data []byte, ecosystem string) []Package {
+	packages := []Package{}
+
+	for _, fields := range parseFields(data, ":") {
+		if status, ok := fields["Status"]; ok && !strings.HasSuffix(status, " installed") {
+			continue
+		}
+
+		pkg := Package{
+			Ecosystem: ecosystem,
+			Name:      fields["Package"],
+			Version:   fields["Version"],
+		}
+
+		if source := strings.Fields(fields["Source"]); 0 < len(source) {
+			pkg.Source = source[0]
+			if 1 < len(source) {
+				pkg.SourceVersion = strings.Trim(source[1], "()")
+			}
+		}
+
+		if "" != pkg.Name && "" != pkg.Version {
+			packages = append(packages, pkg)
+		}
+	}
+
+	return packages
+}
+
+// apkPackages returns the Alpine packages in the passed apk database.
+func apkPackages(data []byte, ecosystem string) []Package {
+	packages := []Package{}
+
+	for _, fields := range parseFields(data, ":") {
+		pkg := Package{
+			Ecosystem: ecosystem,
+			Name:      fields["P"],
+			Version:   fields["V"],
+			Source:    fields["o"],
+		}
+
+		if "" != pkg.Name && "" != pkg.Version {
+			packages = append(packages, pkg)
+		}
+	}
+
+	return packages
+}
+
+// npmPackage returns the npm package described by the passed package.json.
+func npmPackage(data []byte) (Package, bool) {
+	var manifest struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	if err := json.Unmarshal(data, &manifest); nil != err {
+		return Package{}, false
+	}
+
+	pkg := Package{Ecosystem: "npm", Name: manifest.Name, Version: manifest.Version}
+	return pkg, "" != pkg.Name && "" != pkg.Version
+}
+
+// pythonPackage returns the Python package described by the passed METADATA
+// or PKG-INFO file.
+func pythonPackage(data []byte) (Package, bool) {
+	paragraphs := parseFields(data, ":")
+	if 0 == len(paragraphs) {
+		return Package{}, false
+	}
+
+	pkg := Package{Ecosystem: "PyPI", Name: paragraphs[0]["Name"], Version: paragraphs[0]["Version"]}
+	return pkg, "" != pkg.Name && "" != pkg.Version
+}
+
+// gemPackage returns the Ruby gem described by the gemspec at the passed
+// path, which is named after the gem, its version, and optionally its
+// platform, such as "rails-6.0.3.gemspec" or
+// "nokogiri-1.10.9-x86_64-linux.gemspec".
+func gemPackage(filePath string) (Package, bool) {
+	name := strings.TrimSuffix(path.Base(filePath), ".gemspec")
+
+	for i := 1; i < len(name)-1; i++ {
+		if '-' != name[i] || !isDigit(name[i+1]) {
+			continue
+		}
+
+		version := name[i+1:]
+		if j := strings.Index(version, "-"); 0 <= j {
+			version = version[:j]
+		}
+
+		return Package{Ecosystem: "RubyGems", Name: name[:i], Version: version}, true
+	}
+
+	return Package{}, false
+}
+
+// readGoString reads a string which is prefixed with its length, as a
+// varint, from the passed data, and returns it along with the rest of the
+// data.
+func readGoString(data []byte) ([]byte, []byte, bool) {
+	length, n := binary.Uvarint(data)
+	if 0 >= n || uint64(len(data)-n) < length {
+		return nil, nil, false
+	}
+
+	end := n + int(length)
+	return data[n:end], data[end:], true
+}
+
+// goModInfo returns the Go version and module information embedded in the
+// passed Go binary, as a "go" line with the version followed by the module
+// lines, and false if it is not a Go binary. Only binaries built with Go 1.18
+// or later are recognized, as earlier versions store pointers to the
+// information rather than the information itself.
+func goModInfo(data []byte) ([]byte, bool) {
+	for offset := 0; offset < len(data); {
+		i := bytes.Index(data[offset:], goBuildInfoMagic)
+		if 0 > i {
+			return nil, false
+		}
+
+		header := data[offset+i:]
+		offset += i + len(goBuildInfoMagic)
+
+		// The header is 32 bytes, and its 16th byte holds flags, of which
+		// 0x2 means that the strings follow it.
+		if 32 > len(header) || 0 == header[15]&0x2 {
+			continue
+		}
+
+		version, rest, ok := readGoString(header[32:])
+		if !ok || !bytes.HasPrefix(version, []byte("go")) {
+			continue
+		}
+
+		modInfo, _, ok := readGoString(rest)
+		if !ok {
+			continue
+		}
+
+		// The module information is wrapped in 16 byte sentinels.
+		if 33 <= len(modInfo) && '\n' == modInfo[len(modInfo)-17] {
+			modInfo = modInfo[16 : len(modInfo)-16]
+		}
+
+		info := append([]byte("go\t"), version...)
+		info = append(info, '\n')
+		return append(info, modInfo...), true
+	}
+
+	return nil, false
+}
+
+// goPackages returns the Go standard library and the Go modules listed in the
+// passed module information, which was returned by goModInfo. Modules which
+// were replaced are reported as their replacements.
+func goPackages(info []byte) []Package {
+	packages := []Package{}
+
+	for _, line := range strings.Split(string(info), "\n") {
+		fields := strings.Split(line, "\t")
+		if 2 > len(fields) {
+			continue
+		}
+
+		switch fields[0] {
+		case "go":
+			version := strings.Fields(strings.TrimPrefix(fields[1], "go"))
+			if 0 < len(version) {
+				packages = append(packages, Package{Ecosystem: "Go", Name: "stdlib", Version: version[0]})
+			}
+		case "mod", "dep":
+			if 3 <= len(fields) && "(devel)" != fields[2] {
+				packages = append(packages, Package{Ecosystem: "Go", Name: fields[1], Version: strings.TrimPrefix(fields[2], "v")})
+			}
+		case "=>":
+			if 3 <= len(fields) && 0 < len(packages) {
+				packages[len(packages)-1] = Package{Ecosystem: "Go", Name: fields[1], Version: strings.TrimPrefix(fields[2], "v")}
+			}
+		}
+	}
+
+	return packages
+}
+
+// isJavaArchive returns true if the file with the passed name is a Java
+// archive, which may contain Maven packages.
+func isJavaArchive(name string) bool {
+	return strings.HasSuffix(name, ".jar") || strings.HasSuffix(name, ".war")
+}
+
+// readZipFile reads the passed file from a zip archive, unless it is larger
+// than maxFileSize.
+func readZipFile(file *zip.File) ([]byte, bool) {
+	if maxFileSize < file.UncompressedSize64 {
+		return nil, false
+	}
+
+	reader, err := file.Open()
+	if nil != err {
+		return nil, false
+	}
+	defer reader.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(reader, maxFileSize))
+	return data, nil == err
+}
+
+// mavenPackage returns the Maven package described by the passed
+// pom.properties file. Its name is the package's group and artifact IDs,
+// such as "org.apache.logging.log4j:log4j-core".
+func mavenPackage(data []byte) (Package, bool) {
+	properties := map[string]string{}
+	for _, paragraph := range parseFields(data, "=") {
+		for key, value := range paragraph {
+			properties[strings.TrimSpace(key)] = value
+		}
+	}
+
+	groupID, artifactID := properties["groupId"], properties["artifactId"]
+	pkg := Package{Ecosystem: "Maven", Name: groupID + ":" + artifactID, Version: properties["version"]}
+	return pkg, "" != groupID && "" != artifactID && "" != pkg.Version
+}
+
+// mavenPackages returns the Maven packages described by the pom.properties
+// files in the passed Java archive, and in the archives nested in it up to
+// the passed depth.
+func mavenPackages(data []byte, depth int) []Package {
+	packages := []Package{}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if nil != err {
+		return packages
+	}
+
+	for _, file := range archive.File {
+		switch {
+		case strings.HasPrefix(file.Name, "META-INF/maven/") && strings.HasSuffix(file.Name, "/pom.properties"):
+			if properties, ok := readZipFile(file); ok {
+				if pkg, ok := mavenPackage(properties); ok {
+					packages = append(packages, pkg)
+				}
+			}
+		case 0 < depth && isJavaArchive(file.Name):
+			if nested, ok := readZipFile(file); ok {
+				packages = append(packages, mavenPackages(nested, depth-1)...)
+			}
+		}
+	}
+
+	return packages
+}
+
+// inventory returns the packages installed in the image, sorted by their
+// ecosystems, names, and versions.
+func (files imageFiles) inventory() Inventory {
+	inventory := Inventory{Packages: []Package{}}
+
+	for filePath, data := range files {
+		var pkg Package
+		ok := false
+
+		switch name := path.Base(filePath); {
+		case !isPackageFile(filePath):
+			// Other files are Go binaries, of which only the module
+			// information was kept.
+			inventory.Packages = append(inventory.Packages, goPackages(data)...)
+		case "var/lib/dpkg/status" == filePath, strings.HasPrefix(filePath, "var/lib/dpkg/status.d/"):
+			inventory.Packages = append(inventory.Packages, dpkgPackages(data, files.ecosystem("Debian"))...)
+		case "lib/apk/db/installed" == filePath:
+			inventory.Packages = append(inventory.Packages, apkPackages(data, files.ecosystem("Alpine"))...)
+		case "package.json" == name:
+			pkg, ok = npmPackage(data)
+		case "METADATA" == name, "PKG-INFO" == name:
+			pkg, ok = pythonPackage(data)
+		case strings.HasSuffix(name, ".gemspec"):
+			pkg, ok = gemPackage(filePath)
+		case isJavaArchive(name):
+			inventory.Packages = append(inventory.Packages, mavenPackages(data, maxJavaArchiveDepth)...)
+		}
+
+		if ok {
+			inventory.Packages = append(inventory.Packages, pkg)
+		}
+	}
+
+	sort.Slice(inventory.Packages, func(i, j int) bool {
+		a, b := inventory.Packages[i], inventory.Packages[j]
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+
+	return inventory
+}
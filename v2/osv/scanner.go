@@ -0,0 +1,71 @@
+package osv
+
+import (
+	"context"
+	"time"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// Scanner implements voucher.VulnerabilityScanner, and matches the packages
+// installed in images against an OSV Database.
+type Scanner struct {
+	database   *Database
+	inventory  InventorySource
+	failOn     voucher.Severity
+	failOnCVSS float32
+	allowlist  voucher.Allowlist
+}
+
+// FailOn sets severity level that a vulnerability must match or exheed to
+// prompt a failure.
+func (scanner *Scanner) FailOn(severity voucher.Severity) {
+	scanner.failOn = severity
+}
+
+// FailOnCVSS sets the CVSS score that a vulnerability must match or exceed to
+// prompt a failure.
+func (scanner *Scanner) FailOnCVSS(score float32) {
+	scanner.failOnCVSS = score
+}
+
+// SetAllowlist sets the Allowlist to suppress vulnerabilities with.
+func (scanner *Scanner) SetAllowlist(allowlist voucher.Allowlist) {
+	scanner.allowlist = allowlist
+}
+
+// Scan gets the package inventory of the passed image, and returns the
+// vulnerabilities in the Database which affect its packages. If there is no
+// inventory, it returns a NoMetadataError.
+func (scanner *Scanner) Scan(ctx context.Context, i voucher.ImageData) ([]voucher.Vulnerability, error) {
+	vulns := make([]voucher.Vulnerability, 0)
+
+	inventory, err := scanner.inventory.GetInventory(ctx, i)
+	if ErrNoInventory == err {
+		return vulns, &voucher.NoMetadataError{Type: voucher.VulnerabilityType, Err: err}
+	}
+	if nil != err {
+		return vulns, err
+	}
+
+	for _, pkg := range inventory.Packages {
+		for _, match := range scanner.database.Match(pkg) {
+			vuln := toVoucherVulnerability(pkg, match)
+			if voucher.ShouldIncludeVulnerabilityWithCVSS(vuln, scanner.failOn, scanner.failOnCVSS) {
+				vulns = append(vulns, vuln)
+			}
+		}
+	}
+
+	return scanner.allowlist.Suppress(vulns, i, time.Now()), nil
+}
+
+// NewScanner creates a new Scanner, which matches the packages listed by the
+// passed InventorySource against the passed Database.
+func NewScanner(database *Database, inventory InventorySource) *Scanner {
+	scanner := new(Scanner)
+	scanner.database = database
+	scanner.inventory = inventory
+
+	return scanner
+}
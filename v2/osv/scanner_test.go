@@ -0,0 +1,168 @@
+package osv
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+const testImage = "gcr.io/path/to/image@sha256:97db2bc359ccc94d3b2d6f5daa4173e9e91c513b0dcd961408adbb95ec5e5ce5"
+
+const testInventoryName = "sha256-97db2bc359ccc94d3b2d6f5daa4173e9e91c513b0dcd961408adbb95ec5e5ce5.json"
+
+const testInventory = `{
+	"packages": [
+		{"ecosystem": "Debian:10", "name": "libssl1.1", "version": "1.1.1d-0+deb10u2", "source": "openssl"},
+		{"ecosystem": "Debian:11", "name": "bash", "version": "5.0-4"},
+		{"ecosystem": "PyPI", "name": "pyyaml", "version": "5.3"},
+		{"ecosystem": "npm", "name": "lodash", "version": "4.17.21"}
+	]
+}`
+
+var testEntries = map[string]string{
+	"debian/DSA-4661-1.json": `{
+		"id": "DSA-4661-1",
+		"aliases": ["CVE-2020-1967"],
+		"summary": "openssl: Segmentation fault in SSL_check_chain",
+		"affected": [{
+			"package": {"ecosystem": "Debian:10", "name": "openssl"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "1.1.1d-0+deb10u3"}]}]
+		}],
+		"references": [{"type": "ADVISORY", "url": "https://www.debian.org/security/2020/dsa-4661"}]
+	}`,
+	"debian/DLA-0000-1.json": `{
+		"id": "DLA-0000-1",
+		"aliases": ["CVE-2019-18276"],
+		"affected": [{
+			"package": {"ecosystem": "Debian:10", "name": "bash"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "5.0-5"}]}]
+		}]
+	}`,
+	"pypi/GHSA-8q59-q68h-6hv4.json": `{
+		"id": "GHSA-8q59-q68h-6hv4",
+		"aliases": ["CVE-2020-1747"],
+		"summary": "Improper Input Validation in PyYAML",
+		"severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}],
+		"affected": [{
+			"package": {"ecosystem": "PyPI", "name": "PyYAML"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "5.3.1"}]}]
+		}],
+		"database_specific": {"severity": "CRITICAL"}
+	}`,
+	"npm/GHSA-35jh-r3h4-6jhm.json": `{
+		"id": "GHSA-35jh-r3h4-6jhm",
+		"affected": [{
+			"package": {"ecosystem": "npm", "name": "lodash"},
+			"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.21"}]}]
+		}],
+		"database_specific": {"severity": "HIGH"}
+	}`,
+	"npm/GHSA-withdrawn.json": `{
+		"id": "GHSA-withdrawn",
+		"withdrawn": "2021-01-01T00:00:00Z",
+		"affected": [{
+			"package": {"ecosystem": "npm", "name": "lodash"},
+			"versions": ["4.17.21"]
+		}]
+	}`,
+	"README.md": "not an entry",
+}
+
+func newTestImageData(t *testing.T) voucher.ImageData {
+	t.Helper()
+
+	i, err := voucher.NewImageData(testImage)
+	require.NoError(t, err)
+	return i
+}
+
+func newTestDatabase(t *testing.T) *Database {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "voucher-osv")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for name, content := range testEntries {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	}
+
+	db, err := LoadDatabase(dir)
+	require.NoError(t, err)
+	return db
+}
+
+func TestScanner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "voucher-osv-inventory")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	scanner := NewScanner(newTestDatabase(t), NewDirectoryInventory(dir))
+	scanner.FailOn(voucher.LowSeverity)
+
+	_, err = scanner.Scan(context.Background(), newTestImageData(t))
+	assert.True(t, voucher.IsNoMetadataError(err), "missing inventory was not a NoMetadataError: %s", err)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, testInventoryName), []byte(testInventory), 0600))
+
+	vulns, err := scanner.Scan(context.Background(), newTestImageData(t))
+	require.NoError(t, err)
+	assert.Equal(t, []voucher.Vulnerability{
+		{
+			Name:           "CVE-2020-1967",
+			Description:    "openssl: Segmentation fault in SSL_check_chain",
+			Severity:       voucher.UnknownSeverity,
+			FixedBy:        "1.1.1d-0+deb10u3",
+			PackageName:    "libssl1.1",
+			PackageVersion: "1.1.1d-0+deb10u2",
+			URLs:           []string{"https://www.debian.org/security/2020/dsa-4661"},
+		},
+		{
+			Name:           "CVE-2020-1747",
+			Description:    "Improper Input Validation in PyYAML",
+			Severity:       voucher.CriticalSeverity,
+			FixedBy:        "5.3.1",
+			CVSSScore:      9.8,
+			CVSSVector:     "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+			PackageName:    "pyyaml",
+			PackageVersion: "5.3",
+		},
+	}, vulns)
+
+	scanner.FailOnCVSS(9)
+	scanner.FailOn(voucher.CriticalSeverity)
+
+	vulns, err = scanner.Scan(context.Background(), newTestImageData(t))
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	assert.Equal(t, "CVE-2020-1747", vulns[0].Name)
+}
+
+func TestLoadDatabaseWithInvalidEntry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "voucher-osv")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "invalid.json"), []byte("{"), 0600))
+
+	_, err = LoadDatabase(dir)
+	assert.Error(t, err)
+}
+
+func TestGetSeverity(t *testing.T) {
+	assert.Equal(t, voucher.NegligibleSeverity, getSeverity("unimportant"))
+	assert.Equal(t, voucher.LowSeverity, getSeverity("LOW"))
+	assert.Equal(t, voucher.MediumSeverity, getSeverity("MODERATE"))
+	assert.Equal(t, voucher.HighSeverity, getSeverity("high"))
+	assert.Equal(t, voucher.CriticalSeverity, getSeverity("CRITICAL"))
+	assert.Equal(t, voucher.UnknownSeverity, getSeverity(""))
+}
@@ -0,0 +1,387 @@
+package osv
+
+import (
+	"strconv"
+	"strings"
+)
+
+// compareFunc compares two versions, returning a negative number if a is
+// older than b, a positive number if a is newer than b, and 0 if they are
+// equal.
+type compareFunc func(a, b string) int
+
+// comparatorFor returns the compareFunc for versions in the passed ecosystem.
+// Ecosystems without their own version scheme are compared with
+// compareGeneric, which handles semantic versions and most language package
+// versions.
+func comparatorFor(ecosystem string) compareFunc {
+	name, _ := splitEcosystem(ecosystem)
+
+	switch name {
+	case "Debian", "Ubuntu":
+		return compareDebian
+	case "Alpine":
+		return compareAlpine
+	}
+
+	return compareGeneric
+}
+
+// isDigit returns true if the passed byte is an ASCII digit.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// isLetter returns true if the passed byte is an ASCII letter.
+func isLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// leadingDigits splits the passed string after its leading digits.
+func leadingDigits(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// compareNumbers compares two strings of digits of any length.
+func compareNumbers(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+// compareDebian compares two Debian package versions, which are made up of an
+// epoch, an upstream version, and a revision, as dpkg does.
+func compareDebian(a, b string) int {
+	aEpoch, aUpstream, aRevision := splitDebianVersion(a)
+	bEpoch, bUpstream, bRevision := splitDebianVersion(b)
+
+	if c := compareNumbers(aEpoch, bEpoch); 0 != c {
+		return c
+	}
+
+	if c := compareDebianPart(aUpstream, bUpstream); 0 != c {
+		return c
+	}
+
+	return compareDebianPart(aRevision, bRevision)
+}
+
+// splitDebianVersion splits a Debian version into its epoch, upstream version,
+// and revision.
+func splitDebianVersion(version string) (string, string, string) {
+	epoch := "0"
+	if i := strings.Index(version, ":"); 0 <= i {
+		epoch, version = version[:i], version[i+1:]
+	}
+
+	revision := ""
+	if i := strings.LastIndex(version, "-"); 0 <= i {
+		version, revision = version[:i], version[i+1:]
+	}
+
+	return epoch, version, revision
+}
+
+// debianOrder returns the sort weight of the first character of the passed
+// string. Letters sort before other characters, and "~" sorts before
+// everything, including the end of the string.
+func debianOrder(s string) int {
+	switch {
+	case "" == s || isDigit(s[0]):
+		return 0
+	case isLetter(s[0]):
+		return int(s[0])
+	case '~' == s[0]:
+		return -1
+	}
+	return int(s[0]) + 256
+}
+
+// compareDebianPart compares an upstream version or revision, by comparing
+// alternating runs of non-digits and digits.
+func compareDebianPart(a, b string) int {
+	for "" != a || "" != b {
+		for ("" != a && !isDigit(a[0])) || ("" != b && !isDigit(b[0])) {
+			aOrder, bOrder := debianOrder(a), debianOrder(b)
+			if aOrder != bOrder {
+				return aOrder - bOrder
+			}
+			a, b = a[1:], b[1:]
+		}
+
+		var aDigits, bDigits string
+		aDigits, a = leadingDigits(a)
+		bDigits, b = leadingDigits(b)
+
+		if c := compareNumbers(aDigits, bDigits); 0 != c {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// alpineSuffixes are the suffixes allowed in Alpine package versions, in
+// order. A version without a suffix sorts between "rc" and "cvs".
+var alpineSuffixes = []string{"alpha", "beta", "pre", "rc", "", "cvs", "svn", "git", "hg", "p"}
+
+// alpineNoSuffix is the position of a version without a suffix in
+// alpineSuffixes.
+const alpineNoSuffix = 4
+
+// alpineVersion is a parsed Alpine package version, such as "1.1.1g_p1-r0".
+type alpineVersion struct {
+	numbers  []string
+	letter   byte
+	suffixes []alpineSuffix
+	revision string
+}
+
+// alpineSuffix is a suffix of an Alpine package version, such as "_rc1".
+type alpineSuffix struct {
+	order  int
+	number string
+}
+
+// parseAlpineVersion parses the passed Alpine package version. It returns
+// false if the version is not valid.
+func parseAlpineVersion(version string) (alpineVersion, bool) {
+	var parsed alpineVersion
+
+	if i := strings.LastIndex(version, "-r"); 0 <= i {
+		parsed.revision = version[i+2:]
+		version = version[:i]
+		if _, err := strconv.ParseUint(parsed.revision, 10, 64); nil != err {
+			return parsed, false
+		}
+	}
+
+	for {
+		var number string
+		number, version = leadingDigits(version)
+		if "" == number {
+			return parsed, false
+		}
+		parsed.numbers = append(parsed.numbers, number)
+
+		if !strings.HasPrefix(version, ".") {
+			break
+		}
+		version = version[1:]
+	}
+
+	if "" != version && isLetter(version[0]) {
+		parsed.letter = version[0]
+		version = version[1:]
+	}
+
+	for "" != version {
+		if '_' != version[0] {
+			return parsed, false
+		}
+		version = version[1:]
+
+		suffix := alpineSuffix{order: -1}
+		for order, name := range alpineSuffixes {
+			if "" != name && strings.HasPrefix(version, name) {
+				suffix.order = order
+				version = version[len(name):]
+				break
+			}
+		}
+		if -1 == suffix.order {
+			return parsed, false
+		}
+
+		suffix.number, version = leadingDigits(version)
+		parsed.suffixes = append(parsed.suffixes, suffix)
+	}
+
+	return parsed, true
+}
+
+// compareAlpine compares two Alpine package versions, as apk does. Versions
+// that are not valid are compared with compareGeneric.
+func compareAlpine(a, b string) int {
+	aVersion, aOK := parseAlpineVersion(a)
+	bVersion, bOK := parseAlpineVersion(b)
+	if !aOK || !bOK {
+		return compareGeneric(a, b)
+	}
+
+	for i := 0; i < len(aVersion.numbers) && i < len(bVersion.numbers); i++ {
+		if c := compareNumbers(aVersion.numbers[i], bVersion.numbers[i]); 0 != c {
+			return c
+		}
+	}
+	if len(aVersion.numbers) != len(bVersion.numbers) {
+		return len(aVersion.numbers) - len(bVersion.numbers)
+	}
+
+	if aVersion.letter != bVersion.letter {
+		return int(aVersion.letter) - int(bVersion.letter)
+	}
+
+	for i := 0; i < len(aVersion.suffixes) || i < len(bVersion.suffixes); i++ {
+		aSuffix := alpineSuffix{order: alpineNoSuffix}
+		if i < len(aVersion.suffixes) {
+			aSuffix = aVersion.suffixes[i]
+		}
+
+		bSuffix := alpineSuffix{order: alpineNoSuffix}
+		if i < len(bVersion.suffixes) {
+			bSuffix = bVersion.suffixes[i]
+		}
+
+		if aSuffix.order != bSuffix.order {
+			return aSuffix.order - bSuffix.order
+		}
+
+		if c := compareNumbers(aSuffix.number, bSuffix.number); 0 != c {
+			return c
+		}
+	}
+
+	return compareNumbers(aVersion.revision, bVersion.revision)
+}
+
+// genericRelease is the rank of a final release in compareGeneric. Qualifiers
+// ranked below it are pre-releases, and those ranked above it are
+// post-releases.
+const genericRelease = 4
+
+// genericQualifiers ranks the qualifiers that appear in versions. Qualifiers
+// that are not listed are treated as pre-releases, and compared by name.
+var genericQualifiers = map[string]int{
+	"dev":       0,
+	"a":         1,
+	"alpha":     1,
+	"b":         2,
+	"beta":      2,
+	"c":         3,
+	"cr":        3,
+	"m":         3,
+	"milestone": 3,
+	"pre":       3,
+	"preview":   3,
+	"rc":        3,
+	"final":     genericRelease,
+	"ga":        genericRelease,
+	"release":   genericRelease,
+	"p":         5,
+	"patch":     5,
+	"post":      5,
+	"rev":       5,
+	"sp":        5,
+}
+
+// versionToken is a run of digits or letters in a version.
+type versionToken struct {
+	value   string
+	numeric bool
+}
+
+// rank returns the rank of a qualifier token.
+func (token versionToken) rank() int {
+	if rank, ok := genericQualifiers[token.value]; ok {
+		return rank
+	}
+	return genericRelease - 1
+}
+
+// tokenizeVersion splits the passed version into runs of digits and letters,
+// ignoring a leading "v" and any build metadata after a "+".
+func tokenizeVersion(version string) []versionToken {
+	version = strings.ToLower(strings.TrimPrefix(version, "v"))
+	if i := strings.Index(version, "+"); 0 <= i {
+		version = version[:i]
+	}
+
+	tokens := []versionToken{}
+	for "" != version {
+		end := 0
+		switch {
+		case isDigit(version[0]):
+			for end < len(version) && isDigit(version[end]) {
+				end++
+			}
+			tokens = append(tokens, versionToken{value: version[:end], numeric: true})
+		case isLetter(version[0]):
+			for end < len(version) && isLetter(version[end]) {
+				end++
+			}
+			tokens = append(tokens, versionToken{value: version[:end]})
+		default:
+			end = 1
+		}
+		version = version[end:]
+	}
+
+	return tokens
+}
+
+// compareTokens compares two version tokens. Numbers sort after qualifiers.
+func compareTokens(a, b versionToken) int {
+	switch {
+	case a.numeric && b.numeric:
+		return compareNumbers(a.value, b.value)
+	case a.numeric:
+		return 1
+	case b.numeric:
+		return -1
+	}
+
+	if a.rank() != b.rank() {
+		return a.rank() - b.rank()
+	}
+	return strings.Compare(a.value, b.value)
+}
+
+// compareToEnd compares the remaining tokens of a version with the end of a
+// shorter version. Trailing zeros and release qualifiers are ignored, so that
+// "1.0" and "1.0.0" are equal, while "1.0-rc1" is older than "1.0".
+func compareToEnd(tokens []versionToken) int {
+	for _, token := range tokens {
+		if token.numeric {
+			if "" != strings.TrimLeft(token.value, "0") {
+				return 1
+			}
+			continue
+		}
+
+		if rank := token.rank(); genericRelease != rank {
+			return rank - genericRelease
+		}
+	}
+
+	return 0
+}
+
+// compareGeneric compares two versions by their runs of digits and letters.
+// This orders semantic versions, including pre-releases, as well as the
+// versions used by most language package managers.
+func compareGeneric(a, b string) int {
+	aTokens, bTokens := tokenizeVersion(a), tokenizeVersion(b)
+
+	for i := 0; i < len(aTokens) || i < len(bTokens); i++ {
+		if i >= len(aTokens) {
+			return -compareToEnd(bTokens[i:])
+		}
+		if i >= len(bTokens) {
+			return compareToEnd(aTokens[i:])
+		}
+		if c := compareTokens(aTokens[i], bTokens[i]); 0 != c {
+			return c
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,116 @@
+package osv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type versionOrder struct {
+	older string
+	newer string
+}
+
+func assertOrdered(t *testing.T, compare compareFunc, orders []versionOrder) {
+	t.Helper()
+
+	for _, order := range orders {
+		assert.True(t, 0 > compare(order.older, order.newer), "%s is not older than %s", order.older, order.newer)
+		assert.True(t, 0 < compare(order.newer, order.older), "%s is not newer than %s", order.newer, order.older)
+		assert.Equal(t, 0, compare(order.older, order.older), "%s is not equal to itself", order.older)
+	}
+}
+
+func TestCompareDebian(t *testing.T) {
+	assertOrdered(t, compareDebian, []versionOrder{
+		{"1.1.1d-0+deb10u2", "1.1.1d-0+deb10u3"},
+		{"1.1.1d-0+deb10u3", "1.1.1g-1"},
+		{"5.0-4", "5.0-10"},
+		{"1.0~rc1-1", "1.0-1"},
+		{"9.9-1", "1:1.0-1"},
+		{"2.30-1", "2.30a-1"},
+	})
+
+	assert.Equal(t, 0, compareDebian("0:1.0-1", "1.0-1"))
+}
+
+func TestCompareAlpine(t *testing.T) {
+	assertOrdered(t, compareAlpine, []versionOrder{
+		{"1.1.1d-r3", "1.1.1g-r0"},
+		{"1.1.1g-r0", "1.1.1g-r1"},
+		{"1.2_rc1-r0", "1.2-r0"},
+		{"1.2-r0", "1.2_p1-r0"},
+		{"1.2.9", "1.2.10"},
+		{"1.2", "1.2.1"},
+	})
+}
+
+func TestCompareGeneric(t *testing.T) {
+	assertOrdered(t, compareGeneric, []versionOrder{
+		{"1.2.3", "1.2.4"},
+		{"1.2.9", "1.2.10"},
+		{"v0.3.2", "v0.4.0"},
+		{"1.0.0-alpha.1", "1.0.0-beta"},
+		{"1.0.0-rc.1", "1.0.0"},
+		{"2.0.0.dev1", "2.0.0a1"},
+		{"2.0.0", "2.0.0.post1"},
+		{"2.0.0.post1", "2.0.1"},
+	})
+
+	assert.Equal(t, 0, compareGeneric("1.0", "1.0.0"))
+	assert.Equal(t, 0, compareGeneric("1.0.0+build.1", "1.0.0"))
+	assert.Equal(t, 0, compareGeneric("5.3.0.RELEASE", "5.3.0"))
+}
+
+func TestRangeAffects(t *testing.T) {
+	r := Range{
+		Type: "ECOSYSTEM",
+		Events: []Event{
+			{Fixed: "1.4.2"},
+			{Introduced: "1.3.0"},
+			{Introduced: "0"},
+			{Fixed: "1.2.5"},
+		},
+	}
+
+	for version, fixedBy := range map[string]string{
+		"1.0.0": "1.2.5",
+		"1.3.1": "1.4.2",
+	} {
+		affected, fixed := r.affects(version, compareGeneric)
+		assert.True(t, affected, "%s was not affected", version)
+		assert.Equal(t, fixedBy, fixed)
+	}
+
+	for _, version := range []string{"1.2.5", "1.2.9", "1.4.2", "2.0.0"} {
+		affected, _ := r.affects(version, compareGeneric)
+		assert.False(t, affected, "%s was affected", version)
+	}
+
+	r = Range{Type: "ECOSYSTEM", Events: []Event{{Introduced: "2.0.0"}, {LastAffected: "2.1.0"}}}
+
+	affected, fixed := r.affects("2.1.0", compareGeneric)
+	assert.True(t, affected)
+	assert.Equal(t, "", fixed)
+
+	affected, _ = r.affects("2.1.1", compareGeneric)
+	assert.False(t, affected)
+}
+
+func TestCVSSV3Score(t *testing.T) {
+	for vector, expected := range map[string]float32{
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H": 9.8,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H": 7.5,
+		"CVSS:3.0/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N": 6.1,
+		"CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:N/I:N/A:N": 0,
+	} {
+		score, ok := cvssV3Score(vector)
+		assert.True(t, ok, "%s was not valid", vector)
+		assert.Equal(t, expected, score, "wrong score for %s", vector)
+	}
+
+	for _, vector := range []string{"", "AV:N/AC:M/Au:N/C:N/I:N/A:P", "CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"} {
+		_, ok := cvssV3Score(vector)
+		assert.False(t, ok, "%s was valid", vector)
+	}
+}
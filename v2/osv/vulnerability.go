@@ -0,0 +1,127 @@
+package osv
+
+import (
+	"strings"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// getSeverity converts a severity name, such as GitHub's "MODERATE", into a
+// Voucher severity.
+func getSeverity(severity string) voucher.Severity {
+	switch strings.ToLower(severity) {
+	case "negligible", "unimportant":
+		return voucher.NegligibleSeverity
+	case "low":
+		return voucher.LowSeverity
+	case "medium", "moderate":
+		return voucher.MediumSeverity
+	case "high", "important":
+		return voucher.HighSeverity
+	case "critical":
+		return voucher.CriticalSeverity
+	}
+	return voucher.UnknownSeverity
+}
+
+// scoreToSeverity converts a CVSS v3 score into a Voucher severity, using the
+// ratings defined by CVSS v3.
+func scoreToSeverity(score float32) voucher.Severity {
+	switch {
+	case 9 <= score:
+		return voucher.CriticalSeverity
+	case 7 <= score:
+		return voucher.HighSeverity
+	case 4 <= score:
+		return voucher.MediumSeverity
+	case 0 < score:
+		return voucher.LowSeverity
+	}
+	return voucher.NegligibleSeverity
+}
+
+// getNamedSeverity returns the severity named in the "severity" field of the
+// passed database or ecosystem specific data, if there is one.
+func getNamedSeverity(specific map[string]interface{}) (voucher.Severity, bool) {
+	name, ok := specific["severity"].(string)
+	if !ok {
+		return voucher.UnknownSeverity, false
+	}
+
+	severity := getSeverity(name)
+	return severity, voucher.UnknownSeverity != severity
+}
+
+// getCVSS returns the CVSS v3 score and vector of the passed Entry, if it has
+// one.
+func getCVSS(entry *Entry) (float32, string) {
+	for _, severity := range entry.Severity {
+		if "CVSS_V3" != severity.Type {
+			continue
+		}
+
+		if score, ok := cvssV3Score(severity.Score); ok {
+			return score, severity.Score
+		}
+	}
+
+	return 0, ""
+}
+
+// getName returns the name of the passed Entry. As other scanners report
+// vulnerabilities by their CVE IDs, the Entry's CVE alias is preferred to its
+// own ID, such as "DSA-4661-1" or "GHSA-...".
+func getName(entry *Entry) string {
+	if strings.HasPrefix(entry.ID, "CVE-") {
+		return entry.ID
+	}
+
+	for _, alias := range entry.Aliases {
+		if strings.HasPrefix(alias, "CVE-") {
+			return alias
+		}
+	}
+
+	return entry.ID
+}
+
+// toVoucherVulnerability converts an OSV Match against the passed Package into
+// a Voucher Vulnerability. The severity is taken from the severity named by
+// the database or ecosystem if there is one, and from the CVSS v3 score
+// otherwise.
+func toVoucherVulnerability(pkg Package, match Match) voucher.Vulnerability {
+	entry := match.Entry
+	score, vector := getCVSS(entry)
+
+	severity, ok := getNamedSeverity(entry.DatabaseSpecific)
+	for i := 0; !ok && i < len(entry.Affected); i++ {
+		severity, ok = getNamedSeverity(entry.Affected[i].EcosystemSpecific)
+	}
+	if !ok && "" != vector {
+		severity = scoreToSeverity(score)
+	}
+
+	description := entry.Summary
+	if "" == description {
+		description = entry.Details
+	}
+
+	var urls []string
+	for _, reference := range entry.References {
+		if "" != reference.URL {
+			urls = append(urls, reference.URL)
+		}
+	}
+
+	return voucher.Vulnerability{
+		Name:           getName(entry),
+		Description:    description,
+		Severity:       severity,
+		FixedBy:        match.FixedBy,
+		CVSSScore:      score,
+		CVSSVector:     vector,
+		PackageName:    pkg.Name,
+		PackageVersion: pkg.Version,
+		URLs:           urls,
+	}
+}
@@ -0,0 +1,66 @@
+package voucher
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PlatformResult is the result of a PlatformCheck for the image for one
+// platform of a multi-platform image.
+type PlatformResult struct {
+	Platform string `json:"platform"`
+	Digest   string `json:"digest"`
+	Success  bool   `json:"success"`
+	Err      string `json:"error,omitempty"`
+}
+
+// PlatformsError is the error returned by a PlatformCheck when the image for
+// at least one platform failed. It has the results for every platform.
+type PlatformsError struct {
+	Code    ErrorCode
+	Results []PlatformResult
+}
+
+// Error returns the error message for the PlatformsError, which lists the
+// platforms that failed.
+func (err *PlatformsError) Error() string {
+	failed := make([]string, 0, len(err.Results))
+	for _, result := range err.Results {
+		if result.Success {
+			continue
+		}
+		if "" == result.Err {
+			failed = append(failed, result.Platform)
+			continue
+		}
+		failed = append(failed, fmt.Sprintf("%s (%s)", result.Platform, result.Err))
+	}
+	return fmt.Sprintf("failed for %d of %d platforms: %s", len(failed), len(err.Results), strings.Join(failed, ", "))
+}
+
+// ErrorCode returns the ErrorCode of the PlatformsError.
+func (err *PlatformsError) ErrorCode() ErrorCode {
+	return err.Code
+}
+
+// ErrorDetails returns the results for each platform, so they can be
+// reported as a CheckResult's ErrDetails.
+func (err *PlatformsError) ErrorDetails() interface{} {
+	return PlatformsDetails{
+		Platforms: err.Results,
+	}
+}
+
+// PlatformsDetails are the details of a PlatformsError.
+type PlatformsDetails struct {
+	Platforms []PlatformResult `json:"platforms"`
+}
+
+// NewPlatformsError creates a new PlatformsError with the passed ErrorCode and
+// results.
+func NewPlatformsError(code ErrorCode, results []PlatformResult) error {
+	return &PlatformsError{
+		Code:    code,
+		Results: results,
+	}
+}
@@ -0,0 +1,9 @@
+package voucher
+
+// PlatformCheck is a Check which checks the image for each platform of a
+// multi-platform image, and which can be limited to some of those platforms.
+// Platforms are in the form "os/architecture[/variant]".
+type PlatformCheck interface {
+	Check
+	SetPlatforms([]string)
+}
@@ -0,0 +1,131 @@
+package voucher
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCheckTimedOut is the error returned when a Check does not complete within
+// the Timeout set in its CheckPolicy.
+var ErrCheckTimedOut = NewCheckError(ErrorCodeTimedOut, "check timed out")
+
+// ErrCheckCancelled is the error reported for a Check that was stopped, or
+// never started, because its Suite was cancelled.
+var ErrCheckCancelled = NewCheckError(ErrorCodeCancelled, "cancelled: check was stopped before it finished")
+
+// CheckPolicy describes how a Suite runs a Check. A zero CheckPolicy runs the
+// Check once, limited only by the context passed to Suite.Run.
+type CheckPolicy struct {
+	Timeout time.Duration // The maximum duration of a single attempt, or 0 for no limit.
+	Retries int           // The number of times to retry a Check that failed with a transient error.
+	Backoff time.Duration // The delay before the first retry. It doubles for every retry after that.
+}
+
+// backoffFor returns the delay before the passed retry, where the first retry
+// is 1.
+func (policy CheckPolicy) backoffFor(retry int) time.Duration {
+	return policy.Backoff << uint(retry-1)
+}
+
+// transientError wraps an error that may not occur on another attempt.
+type transientError struct {
+	err error
+}
+
+// Error returns the error message of the wrapped error.
+func (err *transientError) Error() string {
+	return err.err.Error()
+}
+
+// Temporary returns true, marking this error as transient.
+func (err *transientError) Temporary() bool {
+	return true
+}
+
+// Unwrap returns the wrapped error.
+func (err *transientError) Unwrap() error {
+	return err.err
+}
+
+// NewTransientError wraps the passed error to mark it as transient, so that
+// Suites will retry the Check that returned it.
+func NewTransientError(err error) error {
+	return &transientError{err: err}
+}
+
+// IsTransientError returns true if the passed error, or any error it wraps,
+// reports itself as temporary. This includes errors created with
+// NewTransientError, as well as timeouts returned by the net package.
+func IsTransientError(err error) bool {
+	var temporary interface {
+		Temporary() bool
+	}
+
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
+
+// checkOutcome is the outcome of a single attempt at running a Check.
+type checkOutcome struct {
+	ok  bool
+	err error
+}
+
+// attemptCheck runs the passed Check once. If timeout is greater than zero,
+// the attempt is abandoned once it has run for that long, and ErrCheckTimedOut
+// is returned. The attempt is also abandoned if the passed context is done.
+// The second return value is true if the attempt timed out.
+func attemptCheck(ctx context.Context, check Check, timeout time.Duration, imageData ImageData) (bool, bool, error) {
+	var attemptCtx context.Context
+	var cancel context.CancelFunc
+
+	if 0 < timeout {
+		attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		attemptCtx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	// The channel is buffered so the goroutine can exit if we stop waiting.
+	outcomes := make(chan checkOutcome, 1)
+	go func() {
+		ok, err := check.Check(attemptCtx, imageData)
+		outcomes <- checkOutcome{ok: ok, err: err}
+	}()
+
+	select {
+	case outcome := <-outcomes:
+		return outcome.ok, false, outcome.err
+	case <-attemptCtx.Done():
+	}
+
+	// Prefer the Check's own outcome if it finished at the same time.
+	select {
+	case outcome := <-outcomes:
+		return outcome.ok, false, outcome.err
+	default:
+	}
+
+	if context.DeadlineExceeded == attemptCtx.Err() {
+		return false, true, ErrCheckTimedOut
+	}
+	return false, false, attemptCtx.Err()
+}
+
+// wait blocks for the passed duration. It returns false if the context was
+// cancelled before the duration passed.
+func wait(ctx context.Context, duration time.Duration) bool {
+	if 0 >= duration {
+		return nil == ctx.Err()
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
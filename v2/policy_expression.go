@@ -0,0 +1,344 @@
+package voucher
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// PolicyExpression is a boolean expression over the results of Checks, which
+// decides whether an image passes a group of Checks. Expressions are made of
+// Check names, combined with AND, OR, parentheses, and "N of [...]", such as:
+//
+//    snakeoil AND (approved OR is_shopify)
+//    2 of [provenance, approved, diy]
+//
+// AND binds more tightly than OR.
+type PolicyExpression interface {
+	// Evaluate returns the value of the expression, given a map of Check names
+	// to whether that Check passed, along with the clause which decided it.
+	Evaluate(passed map[string]bool) (bool, PolicyExpression)
+
+	// Checks returns the sorted names of the Checks used by the expression.
+	Checks() []string
+
+	// String returns the expression as it would be written.
+	String() string
+}
+
+// ParsePolicyExpression parses the passed string as a PolicyExpression.
+func ParsePolicyExpression(expression string) (PolicyExpression, error) {
+	parser := &policyParser{tokens: tokenizePolicy(expression)}
+	if 0 == len(parser.tokens) {
+		return nil, fmt.Errorf("policy expression is empty")
+	}
+
+	parsed, err := parser.parseOr()
+	if nil != err {
+		return nil, fmt.Errorf("invalid policy expression \"%s\": %s", expression, err)
+	}
+
+	if token := parser.peek(); "" != token {
+		return nil, fmt.Errorf("invalid policy expression \"%s\": unexpected \"%s\"", expression, token)
+	}
+
+	return parsed, nil
+}
+
+// checkExpression is a PolicyExpression which is true if the named Check passed.
+type checkExpression string
+
+func (expression checkExpression) Evaluate(passed map[string]bool) (bool, PolicyExpression) {
+	return passed[string(expression)], expression
+}
+
+func (expression checkExpression) Checks() []string {
+	return []string{string(expression)}
+}
+
+func (expression checkExpression) String() string {
+	return string(expression)
+}
+
+// andExpression is a PolicyExpression which is true if all of its operands
+// are true. If it is false, it is decided by the first operand that is false.
+type andExpression []PolicyExpression
+
+func (expression andExpression) Evaluate(passed map[string]bool) (bool, PolicyExpression) {
+	for _, operand := range expression {
+		if ok, clause := operand.Evaluate(passed); !ok {
+			return false, clause
+		}
+	}
+	return true, expression
+}
+
+func (expression andExpression) Checks() []string {
+	return checksOf(expression)
+}
+
+func (expression andExpression) String() string {
+	operands := make([]string, len(expression))
+	for i, operand := range expression {
+		operands[i] = operand.String()
+		if _, ok := operand.(orExpression); ok {
+			operands[i] = "(" + operands[i] + ")"
+		}
+	}
+	return strings.Join(operands, " AND ")
+}
+
+// orExpression is a PolicyExpression which is true if any of its operands are
+// true. If it is true, it is decided by the first operand that is true.
+type orExpression []PolicyExpression
+
+func (expression orExpression) Evaluate(passed map[string]bool) (bool, PolicyExpression) {
+	for _, operand := range expression {
+		if ok, clause := operand.Evaluate(passed); ok {
+			return true, clause
+		}
+	}
+	return false, expression
+}
+
+func (expression orExpression) Checks() []string {
+	return checksOf(expression)
+}
+
+func (expression orExpression) String() string {
+	operands := make([]string, len(expression))
+	for i, operand := range expression {
+		operands[i] = operand.String()
+	}
+	return strings.Join(operands, " OR ")
+}
+
+// thresholdExpression is a PolicyExpression which is true if at least
+// required of its operands are true.
+type thresholdExpression struct {
+	required int
+	operands []PolicyExpression
+}
+
+func (expression thresholdExpression) Evaluate(passed map[string]bool) (bool, PolicyExpression) {
+	count := 0
+	for _, operand := range expression.operands {
+		if ok, _ := operand.Evaluate(passed); ok {
+			count++
+		}
+	}
+	return count >= expression.required, expression
+}
+
+func (expression thresholdExpression) Checks() []string {
+	return checksOf(expression.operands)
+}
+
+func (expression thresholdExpression) String() string {
+	operands := make([]string, len(expression.operands))
+	for i, operand := range expression.operands {
+		operands[i] = operand.String()
+	}
+	return fmt.Sprintf("%d of [%s]", expression.required, strings.Join(operands, ", "))
+}
+
+// checksOf returns the sorted, unique names of the Checks used by the passed
+// expressions.
+func checksOf(expressions []PolicyExpression) []string {
+	unique := make(map[string]bool)
+	for _, expression := range expressions {
+		for _, name := range expression.Checks() {
+			unique[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(unique))
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tokenizePolicy splits a policy expression into words and punctuation.
+func tokenizePolicy(expression string) []string {
+	tokens := []string{}
+	word := ""
+
+	for _, r := range expression {
+		switch {
+		case unicode.IsSpace(r):
+			if "" != word {
+				tokens = append(tokens, word)
+				word = ""
+			}
+		case strings.ContainsRune("()[],", r):
+			if "" != word {
+				tokens = append(tokens, word)
+				word = ""
+			}
+			tokens = append(tokens, string(r))
+		default:
+			word += string(r)
+		}
+	}
+
+	if "" != word {
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// policyParser is a recursive descent parser for PolicyExpressions.
+type policyParser struct {
+	tokens []string
+	pos    int
+}
+
+// peek returns the next token without consuming it, or an empty string if
+// there are no tokens left.
+func (parser *policyParser) peek() string {
+	if parser.pos < len(parser.tokens) {
+		return parser.tokens[parser.pos]
+	}
+	return ""
+}
+
+// next consumes and returns the next token.
+func (parser *policyParser) next() string {
+	token := parser.peek()
+	if "" != token {
+		parser.pos++
+	}
+	return token
+}
+
+// expect consumes the next token, returning an error if it is not the
+// passed token.
+func (parser *policyParser) expect(expected string) error {
+	if token := parser.next(); token != expected {
+		return unexpectedToken(token, "\""+expected+"\"")
+	}
+	return nil
+}
+
+// parseOr parses one or more AND expressions separated by OR.
+func (parser *policyParser) parseOr() (PolicyExpression, error) {
+	operands := orExpression{}
+	for {
+		operand, err := parser.parseAnd()
+		if nil != err {
+			return nil, err
+		}
+		operands = append(operands, operand)
+
+		if !strings.EqualFold("or", parser.peek()) {
+			break
+		}
+		parser.next()
+	}
+
+	if 1 == len(operands) {
+		return operands[0], nil
+	}
+	return operands, nil
+}
+
+// parseAnd parses one or more operands separated by AND.
+func (parser *policyParser) parseAnd() (PolicyExpression, error) {
+	operands := andExpression{}
+	for {
+		operand, err := parser.parseOperand()
+		if nil != err {
+			return nil, err
+		}
+		operands = append(operands, operand)
+
+		if !strings.EqualFold("and", parser.peek()) {
+			break
+		}
+		parser.next()
+	}
+
+	if 1 == len(operands) {
+		return operands[0], nil
+	}
+	return operands, nil
+}
+
+// parseOperand parses a Check name, a parenthesized expression, or an
+// "N of [...]" expression.
+func (parser *policyParser) parseOperand() (PolicyExpression, error) {
+	token := parser.next()
+
+	switch {
+	case "(" == token:
+		expression, err := parser.parseOr()
+		if nil != err {
+			return nil, err
+		}
+		return expression, parser.expect(")")
+	case "" == token || isPolicyKeyword(token) || strings.ContainsAny(token, ")[],"):
+		return nil, unexpectedToken(token, "a check name")
+	}
+
+	if !strings.EqualFold("of", parser.peek()) {
+		return checkExpression(token), nil
+	}
+
+	required, err := strconv.Atoi(token)
+	if nil != err || 0 > required {
+		return nil, fmt.Errorf("\"%s\" is not a valid number of checks", token)
+	}
+	parser.next()
+
+	return parser.parseThreshold(required)
+}
+
+// parseThreshold parses the bracketed list of an "N of [...]" expression.
+func (parser *policyParser) parseThreshold(required int) (PolicyExpression, error) {
+	if err := parser.expect("["); nil != err {
+		return nil, err
+	}
+
+	expression := thresholdExpression{required: required}
+	for {
+		operand, err := parser.parseOr()
+		if nil != err {
+			return nil, err
+		}
+		expression.operands = append(expression.operands, operand)
+
+		if "," != parser.peek() {
+			break
+		}
+		parser.next()
+	}
+
+	if err := parser.expect("]"); nil != err {
+		return nil, err
+	}
+
+	if required > len(expression.operands) {
+		return nil, fmt.Errorf("%s can never pass", expression)
+	}
+
+	return expression, nil
+}
+
+// isPolicyKeyword returns true if the passed token is a keyword, and can't be
+// used as a Check name.
+func isPolicyKeyword(token string) bool {
+	return strings.EqualFold("and", token) || strings.EqualFold("or", token) || strings.EqualFold("of", token)
+}
+
+// unexpectedToken returns an error describing a token that was found where
+// another was expected.
+func unexpectedToken(token, expected string) error {
+	if "" == token {
+		return fmt.Errorf("expected %s, found the end of the expression", expected)
+	}
+	return fmt.Errorf("expected %s, found \"%s\"", expected, token)
+}
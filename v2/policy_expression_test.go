@@ -0,0 +1,94 @@
+package voucher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicyExpression(t *testing.T) {
+	for _, testCase := range []struct {
+		expression string
+		expected   string
+		checks     []string
+	}{
+		{"diy", "diy", []string{"diy"}},
+		{"snakeoil AND (approved OR is_shopify)", "snakeoil AND (approved OR is_shopify)", []string{"approved", "is_shopify", "snakeoil"}},
+		{"snakeoil and approved or is_shopify", "snakeoil AND approved OR is_shopify", []string{"approved", "is_shopify", "snakeoil"}},
+		{"2 of [provenance, approved, diy]", "2 of [provenance, approved, diy]", []string{"approved", "diy", "provenance"}},
+		{"snakeoil AND 1 OF [diy, nobody AND org]", "snakeoil AND 1 of [diy, nobody AND org]", []string{"diy", "nobody", "org", "snakeoil"}},
+		{"((diy))", "diy", []string{"diy"}},
+	} {
+		t.Run(testCase.expression, func(t *testing.T) {
+			policy, err := ParsePolicyExpression(testCase.expression)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, policy.String())
+			assert.Equal(t, testCase.checks, policy.Checks())
+		})
+	}
+}
+
+func TestParseInvalidPolicyExpression(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"diy AND",
+		"AND diy",
+		"(diy OR nobody",
+		"diy nobody",
+		"3 of [diy, nobody]",
+		"two of [diy, nobody]",
+		"2 of diy, nobody",
+		"2 of [diy, nobody",
+	} {
+		_, err := ParsePolicyExpression(expression)
+		assert.Errorf(t, err, "expected \"%s\" to be invalid", expression)
+	}
+}
+
+func TestEvaluatePolicyExpression(t *testing.T) {
+	for _, testCase := range []struct {
+		expression string
+		passed     map[string]bool
+		success    bool
+		decision   string
+	}{
+		{"snakeoil AND (approved OR is_shopify)", map[string]bool{"snakeoil": true, "is_shopify": true}, true, "snakeoil AND (approved OR is_shopify)"},
+		{"snakeoil AND (approved OR is_shopify)", map[string]bool{"approved": true, "is_shopify": true}, false, "snakeoil"},
+		{"snakeoil AND (approved OR is_shopify)", map[string]bool{"snakeoil": true}, false, "approved OR is_shopify"},
+		{"approved OR is_shopify", map[string]bool{"is_shopify": true}, true, "is_shopify"},
+		{"2 of [provenance, approved, diy]", map[string]bool{"provenance": true, "diy": true}, true, "2 of [provenance, approved, diy]"},
+		{"2 of [provenance, approved, diy]", map[string]bool{"provenance": true}, false, "2 of [provenance, approved, diy]"},
+	} {
+		policy, err := ParsePolicyExpression(testCase.expression)
+		require.NoError(t, err)
+
+		success, clause := policy.Evaluate(testCase.passed)
+		assert.Equal(t, testCase.success, success, testCase.expression)
+		assert.Equal(t, testCase.decision, clause.String(), testCase.expression)
+	}
+}
+
+func TestNewPolicyResponse(t *testing.T) {
+	imageData := newTestImageData(t)
+	results := []CheckResult{
+		{Name: "snakeoil", Success: true},
+		{Name: "approved", Success: false},
+		{Name: "is_shopify", Success: true},
+	}
+
+	response := NewResponse(imageData, results)
+	assert.False(t, response.Success)
+	assert.Empty(t, response.Policy)
+
+	policy, err := ParsePolicyExpression("snakeoil AND (approved OR is_shopify)")
+	require.NoError(t, err)
+
+	response = NewPolicyResponse(imageData, results, policy)
+	assert.True(t, response.Success)
+	assert.Equal(t, "snakeoil AND (approved OR is_shopify)", response.Policy)
+	assert.Equal(t, "snakeoil AND (approved OR is_shopify)", response.Decision)
+	assert.Equal(t, results, response.Results)
+
+	assert.Equal(t, NewResponse(imageData, results), NewPolicyResponse(imageData, results, nil))
+}
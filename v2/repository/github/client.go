@@ -87,12 +87,12 @@ func (ghc *client) GetOrganization(ctx context.Context, details repository.Build
 func (ghc *client) GetCommit(ctx context.Context, details repository.BuildDetail) (repository.Commit, error) {
 	commitURI, err := GetCommitURL(&details)
 	if err != nil {
-		return repository.Commit{}, fmt.Errorf("error creating a commit url. Error: %s", err)
+		return repository.Commit{}, fmt.Errorf("error creating a commit url. Error: %w", err)
 	}
 
 	commit, err := newCommitInfoResult(ctx, ghc.ghClient, commitURI)
 	if err != nil {
-		return repository.Commit{}, fmt.Errorf("GetCommitInfo query could not be completed. Error: %s", err)
+		return repository.Commit{}, fmt.Errorf("GetCommitInfo query could not be completed. Error: %w", err)
 	}
 	return commit, nil
 }
@@ -106,7 +106,7 @@ func (ghc *client) GetDefaultBranch(ctx context.Context, details repository.Buil
 
 	defaultBranchResult, err := newDefaultBranchResult(ctx, ghc.ghClient, repo.String())
 	if err != nil {
-		return repository.Branch{}, fmt.Errorf("GetDefaultBranch query could not be completed. Error: %s", err)
+		return repository.Branch{}, fmt.Errorf("GetDefaultBranch query could not be completed. Error: %w", err)
 	}
 	return defaultBranchResult, nil
 }
@@ -120,7 +120,7 @@ func (ghc *client) GetBranch(ctx context.Context, details repository.BuildDetail
 
 	branchResult, err := newBranchResult(ctx, ghc.ghClient, repo.String(), name)
 	if err != nil {
-		return repository.Branch{}, fmt.Errorf("GetBranch query could not be completed. Error: %s", err)
+		return repository.Branch{}, fmt.Errorf("GetBranch query could not be completed. Error: %w", err)
 	}
 	return branchResult, nil
 }
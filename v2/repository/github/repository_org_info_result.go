@@ -22,7 +22,7 @@ func newRepositoryOrgInfoResult(ctx context.Context, ghc ghGraphQLClient, uri st
 
 	queryResult := new(repositoryOrgInfoQuery)
 	if err := ghc.Query(ctx, queryResult, repoInfoVariables); err != nil {
-		return repository.Organization{}, fmt.Errorf("RepositoryInfo query could not be completed. Error: %s", err)
+		return repository.Organization{}, fmt.Errorf("RepositoryInfo query could not be completed. Error: %w", err)
 	}
 	if queryResult.Resource.Repository.Owner.Typename != organizationType {
 		return repository.Organization{}, repository.NewTypeMismatchError(organizationType, queryResult.Resource.Repository.Owner.Typename)
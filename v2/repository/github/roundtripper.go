@@ -1,6 +1,11 @@
 package github
 
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
 
 // roundTripperWrapper allows us to attach default headers to all githubv4 requests
 type roundTripperWrapper struct {
@@ -24,8 +29,27 @@ func addPreviewSchemaHeaders(req *http.Request, previewSchemaHeaders []string) *
 	return req
 }
 
-// RoundTrip implements the http RoundTripper interface
+// isTransientStatus returns true if the passed status code means that the
+// request may succeed if it is made again: a server error, or a rate limit.
+func isTransientStatus(statusCode int) bool {
+	return http.StatusInternalServerError <= statusCode || http.StatusTooManyRequests == statusCode
+}
+
+// RoundTrip implements the http RoundTripper interface. Responses with a
+// server error or rate limit status are returned as transient errors, so
+// that Checks which query GitHub are retried.
 func (rtw *roundTripperWrapper) RoundTrip(req *http.Request) (*http.Response, error) {
 	req = addPreviewSchemaHeaders(req, previewSchemas)
-	return rtw.roundTripper.RoundTrip(req)
+
+	resp, err := rtw.roundTripper.RoundTrip(req)
+	if nil != err {
+		return nil, err
+	}
+
+	if isTransientStatus(resp.StatusCode) {
+		resp.Body.Close()
+		return nil, voucher.NewTransientError(fmt.Errorf("github returned %s", resp.Status))
+	}
+
+	return resp, nil
 }
@@ -1,10 +1,21 @@
 package github
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/metrics"
+	"github.com/grafeas/voucher/v2/repository"
+	vtesting "github.com/grafeas/voucher/v2/testing"
 )
 
 func TestNewRoundTripperWrapper(t *testing.T) {
@@ -50,3 +61,72 @@ func TestAddPreviewSchemaHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestRoundTripperWrapperTransientStatuses(t *testing.T) {
+	statuses := map[int]bool{
+		http.StatusOK:                  false,
+		http.StatusNotFound:            false,
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+	}
+
+	for status, transient := range statuses {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		client := &http.Client{Transport: newRoundTripperWrapper(http.DefaultTransport)}
+		resp, err := client.Get(server.URL)
+		if transient {
+			assert.True(t, voucher.IsTransientError(err), "status %d should be transient", status)
+		} else {
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, status, resp.StatusCode)
+		}
+
+		server.Close()
+	}
+}
+
+// defaultBranchCheck is a voucher.Check which succeeds if the default branch
+// of the voucher repository can be requested.
+type defaultBranchCheck struct {
+	client repository.Client
+}
+
+func (c *defaultBranchCheck) Check(ctx context.Context, _ voucher.ImageData) (bool, error) {
+	_, err := c.client.GetDefaultBranch(ctx, repository.BuildDetail{RepositoryURL: "https://github.com/grafeas/voucher"})
+	return nil == err, err
+}
+
+func TestChecksAreRetriedAfterServerErrors(t *testing.T) {
+	const response = `{"data":{"resource":{"__typename":"Repository","defaultBranchRef":{"name":"main","target":{"__typename":"Commit","history":{"pageInfo":{"endCursor":"","hasNextPage":false},"__typename":"CommitHistoryConnection","nodes":[]}}}}}}`
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if 1 == atomic.AddInt32(&requests, 1) {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, response)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: newRoundTripperWrapper(http.DefaultTransport)}
+	check := &defaultBranchCheck{
+		client: &client{ghClient: githubv4.NewEnterpriseClient(server.URL, httpClient)},
+	}
+
+	suite := voucher.NewSuite()
+	suite.Add("branch", check)
+	suite.SetPolicy("branch", voucher.CheckPolicy{Retries: 1})
+
+	results := suite.Run(context.Background(), &metrics.NoopClient{}, vtesting.NewTestReference(t))
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Success, results[0].Err)
+	assert.Equal(t, 2, results[0].Attempts)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
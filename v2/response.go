@@ -2,11 +2,15 @@ package voucher
 
 import "github.com/docker/distribution/reference"
 
-// Response describes the response from a Check call.
+// Response describes the response from a Check call. If the Checks were
+// judged by a PolicyExpression, Policy is that expression, and Decision is the
+// clause of it which decided whether the image passed.
 type Response struct {
-	Image   string        `json:"image"`
-	Success bool          `json:"success"`
-	Results []CheckResult `json:"results"`
+	Image    string        `json:"image"`
+	Success  bool          `json:"success"`
+	Policy   string        `json:"policy,omitempty"`
+	Decision string        `json:"decision,omitempty"`
+	Results  []CheckResult `json:"results"`
 }
 
 // NewResponse creates a new Response for the passed ImageData,
@@ -25,3 +29,27 @@ func NewResponse(reference reference.Reference, results []CheckResult) (checkRes
 
 	return checkResponse
 }
+
+// NewPolicyResponse creates a new Response for the passed ImageData, with the
+// passed results. Success is decided by evaluating the passed PolicyExpression
+// against the results, rather than requiring every Check to pass. If policy
+// is nil, this is the same as NewResponse.
+func NewPolicyResponse(reference reference.Reference, results []CheckResult, policy PolicyExpression) Response {
+	checkResponse := NewResponse(reference, results)
+	if nil == policy {
+		return checkResponse
+	}
+
+	passed := make(map[string]bool, len(results))
+	for _, result := range results {
+		passed[result.Name] = result.Success
+	}
+
+	success, clause := policy.Evaluate(passed)
+
+	checkResponse.Success = success
+	checkResponse.Policy = policy.String()
+	checkResponse.Decision = clause.String()
+
+	return checkResponse
+}
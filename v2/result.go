@@ -3,12 +3,36 @@ package voucher
 // CheckResult describes the result of a Check. If a check failed, it will have a
 // status of false. If a check succeeded, but its Attestation creation failed,
 // Success will be true, Attested will be false. Err will contain the first error to
-// occur.
+// occur. Attempts is the number of times the Check was run, and TimedOut is true
+// if the last attempt ran out of time. Skipped is true if the Check was not run
+// because one of its dependencies did not pass, and Cancelled is true if the Check
+// was stopped (or never started) because the Suite running it was cancelled.
+// ErrCode and ErrDetails describe Err in a machine-readable way; ErrCode is
+// stable between releases, while the text of Err may change. Cached is true if
+// the Check was not run because the image already had an attestation for it.
+// Waived is true if the Check failed, but passed because of the Waiver. A Check
+// which passed may still have an Err, if it was waived or returned a warning.
 type CheckResult struct {
-	ImageData ImageData   `json:"-"`
-	Name      string      `json:"name"`
-	Err       string      `json:"error,omitempty"`
-	Success   bool        `json:"success"`
-	Attested  bool        `json:"attested"`
-	Details   interface{} `json:"details,omitempty"`
+	ImageData  ImageData   `json:"-"`
+	Name       string      `json:"name"`
+	Err        string      `json:"error,omitempty"`
+	ErrCode    ErrorCode   `json:"error_code,omitempty"`
+	ErrDetails interface{} `json:"error_details,omitempty"`
+	Success    bool        `json:"success"`
+	Attested   bool        `json:"attested"`
+	Details    interface{} `json:"details,omitempty"`
+	Attempts   int         `json:"attempts,omitempty"`
+	TimedOut   bool        `json:"timed_out,omitempty"`
+	Skipped    bool        `json:"skipped,omitempty"`
+	Cancelled  bool        `json:"cancelled,omitempty"`
+	Cached     bool        `json:"cached,omitempty"`
+	Waived     bool        `json:"waived,omitempty"`
+	Waiver     *Waiver     `json:"waiver,omitempty"`
+}
+
+// setError sets Err, ErrCode, and ErrDetails to describe the passed error.
+func (result *CheckResult) setError(err error) {
+	result.Err = err.Error()
+	result.ErrCode = ErrorCodeOf(err)
+	result.ErrDetails = ErrorDetailsOf(err)
 }
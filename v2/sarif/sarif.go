@@ -0,0 +1,251 @@
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+const (
+	toolName           = "voucher"
+	toolInformationURI = "https://github.com/grafeas/voucher"
+)
+
+// NewLog creates a new Log with a single Run for Voucher, which has no
+// Results yet.
+func NewLog() *Log {
+	return &Log{
+		Version: Version,
+		Schema:  Schema,
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Name:           toolName,
+						InformationURI: toolInformationURI,
+						Rules:          []Rule{},
+					},
+				},
+				Results: []Result{},
+			},
+		},
+	}
+}
+
+// AddVulnerabilities adds a Result to the Log for each of the passed
+// vulnerabilities in the passed image, as reported by the Check with the
+// passed name. Each vulnerability gets a Rule, which is shared by every
+// Result for the same CVE. If failing is false, the vulnerabilities did not
+// cause the Check to fail, and their Results are notes.
+func (l *Log) AddVulnerabilities(image, checkName string, vulns []voucher.Vulnerability, failing bool) {
+	run := &l.Runs[0]
+	for _, vuln := range vulns {
+		run.Results = append(run.Results, newResult(run.ruleIndex(vuln), image, checkName, vuln, failing))
+	}
+}
+
+// AddResponse adds the vulnerabilities reported by each of the CheckResults
+// in the passed Response to the Log. Results whose errors have no
+// vulnerabilities in their details are skipped. Vulnerabilities reported by
+// Checks that passed are added as notes.
+func (l *Log) AddResponse(resp voucher.Response) error {
+	for _, result := range resp.Results {
+		details, err := vulnerabilityDetails(result)
+		if nil != err {
+			return fmt.Errorf("failed to read the vulnerabilities reported by %s: %s", result.Name, err)
+		}
+
+		l.AddVulnerabilities(resp.Image, result.Name, details.Vulnerabilities, !result.Success)
+		l.AddVulnerabilities(resp.Image, result.Name, details.Warnings, false)
+	}
+
+	return nil
+}
+
+// vulnerabilityDetails returns the VulnerabilitiesDetails of the passed
+// CheckResult. The details are converted through JSON, as a Response that
+// was decoded by a client has them as a map, rather than as the type that
+// the Check reported them with.
+func vulnerabilityDetails(result voucher.CheckResult) (voucher.VulnerabilitiesDetails, error) {
+	var details voucher.VulnerabilitiesDetails
+
+	switch result.ErrCode {
+	case voucher.ErrorCodeVulnerable, voucher.ErrorCodeExploited:
+	default:
+		return details, nil
+	}
+
+	if nil == result.ErrDetails {
+		return details, nil
+	}
+
+	raw, err := json.Marshal(result.ErrDetails)
+	if nil != err {
+		return details, err
+	}
+
+	err = json.Unmarshal(raw, &details)
+	return details, err
+}
+
+// ruleIndex returns the index of the Rule for the passed vulnerability,
+// adding one if the Run does not have it yet.
+func (run *Run) ruleIndex(vuln voucher.Vulnerability) int {
+	for i, rule := range run.Tool.Driver.Rules {
+		if rule.ID == vuln.Name {
+			return i
+		}
+	}
+
+	run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newRule(vuln))
+	return len(run.Tool.Driver.Rules) - 1
+}
+
+// newRule creates a Rule describing the passed vulnerability.
+func newRule(vuln voucher.Vulnerability) Rule {
+	rule := Rule{
+		ID:               vuln.Name,
+		ShortDescription: &Message{Text: vuln.Name},
+		DefaultConfiguration: &Configuration{
+			Level: severityLevel(vuln.Severity),
+		},
+		Properties: map[string]interface{}{
+			"security-severity": securitySeverity(vuln),
+			"tags":              []string{"security", "vulnerability", vuln.Severity.String()},
+		},
+	}
+
+	help := vuln.Name
+	if "" != vuln.Description {
+		rule.FullDescription = &Message{Text: vuln.Description}
+		help = vuln.Description
+	}
+	if 0 != len(vuln.URLs) {
+		rule.HelpURI = vuln.URLs[0]
+		help += "\n\n" + strings.Join(vuln.URLs, "\n")
+	}
+	rule.Help = &Message{Text: help}
+
+	return rule
+}
+
+// newResult creates a Result for the passed vulnerability in the passed image.
+func newResult(ruleIndex int, image, checkName string, vuln voucher.Vulnerability, failing bool) Result {
+	level := NoteLevel
+	if failing {
+		level = severityLevel(vuln.Severity)
+	}
+
+	result := Result{
+		RuleID:    vuln.Name,
+		RuleIndex: ruleIndex,
+		Level:     level,
+		Message:   Message{Text: resultMessage(vuln)},
+		Locations: []Location{
+			{
+				PhysicalLocation: &PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: image},
+				},
+			},
+		},
+		PartialFingerprints: map[string]string{
+			"vulnerability/v1": strings.Join([]string{checkName, vuln.Name, vuln.PackageName, vuln.PackageVersion}, "/"),
+		},
+		Properties: map[string]interface{}{
+			"check":    checkName,
+			"severity": vuln.Severity.String(),
+			"fixable":  vuln.Fixable(),
+		},
+	}
+
+	if "" != vuln.PackageName {
+		result.Locations[0].LogicalLocations = []LogicalLocation{
+			{
+				Name:               vuln.PackageName,
+				FullyQualifiedName: packageVersion(vuln),
+				Kind:               "package",
+			},
+		}
+		result.Properties["package_name"] = vuln.PackageName
+	}
+	if "" != vuln.PackageVersion {
+		result.Properties["package_version"] = vuln.PackageVersion
+	}
+	if vuln.Fixable() {
+		result.Properties["fixed_by"] = vuln.FixedBy
+	}
+	if 0 < vuln.CVSSScore {
+		result.Properties["cvss_score"] = vuln.CVSSScore
+	}
+	if "" != vuln.VEXStatus {
+		result.Properties["vex_status"] = vuln.VEXStatus
+	}
+
+	if vuln.Suppressed {
+		result.Suppressions = []Suppression{
+			{
+				Kind:          "external",
+				Status:        "accepted",
+				Justification: vuln.SuppressedReason,
+			},
+		}
+	}
+
+	return result
+}
+
+// packageVersion returns the name and version of the package affected by the
+// passed vulnerability.
+func packageVersion(vuln voucher.Vulnerability) string {
+	if "" == vuln.PackageVersion {
+		return vuln.PackageName
+	}
+	return vuln.PackageName + "@" + vuln.PackageVersion
+}
+
+// resultMessage describes the passed vulnerability, and how to fix it.
+func resultMessage(vuln voucher.Vulnerability) string {
+	message := fmt.Sprintf("%s (%s)", vuln.Name, vuln.Severity)
+	if "" != vuln.PackageName {
+		message = fmt.Sprintf("%s in %s", message, strings.TrimSpace(vuln.PackageName+" "+vuln.PackageVersion))
+	}
+
+	if vuln.Fixable() {
+		return fmt.Sprintf("%s, fixed in %s", message, vuln.FixedBy)
+	}
+	return message + ", no fix is available"
+}
+
+// severityLevel returns the Level of a Result for a vulnerability with the
+// passed Severity.
+func severityLevel(severity voucher.Severity) Level {
+	switch severity {
+	case voucher.CriticalSeverity, voucher.HighSeverity:
+		return ErrorLevel
+	case voucher.MediumSeverity, voucher.UnknownSeverity:
+		return WarningLevel
+	}
+	return NoteLevel
+}
+
+// securitySeverity returns the score that GitHub code scanning uses to rank
+// the passed vulnerability. It is the CVSS score if the vulnerability has
+// one, and otherwise a score within the range of its Severity.
+func securitySeverity(vuln voucher.Vulnerability) string {
+	score := vuln.CVSSScore
+	if 0 >= score {
+		switch vuln.Severity {
+		case voucher.CriticalSeverity:
+			score = 9.5
+		case voucher.HighSeverity:
+			score = 8.0
+		case voucher.MediumSeverity, voucher.UnknownSeverity:
+			score = 5.5
+		case voucher.LowSeverity:
+			score = 2.0
+		}
+	}
+	return fmt.Sprintf("%.1f", score)
+}
@@ -0,0 +1,156 @@
+package sarif
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+const testImage = "gcr.io/path/to/image@sha256:97db2bc359ccc94d3b2d6f5daa4173e9e91c513b0dcd961408adbb95ec5e5ce5"
+
+var (
+	fixable = voucher.Vulnerability{
+		Name:           "CVE-2020-1234",
+		Description:    "a bad one",
+		Severity:       voucher.HighSeverity,
+		FixedBy:        "1.1.1d-0+deb10u3",
+		CVSSScore:      7.5,
+		PackageName:    "openssl",
+		PackageVersion: "1.1.1c-1",
+		URLs:           []string{"https://security-tracker.debian.org/tracker/CVE-2020-1234"},
+	}
+	unfixable = voucher.Vulnerability{
+		Name:           "CVE-2020-5678",
+		Severity:       voucher.CriticalSeverity,
+		PackageName:    "zlib",
+		PackageVersion: "1.2.11",
+	}
+	suppressed = voucher.Vulnerability{
+		Name:             "CVE-2020-1234",
+		Severity:         voucher.HighSeverity,
+		PackageName:      "libssl",
+		Suppressed:       true,
+		SuppressedReason: "not reachable",
+	}
+)
+
+func TestAddVulnerabilities(t *testing.T) {
+	log := NewLog()
+	log.AddVulnerabilities(testImage, "snakeoil", []voucher.Vulnerability{fixable, suppressed}, true)
+	log.AddVulnerabilities(testImage, "snakeoil", []voucher.Vulnerability{unfixable}, false)
+
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+
+	assert.Equal(t, "voucher", run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, 2, "there should be one rule per CVE")
+
+	rule := run.Tool.Driver.Rules[0]
+	assert.Equal(t, "CVE-2020-1234", rule.ID)
+	assert.Equal(t, "a bad one", rule.FullDescription.Text)
+	assert.Equal(t, "https://security-tracker.debian.org/tracker/CVE-2020-1234", rule.HelpURI)
+	assert.Equal(t, ErrorLevel, rule.DefaultConfiguration.Level)
+	assert.Equal(t, "7.5", rule.Properties["security-severity"])
+	assert.Equal(t, "9.5", run.Tool.Driver.Rules[1].Properties["security-severity"])
+
+	require.Len(t, run.Results, 3)
+
+	result := run.Results[0]
+	assert.Equal(t, "CVE-2020-1234", result.RuleID)
+	assert.Equal(t, 0, result.RuleIndex)
+	assert.Equal(t, ErrorLevel, result.Level)
+	assert.Equal(t, "CVE-2020-1234 (high) in openssl 1.1.1c-1, fixed in 1.1.1d-0+deb10u3", result.Message.Text)
+	assert.Equal(t, testImage, result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, "openssl@1.1.1c-1", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, map[string]interface{}{
+		"check":           "snakeoil",
+		"severity":        "high",
+		"fixable":         true,
+		"fixed_by":        "1.1.1d-0+deb10u3",
+		"package_name":    "openssl",
+		"package_version": "1.1.1c-1",
+		"cvss_score":      float32(7.5),
+	}, result.Properties)
+	assert.Empty(t, result.Suppressions)
+
+	result = run.Results[1]
+	assert.Equal(t, 0, result.RuleIndex, "the same CVE should share a rule")
+	assert.Equal(t, []Suppression{{Kind: "external", Status: "accepted", Justification: "not reachable"}}, result.Suppressions)
+
+	result = run.Results[2]
+	assert.Equal(t, "CVE-2020-5678", result.RuleID)
+	assert.Equal(t, 1, result.RuleIndex)
+	assert.Equal(t, NoteLevel, result.Level, "warnings should be notes")
+	assert.Equal(t, "CVE-2020-5678 (critical) in zlib 1.2.11, no fix is available", result.Message.Text)
+}
+
+func TestAddResponse(t *testing.T) {
+	resp := voucher.Response{
+		Image: testImage,
+		Results: []voucher.CheckResult{
+			{
+				Name:    "diy",
+				Success: true,
+			},
+			{
+				Name:    "snakeoil",
+				ErrCode: voucher.ErrorCodeVulnerable,
+				ErrDetails: voucher.VulnerabilitiesDetails{
+					Vulnerabilities: []voucher.Vulnerability{fixable},
+				},
+			},
+			{
+				Name:    "nobody",
+				ErrCode: voucher.ErrorCodeUnknown,
+				ErrDetails: map[string]string{
+					"user": "root",
+				},
+			},
+		},
+	}
+
+	// Round trip the response, as the client would receive it.
+	raw, err := json.Marshal(resp)
+	require.NoError(t, err)
+
+	var decoded voucher.Response
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+
+	log := NewLog()
+	require.NoError(t, log.AddResponse(decoded))
+
+	run := log.Runs[0]
+	require.Len(t, run.Results, 1)
+	assert.Equal(t, "CVE-2020-1234", run.Results[0].RuleID)
+	assert.Equal(t, ErrorLevel, run.Results[0].Level)
+	assert.Equal(t, "snakeoil", run.Results[0].Properties["check"])
+
+	raw, err = json.Marshal(log)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"version":"2.1.0"`)
+	assert.Contains(t, string(raw), `"$schema":"https://json.schemastore.org/sarif-2.1.0.json"`)
+}
+
+func TestAddResponseWithPassingCheck(t *testing.T) {
+	log := NewLog()
+	require.NoError(t, log.AddResponse(voucher.Response{
+		Image: testImage,
+		Results: []voucher.CheckResult{
+			{
+				Name:    "snakeoil",
+				Success: true,
+				ErrCode: voucher.ErrorCodeVulnerable,
+				ErrDetails: voucher.VulnerabilitiesDetails{
+					Vulnerabilities: []voucher.Vulnerability{fixable},
+				},
+			},
+		},
+	}))
+
+	require.Len(t, log.Runs[0].Results, 1)
+	assert.Equal(t, NoteLevel, log.Runs[0].Results[0].Level, "vulnerabilities that passed should be notes")
+}
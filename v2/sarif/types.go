@@ -0,0 +1,109 @@
+package sarif
+
+// Version is the version of SARIF that Logs are written in.
+const Version = "2.1.0"
+
+// Schema is the location of the JSON schema for SARIF 2.1.0.
+const Schema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// Log is a SARIF log file, which contains the Runs of one or more tools.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run describes the Results reported by a Tool.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+// Tool describes the tool that reported the Results of a Run.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver describes the tool's primary component, and the Rules that its
+// Results refer to.
+type Driver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule describes a vulnerability, which Results refer to by its ID.
+type Rule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name,omitempty"`
+	ShortDescription     *Message               `json:"shortDescription,omitempty"`
+	FullDescription      *Message               `json:"fullDescription,omitempty"`
+	HelpURI              string                 `json:"helpUri,omitempty"`
+	Help                 *Message               `json:"help,omitempty"`
+	DefaultConfiguration *Configuration         `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Configuration is the default configuration of a Rule.
+type Configuration struct {
+	Level Level `json:"level"`
+}
+
+// Message is a piece of text in a Log.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Level is the level of a Result, which describes how serious it is.
+type Level string
+
+// Levels that Results can have.
+const (
+	ErrorLevel   Level = "error"
+	WarningLevel Level = "warning"
+	NoteLevel    Level = "note"
+	NoneLevel    Level = "none"
+)
+
+// Result is an occurrence of a Rule, such as a vulnerability in one of an
+// image's packages.
+type Result struct {
+	RuleID              string                 `json:"ruleId"`
+	RuleIndex           int                    `json:"ruleIndex"`
+	Level               Level                  `json:"level"`
+	Message             Message                `json:"message"`
+	Locations           []Location             `json:"locations,omitempty"`
+	PartialFingerprints map[string]string      `json:"partialFingerprints,omitempty"`
+	Suppressions        []Suppression          `json:"suppressions,omitempty"`
+	Properties          map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Location is where a Result was found.
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+// PhysicalLocation is the artifact a Result was found in.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+}
+
+// ArtifactLocation identifies an artifact by its URI.
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// LogicalLocation is a named part of an artifact, such as a package.
+type LogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// Suppression describes why a Result should not be acted on.
+type Suppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status,omitempty"`
+	Justification string `json:"justification,omitempty"`
+}
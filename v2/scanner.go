@@ -2,13 +2,16 @@ package voucher
 
 import (
 	"context"
+	"time"
 )
 
 // MetadataScanner implements voucher.VulnerabilityScanner, and connects to Grafeas
 // to obtain vulnerability information.
 type MetadataScanner struct {
-	failOn Severity
-	client MetadataClient
+	failOn     Severity
+	failOnCVSS float32
+	client     MetadataClient
+	allowlist  Allowlist
 }
 
 // FailOn sets severity level that a vulnerability must match or exheed to
@@ -17,6 +20,17 @@ func (s *MetadataScanner) FailOn(severity Severity) {
 	s.failOn = severity
 }
 
+// FailOnCVSS sets the CVSS score that a vulnerability must match or exceed to
+// prompt a failure.
+func (s *MetadataScanner) FailOnCVSS(score float32) {
+	s.failOnCVSS = score
+}
+
+// SetAllowlist sets the Allowlist to suppress vulnerabilities with.
+func (s *MetadataScanner) SetAllowlist(allowlist Allowlist) {
+	s.allowlist = allowlist
+}
+
 // Scan gets the vulnerabilities for an Image.
 func (s *MetadataScanner) Scan(ctx context.Context, i ImageData) ([]Vulnerability, error) {
 	v, err := s.client.GetVulnerabilities(ctx, i)
@@ -24,8 +38,8 @@ func (s *MetadataScanner) Scan(ctx context.Context, i ImageData) ([]Vulnerabilit
 		return []Vulnerability{}, err
 	}
 	vulns := make([]Vulnerability, 0, len(v))
-	for _, item := range v {
-		if ShouldIncludeVulnerability(item, s.failOn) {
+	for _, item := range s.allowlist.Suppress(v, i, time.Now()) {
+		if ShouldIncludeVulnerabilityWithCVSS(item, s.failOn, s.failOnCVSS) {
 			vulns = append(vulns, item)
 		}
 	}
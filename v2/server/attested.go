@@ -0,0 +1,45 @@
+package server
+
+import (
+	"fmt"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/signer"
+)
+
+// reuseAttestations splits the passed check names into the names of the checks
+// which do not have a valid attestation in the passed list yet, and results
+// for the checks that do. An attestation is only valid if the passed verifier
+// confirms that it was signed with the key for its check, and that it signs
+// the passed payload, which identifies the image. Those results are marked as
+// cached, as the checks were not run again.
+func reuseAttestations(imageData voucher.ImageData, attestations []voucher.SignedAttestation, names []string, verifier signer.AttestationVerifier, payload string) ([]string, []voucher.CheckResult) {
+	remaining := make([]string, 0, len(names))
+	results := make([]voucher.CheckResult, 0, len(names))
+
+	for _, name := range names {
+		attested := false
+		for _, attestation := range attestations {
+			if attestation.CheckName != name {
+				continue
+			}
+
+			if err := verifier.Verify(name, payload, attestation.Signature); nil != err {
+				LogWarning(fmt.Sprintf("ignoring attestation for %s on %s", name, imageData), err)
+				continue
+			}
+
+			attested = true
+			result := voucher.SignedAttestationToResult(attestation)
+			result.ImageData = imageData
+			result.Cached = true
+			results = append(results, result)
+			break
+		}
+		if !attested {
+			remaining = append(remaining, name)
+		}
+	}
+
+	return remaining, results
+}
@@ -2,7 +2,6 @@ package server
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -14,7 +13,7 @@ import (
 	"github.com/spf13/viper"
 )
 
-func (s *Server) handleChecks(w http.ResponseWriter, r *http.Request, name ...string) {
+func (s *Server) handleChecks(w http.ResponseWriter, r *http.Request, group string, name ...string) {
 	var imageData voucher.ImageData
 	var repositoryClient repository.Client
 	var err error
@@ -43,6 +42,12 @@ func (s *Server) handleChecks(w http.ResponseWriter, r *http.Request, name ...st
 	}
 	defer metadataClient.Close()
 
+	var cachedResults []voucher.CheckResult
+
+	if s.serverConfig.ReuseAttestations {
+		name, cachedResults = s.getCachedResults(ctx, metadataClient, imageData, name)
+	}
+
 	buildDetail, err := metadataClient.GetBuildDetail(ctx, imageData)
 	if nil != err {
 		LogWarning(fmt.Sprintf("could not get image metadata for %s", imageData), err)
@@ -57,13 +62,16 @@ func (s *Server) handleChecks(w http.ResponseWriter, r *http.Request, name ...st
 		}
 	}
 
-	checksuite, err := config.NewCheckSuite(s.secrets, metadataClient, repositoryClient, name...)
+	checksuite, err := config.NewCheckSuiteWithMetrics(s.secrets, metadataClient, repositoryClient, s.metrics, name...)
 	if nil != err {
 		http.Error(w, "server has been misconfigured", http.StatusInternalServerError)
 		LogError("failed to create CheckSuite", err)
 		return
 	}
 
+	checksuite.SetFailFast(s.IsCheckGroupFailFast(group))
+	checksuite.SetGroupPolicy(s.GetCheckGroupPolicy(group))
+
 	var results []voucher.CheckResult
 
 	if viper.GetBool("dryrun") {
@@ -72,15 +80,36 @@ func (s *Server) handleChecks(w http.ResponseWriter, r *http.Request, name ...st
 		results = checksuite.RunAndAttest(ctx, metadataClient, s.metrics, imageData)
 	}
 
-	checkResponse := voucher.NewResponse(imageData, results)
+	checkResponse := voucher.NewPolicyResponse(imageData, append(cachedResults, results...), s.GetCheckGroupPolicy(group))
 
 	LogResult(checkResponse)
 
-	err = json.NewEncoder(w).Encode(checkResponse)
+	writeResponse(w, r, checkResponse)
+}
+
+// getCachedResults returns the names of the passed checks which must be run,
+// and results for those which already have a valid attestation on the image.
+// If the attestations cannot be requested or verified, all of the checks are
+// run.
+func (s *Server) getCachedResults(ctx context.Context, metadataClient voucher.MetadataClient, imageData voucher.ImageData, names []string) ([]string, []voucher.CheckResult) {
+	verifier := config.NewAttestationVerifier(s.secrets)
+	if nil == verifier {
+		log.Warning("the configured signer cannot verify attestations, running all checks")
+		return names, nil
+	}
+	defer verifier.Close()
+
+	payload, err := metadataClient.NewPayloadBody(imageData)
 	if nil != err {
-		// if all else fails
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		LogError("failed to encode respoonse as JSON", err)
-		return
+		LogWarning(fmt.Sprintf("could not create the attestation payload for %s, running all checks", imageData), err)
+		return names, nil
+	}
+
+	attestations, err := metadataClient.GetAttestations(ctx, imageData)
+	if nil != err {
+		LogWarning(fmt.Sprintf("could not get image attestations for %s, running all checks", imageData), err)
+		return names, nil
 	}
+
+	return reuseAttestations(imageData, attestations, names, verifier, payload)
 }
@@ -12,6 +12,10 @@ type Config struct {
 	RequireAuth bool
 	Username    string
 	PassHash    string
+
+	// ReuseAttestations skips running checks which have already attested
+	// the image, and reports their existing attestations instead.
+	ReuseAttestations bool
 }
 
 // Address is the address of the Server.
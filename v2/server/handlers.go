@@ -38,7 +38,7 @@ func (s *Server) HandleCheckImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.handleChecks(w, r, requiredChecks...)
+	s.handleChecks(w, r, checkName, requiredChecks...)
 }
 
 // HandleVerifyImage is a request handler that verifies an individual
@@ -72,7 +72,7 @@ func (s *Server) HandleVerifyImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.handleVerify(w, r, requiredChecks...)
+	s.handleVerify(w, r, checkName, requiredChecks...)
 }
 
 // HandleHealthCheck is a request handler that returns HTTP Status Code 200
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/sarif"
+)
+
+const (
+	// formatSARIF is the value of the "format" query parameter which requests
+	// the vulnerabilities in a response as a SARIF document.
+	formatSARIF = "sarif"
+
+	sarifContentType = "application/sarif+json"
+)
+
+// writeResponse encodes the passed Response to the writer as JSON, or as a
+// SARIF document of the vulnerabilities it reports if the request asked for
+// one with "?format=sarif".
+func writeResponse(w http.ResponseWriter, r *http.Request, checkResponse voucher.Response) {
+	var output interface{} = checkResponse
+
+	if formatSARIF == r.URL.Query().Get("format") {
+		log := sarif.NewLog()
+		if err := log.AddResponse(checkResponse); nil != err {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			LogError("failed to create SARIF document", err)
+			return
+		}
+
+		w.Header().Set("content-type", sarifContentType)
+		output = log
+	}
+
+	err := json.NewEncoder(w).Encode(output)
+	if nil != err {
+		// if all else fails
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		LogError("failed to encode respoonse as JSON", err)
+		return
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	voucher "github.com/grafeas/voucher/v2"
 	"github.com/grafeas/voucher/v2/cmd/config"
 	"github.com/grafeas/voucher/v2/metrics"
 	log "github.com/sirupsen/logrus"
@@ -12,6 +13,8 @@ import (
 type Server struct {
 	serverConfig *Config
 	checkGroups  map[string][]string
+	failFast     map[string]bool
+	policies     map[string]voucher.PolicyExpression
 	secrets      *config.Secrets
 	metrics      metrics.Client
 }
@@ -23,6 +26,8 @@ func NewServer(config *Config, secrets *config.Secrets, metrics metrics.Client)
 		secrets:      secrets,
 		metrics:      metrics,
 		checkGroups:  make(map[string][]string),
+		failFast:     make(map[string]bool),
+		policies:     make(map[string]voucher.PolicyExpression),
 	}
 }
 
@@ -51,3 +56,31 @@ func (server *Server) GetCheckGroup(name string) []string {
 	checks := server.checkGroups[name]
 	return checks
 }
+
+// SetCheckGroupFailFast sets whether the check group with the passed name is
+// run in fail-fast mode, where the first failing check stops the others.
+func (server *Server) SetCheckGroupFailFast(name string, failFast bool) {
+	if failFast {
+		log.Infof("check group \"%s\" will fail fast", name)
+	}
+	server.failFast[name] = failFast
+}
+
+// IsCheckGroupFailFast returns true if the check group with the passed name
+// is run in fail-fast mode.
+func (server *Server) IsCheckGroupFailFast(name string) bool {
+	return server.failFast[name]
+}
+
+// SetCheckGroupPolicy sets the PolicyExpression which decides whether an image
+// passes the check group with the passed name.
+func (server *Server) SetCheckGroupPolicy(name string, policy voucher.PolicyExpression) {
+	log.Infof("check group \"%s\" uses policy: %s", name, policy)
+	server.policies[name] = policy
+}
+
+// GetCheckGroupPolicy returns the PolicyExpression for the check group with
+// the passed name, or nil if every check in the group must pass.
+func (server *Server) GetCheckGroupPolicy(name string) voucher.PolicyExpression {
+	return server.policies[name]
+}
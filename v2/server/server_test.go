@@ -2,6 +2,7 @@ package server
 
 import (
 	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -11,8 +12,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	voucher "github.com/grafeas/voucher/v2"
+	"github.com/grafeas/voucher/v2/attestation"
 	"github.com/grafeas/voucher/v2/cmd/config"
 	"github.com/grafeas/voucher/v2/metrics"
+	"github.com/grafeas/voucher/v2/sarif"
+	"github.com/grafeas/voucher/v2/signer"
+	vtesting "github.com/grafeas/voucher/v2/testing"
 )
 
 var testParams = []byte(`
@@ -126,3 +132,130 @@ func TestHandlerStatus(t *testing.T) {
 	// Check the status code is what we expect
 	assert.Equal(t, http.StatusOK, recorder.Code, "handler for health check failed")
 }
+
+func TestCheckGroupFailFast(t *testing.T) {
+	failFastServer := NewServer(&Config{}, nil, &metrics.NoopClient{})
+	failFastServer.SetCheckGroup("fast", []string{"diy", "nobody"})
+	failFastServer.SetCheckGroupFailFast("fast", true)
+	failFastServer.SetCheckGroup("slow", []string{"diy", "nobody"})
+
+	assert.True(t, failFastServer.IsCheckGroupFailFast("fast"))
+	assert.False(t, failFastServer.IsCheckGroupFailFast("slow"))
+	assert.False(t, failFastServer.IsCheckGroupFailFast("diy"))
+}
+
+func TestReuseAttestations(t *testing.T) {
+	imageData, err := voucher.NewImageData("gcr.io/somewhere/image@sha256:cb749360c5198a55859a7f335de3cf4e2f64b60886a2098684a2f9c7ffca81f2")
+	require.NoError(t, err)
+
+	payload, err := attestation.NewPayload(imageData).ToString()
+	require.NoError(t, err)
+
+	keyring := vtesting.NewPGPSigner(t)
+
+	snakeoilAttestation, err := voucher.SignAttestation(keyring, voucher.NewAttestation("snakeoil", payload))
+	require.NoError(t, err)
+
+	otherAttestation := voucher.SignedAttestation{
+		Attestation: voucher.NewAttestation("other", payload),
+	}
+
+	remaining, results := reuseAttestations(imageData, []voucher.SignedAttestation{snakeoilAttestation, otherAttestation}, []string{"diy", "snakeoil"}, keyring.(signer.AttestationVerifier), payload)
+
+	assert.Equal(t, []string{"diy"}, remaining)
+	assert.Equal(t, []voucher.CheckResult{
+		{
+			Name:      "snakeoil",
+			ImageData: imageData,
+			Success:   true,
+			Attested:  true,
+			Cached:    true,
+			Details:   snakeoilAttestation,
+		},
+	}, results)
+}
+
+func TestReuseAttestationsRejectsInvalidSignatures(t *testing.T) {
+	imageData, err := voucher.NewImageData("gcr.io/somewhere/image@sha256:cb749360c5198a55859a7f335de3cf4e2f64b60886a2098684a2f9c7ffca81f2")
+	require.NoError(t, err)
+
+	otherImageData, err := voucher.NewImageData("gcr.io/somewhere/image@sha256:97db2bc359ccc94d3b2d6f5daa4173e9e91c513b0dcd961408adbb95ec5e5ce5")
+	require.NoError(t, err)
+
+	payload, err := attestation.NewPayload(imageData).ToString()
+	require.NoError(t, err)
+
+	otherPayload, err := attestation.NewPayload(otherImageData).ToString()
+	require.NoError(t, err)
+
+	keyring := vtesting.NewPGPSigner(t)
+
+	foreignAttestation, err := voucher.SignAttestation(vtesting.NewGeneratedPGPSigner(t, "snakeoil"), voucher.NewAttestation("snakeoil", payload))
+	require.NoError(t, err)
+
+	otherImageAttestation, err := voucher.SignAttestation(keyring, voucher.NewAttestation("snakeoil", otherPayload))
+	require.NoError(t, err)
+
+	unsignedAttestation := voucher.SignedAttestation{
+		Attestation: voucher.NewAttestation("snakeoil", payload),
+	}
+
+	attestations := []voucher.SignedAttestation{foreignAttestation, otherImageAttestation, unsignedAttestation}
+	remaining, results := reuseAttestations(imageData, attestations, []string{"snakeoil"}, keyring.(signer.AttestationVerifier), payload)
+
+	assert.Equal(t, []string{"snakeoil"}, remaining)
+	assert.Empty(t, results)
+}
+
+func TestCheckGroupPolicy(t *testing.T) {
+	policy, err := voucher.ParsePolicyExpression("diy OR nobody")
+	require.NoError(t, err)
+
+	policyServer := NewServer(&Config{}, nil, &metrics.NoopClient{})
+	policyServer.SetCheckGroup("either", []string{"diy", "nobody"})
+	policyServer.SetCheckGroupPolicy("either", policy)
+
+	assert.Equal(t, policy, policyServer.GetCheckGroupPolicy("either"))
+	assert.Nil(t, policyServer.GetCheckGroupPolicy("diy"))
+}
+
+func TestWriteResponseAsSARIF(t *testing.T) {
+	checkResponse := voucher.Response{
+		Image: "gcr.io/somewhere/image@sha256:cb749360c5198a55859a7f335de3cf4e2f64b60886a2098684a2f9c7ffca81f2",
+		Results: []voucher.CheckResult{
+			{
+				Name:    "snakeoil",
+				ErrCode: voucher.ErrorCodeVulnerable,
+				ErrDetails: voucher.VulnerabilitiesDetails{
+					Vulnerabilities: []voucher.Vulnerability{
+						{Name: "CVE-2020-1234", Severity: voucher.HighSeverity},
+					},
+				},
+			},
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "/all?format=sarif", nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	writeResponse(recorder, req, checkResponse)
+
+	assert.Equal(t, "application/sarif+json", recorder.Header().Get("content-type"))
+
+	var log sarif.Log
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&log))
+	assert.Equal(t, sarif.Version, log.Version)
+	require.Len(t, log.Runs[0].Results, 1)
+	assert.Equal(t, "CVE-2020-1234", log.Runs[0].Results[0].RuleID)
+
+	req, err = http.NewRequest(http.MethodPost, "/all", nil)
+	require.NoError(t, err)
+
+	recorder = httptest.NewRecorder()
+	writeResponse(recorder, req, checkResponse)
+
+	var decoded voucher.Response
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&decoded))
+	assert.Equal(t, checkResponse.Image, decoded.Image)
+}
@@ -2,7 +2,6 @@ package server
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -10,7 +9,7 @@ import (
 	"github.com/grafeas/voucher/v2/cmd/config"
 )
 
-func (s *Server) handleVerify(w http.ResponseWriter, r *http.Request, names ...string) {
+func (s *Server) handleVerify(w http.ResponseWriter, r *http.Request, group string, names ...string) {
 	var imageData voucher.ImageData
 	var err error
 
@@ -43,20 +42,15 @@ func (s *Server) handleVerify(w http.ResponseWriter, r *http.Request, names ...s
 		LogWarning(fmt.Sprintf("could not get image attestations for %s", imageData), err)
 	}
 
-	checkResponse := voucher.NewResponse(
+	checkResponse := voucher.NewPolicyResponse(
 		imageData,
 		attestationsToResults(attestations, names),
+		s.GetCheckGroupPolicy(group),
 	)
 
 	LogResult(checkResponse)
 
-	err = json.NewEncoder(w).Encode(checkResponse)
-	if nil != err {
-		// if all else fails
-		http.Error(w, err.Error(), 500)
-		LogError("failed to encode respoonse as JSON", err)
-		return
-	}
+	writeResponse(w, r, checkResponse)
 }
 
 func attestationsToResults(attestations []voucher.SignedAttestation, names []string) []voucher.CheckResult {
@@ -31,6 +31,26 @@ func (keyring *KeyRing) Sign(checkName, body string) (string, string, error) {
 	return signature, fmt.Sprintf("%X", signer.PrimaryKey.Fingerprint), err
 }
 
+// Verify verifies that the passed signature was created with the key for the
+// passed check, and that the message it signs is the passed body.
+func (keyring *KeyRing) Verify(checkName, body, signature string) error {
+	entity, err := keyring.GetSignerByName(checkName)
+	if nil != err {
+		return err
+	}
+
+	signed, err := Verify(openpgp.EntityList{entity}, signature)
+	if nil != err {
+		return err
+	}
+
+	if body != signed {
+		return errWrongMessage
+	}
+
+	return nil
+}
+
 // KeysById returns the set of keys that have the given key id.
 func (keyring *KeyRing) KeysById(id uint64) []openpgp.Key {
 	return keyring.entities.KeysById(id)
@@ -2,12 +2,15 @@ package pgp
 
 import (
 	"bytes"
+	"crypto"
 	"os"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/packet"
 )
 
 const snakeoilKeyID = "1E92E2B4BB73E885"
@@ -108,3 +111,25 @@ func TestAttestationPayload(t *testing.T) {
 		assert.Equalf(t, message, payloadMessage, "Failed to get correct message, was \"%s\" instead of \"%s\"", message, payloadMessage)
 	}
 }
+
+func TestKeyRingVerify(t *testing.T) {
+	keyring := newTestKeyRing(t)
+
+	signature, _, err := keyring.Sign("snakeoil", testSignedValue)
+	require.NoError(t, err)
+
+	assert.NoError(t, keyring.Verify("snakeoil", testSignedValue, signature))
+	assert.Equal(t, errWrongMessage, keyring.Verify("snakeoil", "another value", signature))
+	assert.Error(t, keyring.Verify("diy", testSignedValue, signature), "there is no key for diy")
+
+	foreignEntity, err := openpgp.NewEntity("foreign", "", "foreign@example.com", &packet.Config{RSABits: 1024, DefaultHash: crypto.SHA512})
+	require.NoError(t, err)
+
+	foreignKeyRing := NewKeyRing()
+	foreignKeyRing.AddEntities("snakeoil", openpgp.EntityList{foreignEntity})
+
+	foreignSignature, _, err := foreignKeyRing.Sign("snakeoil", testSignedValue)
+	require.NoError(t, err)
+
+	assert.Equal(t, errNoSigner, keyring.Verify("snakeoil", testSignedValue, foreignSignature))
+}
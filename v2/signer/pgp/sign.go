@@ -14,6 +14,7 @@ import (
 
 var errNotSigned = errors.New("contents were not signed")
 var errNoSigner = errors.New("signer is not in keyring")
+var errWrongMessage = errors.New("signed message does not match")
 
 // signConfig is used for our Signer.
 var signConfig = packet.Config{
@@ -87,11 +88,10 @@ func Verify(keyring openpgp.KeyRing, signed string) (string, error) {
 		return "", errNoSigner
 	}
 
+	// The signature is only checked once the body has been read to the end.
 	body, err := ioutil.ReadAll(messageDetails.UnverifiedBody)
-	if nil != err {
-		if nil != messageDetails.SignatureError {
-			err = messageDetails.SignatureError
-		}
+	if nil != messageDetails.SignatureError {
+		err = messageDetails.SignatureError
 	}
 	return string(body), err
 }
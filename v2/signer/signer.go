@@ -5,3 +5,13 @@ type AttestationSigner interface {
 	Sign(checkName, body string) (string, string, error)
 	Close() error
 }
+
+// AttestationVerifier is an AttestationSigner which can also verify the
+// signatures that it creates.
+type AttestationVerifier interface {
+	AttestationSigner
+
+	// Verify returns an error unless the signature was created by the key for
+	// the given check, and signs the passed body
+	Verify(checkName, body, signature string) error
+}
@@ -39,12 +39,15 @@ func (s *Subscriber) check(canonicalImageReference reference.Canonical) (bool, b
 		}
 	}
 
-	checksuite, err := config.NewCheckSuite(s.secrets, metadataClient, repositoryClient, s.cfg.RequiredChecks...)
+	checksuite, err := config.NewCheckSuiteWithMetrics(s.secrets, metadataClient, repositoryClient, s.metrics, s.cfg.RequiredChecks...)
 	if nil != err {
 		s.log.Errorf("failed to create CheckSuite: %s", err)
 		return false, true
 	}
 
+	checksuite.SetFailFast(s.cfg.FailFast)
+	checksuite.SetGroupPolicy(s.cfg.Policy)
+
 	var results []voucher.CheckResult
 
 	if s.cfg.DryRun {
@@ -53,7 +56,7 @@ func (s *Subscriber) check(canonicalImageReference reference.Canonical) (bool, b
 		results = checksuite.RunAndAttest(ctx, metadataClient, s.metrics, canonicalImageReference)
 	}
 
-	checkResponse := voucher.NewResponse(canonicalImageReference, results)
+	checkResponse := voucher.NewPolicyResponse(canonicalImageReference, results, s.cfg.Policy)
 
 	return checkResponse.Success, false
 }
@@ -3,6 +3,7 @@ package subscriber
 import (
 	"time"
 
+	voucher "github.com/grafeas/voucher/v2"
 	"github.com/grafeas/voucher/v2/server"
 )
 
@@ -13,6 +14,8 @@ type Config struct {
 	Subscription   string
 	RequiredChecks []string
 	DryRun         bool
+	FailFast       bool
+	Policy         voucher.PolicyExpression
 	Timeout        int
 }
 
@@ -2,6 +2,8 @@ package voucher
 
 import (
 	"context"
+	"errors"
+	"sort"
 	"time"
 
 	"github.com/grafeas/voucher/v2/metrics"
@@ -9,7 +11,12 @@ import (
 
 // Suite is a suite of Checks, which
 type Suite struct {
-	checks map[string]Check
+	checks       map[string]Check
+	policies     map[string]CheckPolicy
+	dependencies map[string][]string
+	failFast     bool
+	groupPolicy  PolicyExpression
+	waivers      []Waiver
 }
 
 // Add adds a Check to the checks that can be run. Once a Check is added,
@@ -26,6 +33,57 @@ func (cs *Suite) Has(name string) bool {
 	return nil != cs.checks[name]
 }
 
+// SetPolicy sets the CheckPolicy used when running the Check with the passed
+// name. Checks without a policy are run once, with no timeout of their own.
+func (cs *Suite) SetPolicy(name string, policy CheckPolicy) {
+	cs.policies[name] = policy
+}
+
+// SetDependencies sets the names of the Checks that must pass before the Check
+// with the passed name is run or attested. Dependencies on Checks that are not
+// part of the Suite are ignored.
+func (cs *Suite) SetDependencies(name string, dependencies ...string) {
+	cs.dependencies[name] = dependencies
+}
+
+// SetFailFast enables or disables fail-fast mode. In fail-fast mode, the
+// first Check to fail cancels the Checks that are still running, and the
+// Checks that have not started are not run. If a group policy is set, that
+// happens once a failure means the policy cannot pass.
+func (cs *Suite) SetFailFast(failFast bool) {
+	cs.failFast = failFast
+}
+
+// SetGroupPolicy sets the PolicyExpression which decides whether an image
+// passes the Suite. In fail-fast mode, a failing Check only cancels the others
+// once the policy can no longer pass, however the remaining Checks turn out.
+func (cs *Suite) SetGroupPolicy(policy PolicyExpression) {
+	cs.groupPolicy = policy
+}
+
+// canStillPass returns true if the group policy could pass once the Checks
+// which have not failed yet are run, given the Checks which have failed. If
+// there is no group policy, any failure means the Suite cannot pass.
+func (cs *Suite) canStillPass(failed map[string]bool) bool {
+	if nil == cs.groupPolicy {
+		return 0 == len(failed)
+	}
+
+	passed := make(map[string]bool)
+	for _, name := range cs.groupPolicy.Checks() {
+		passed[name] = !failed[name]
+	}
+
+	ok, _ := cs.groupPolicy.Evaluate(passed)
+	return ok
+}
+
+// SetWaivers sets the Waivers which apply to the Checks in the Suite. A
+// failing Check with a Waiver that applies to it is reported as passing.
+func (cs *Suite) SetWaivers(waivers []Waiver) {
+	cs.waivers = waivers
+}
+
 // Get returns the requested Check, or nil if one does not exist.
 func (cs *Suite) Get(name string) (Check, error) {
 	if cs.Has(name) {
@@ -35,45 +93,135 @@ func (cs *Suite) Get(name string) (Check, error) {
 }
 
 // runner runs the passed check against the passed ImageData, and pushes results to the
-// CheckResults channel.
-func runner(ctx context.Context, name string, check Check, imageData ImageData, resultsChan chan CheckResult, metricsClient metrics.Client) {
+// CheckResults channel. The check is retried as described by the passed CheckPolicy.
+func runner(ctx context.Context, name string, check Check, policy CheckPolicy, imageData ImageData, resultsChan chan CheckResult, metricsClient metrics.Client) {
+	var ok, timedOut bool
+	var err error
+
 	metricsClient.CheckRunStart(name)
 	checkStart := time.Now()
-	ok, err := check.Check(ctx, imageData)
+
+	attempts := 0
+	for {
+		attempts++
+		ok, timedOut, err = attemptCheck(ctx, check, policy.Timeout, imageData)
+		if nil == err || attempts > policy.Retries || !(timedOut || IsTransientError(err)) {
+			break
+		}
+
+		if !wait(ctx, policy.backoffFor(attempts)) {
+			break
+		}
+	}
+
 	metricsClient.CheckRunLatency(name, time.Since(checkStart))
-	if err == nil {
+
+	result := CheckResult{Name: name, Success: ok, ImageData: imageData, Attempts: attempts, TimedOut: timedOut}
+	if errors.Is(err, context.Canceled) {
+		result.setError(ErrCheckCancelled)
+		result.Success = false
+		result.Cancelled = true
+	} else if err == nil || (ok && IsWarning(err)) {
+		if nil != err {
+			result.setError(err)
+		}
 		if ok {
 			metricsClient.CheckRunSuccess(name)
 		} else {
 			metricsClient.CheckRunFailure(name)
 		}
-		resultsChan <- CheckResult{Name: name, Err: "", Success: ok, ImageData: imageData}
 	} else {
 		metricsClient.CheckRunError(name, err)
-		resultsChan <- CheckResult{Name: name, Err: err.Error(), Success: false, ImageData: imageData}
+		result.setError(err)
+		result.Success = false
 	}
+	resultsChan <- result
 }
 
 // Run executes each of the Checks specified by the activeChecks parameter.
 //
 // For example, if a Suite has the "diy" and "nobody" tests, calling
 //
-//    Run(imageData)
+//	Run(imageData)
 //
 // will run the "diy" and "nobody" tests.
 //
+// Checks run in parallel, except that a Check with dependencies waits for them
+// to pass first. If a dependency does not pass, the Check is skipped and its
+// CheckResult reports ErrDependencyFailed.
+//
+// Each Check is run according to the CheckPolicy set for it with SetPolicy.
+// If the Suite is in fail-fast mode, the first Check to fail stops the others,
+// and their CheckResults are marked as cancelled. If a group policy is set,
+// the others are only stopped once the policy can no longer pass.
+//
+// A failing Check with an unexpired Waiver is marked as waived, and treated as
+// though it passed.
+//
 // Run returns a []CheckResult with a CheckResult for each Check that was run.
 func (cs *Suite) Run(ctx context.Context, metricsClient metrics.Client, imageData ImageData) []CheckResult {
 	results := make([]CheckResult, 0, len(cs.checks))
 	resultsChan := make(chan CheckResult, len(cs.checks))
 	defer close(resultsChan)
 
-	for name, check := range cs.checks {
-		go runner(ctx, name, check, imageData, resultsChan, metricsClient)
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	graph := cs.newDependencyGraph()
+	running := 0
+	cancelled := false
+	failed := make(map[string]bool)
+
+	start := func() {
+		if cancelled {
+			return
+		}
+		for _, name := range graph.ready() {
+			running++
+			go runner(runCtx, name, cs.checks[name], cs.policies[name], imageData, resultsChan, metricsClient)
+		}
 	}
 
-	for range cs.checks {
-		results = append(results, <-resultsChan)
+	start()
+	for 0 < running {
+		result := <-resultsChan
+		running--
+		cs.waive(&result, time.Now())
+		results = append(results, result)
+
+		// Once cancelled, Checks that have not started are left in the graph,
+		// and are reported as cancelled below.
+		if cancelled {
+			continue
+		}
+
+		if !result.Success {
+			failed[result.Name] = true
+		}
+
+		for name, dependency := range graph.complete(result.Name, result.Success) {
+			results = append(results, newSkippedResult(name, dependency, imageData))
+			failed[name] = true
+		}
+
+		if cs.failFast && !cs.canStillPass(failed) {
+			cancelled = true
+			cancel()
+		}
+
+		start()
+	}
+
+	for _, name := range graph.remaining() {
+		if cancelled {
+			result := CheckResult{Name: name, ImageData: imageData, Cancelled: true}
+			result.setError(ErrCheckCancelled)
+			results = append(results, result)
+			continue
+		}
+		result := CheckResult{Name: name, ImageData: imageData}
+		result.setError(ErrDependencyCycle)
+		results = append(results, result)
 	}
 
 	return results
@@ -83,11 +231,16 @@ func (cs *Suite) Run(ctx context.Context, metricsClient metrics.Client, imageDat
 // runs the CreateAttestion function in the Check corresponding to that CheckResult. Each
 // CheckResult is updated with the details (or error) and the resulting []CheckResult is
 // returned.
+//
+// Attestations are created in dependency order, and a Check is only attested if
+// the Checks it depends on passed. Waived Checks are only attested if their
+// Waiver allows it.
 func (cs *Suite) Attest(ctx context.Context, metricsClient metrics.Client, metadataClient MetadataClient, results []CheckResult) []CheckResult {
-	for i, result := range results {
+	for _, i := range cs.attestationOrder(results) {
+		result := results[i]
 		checkStart := time.Now()
 		metricsClient.CheckAttestationStart(result.Name)
-		if result.Success {
+		if result.Success && (!result.Waived || result.Waiver.Attest) && dependenciesPassed(cs.dependencies[result.Name], results) {
 			details, err := createAttestation(ctx, metadataClient, result)
 			results[i].Details = details
 			if nil == err {
@@ -95,7 +248,7 @@ func (cs *Suite) Attest(ctx context.Context, metricsClient metrics.Client, metad
 				metricsClient.CheckAttestationSuccess(result.Name)
 			} else {
 				metricsClient.CheckAttestationError(result.Name, err)
-				results[i].Err = err.Error()
+				results[i].setError(err)
 			}
 		}
 		metricsClient.CheckAttestationLatency(result.Name, time.Since(checkStart))
@@ -110,6 +263,45 @@ func (cs *Suite) RunAndAttest(ctx context.Context, metadataClient MetadataClient
 	return cs.Attest(ctx, metricsClient, metadataClient, results)
 }
 
+// attestationOrder returns the indexes of the passed results, sorted so that
+// the result of every Check comes after the results of the Checks it depends on.
+func (cs *Suite) attestationOrder(results []CheckResult) []int {
+	order := cs.order()
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		rank[name] = i
+	}
+
+	indexes := make([]int, len(results))
+	for i := range results {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(a, b int) bool {
+		rankA, okA := rank[results[indexes[a]].Name]
+		rankB, okB := rank[results[indexes[b]].Name]
+		if !okA || !okB {
+			return okA && !okB
+		}
+		return rankA < rankB
+	})
+
+	return indexes
+}
+
+// dependenciesPassed returns false if the result of any of the passed
+// dependencies is in the passed results and was not successful.
+func dependenciesPassed(dependencies []string, results []CheckResult) bool {
+	for _, dependency := range dependencies {
+		for _, result := range results {
+			if result.Name == dependency && !result.Success {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // createAttestation generates an attestation for the image Check described by CheckResult.
 // That attestation is then added to the metadata server the MetadataClient is connected to.
 func createAttestation(ctx context.Context, client MetadataClient, result CheckResult) (interface{}, error) {
@@ -127,5 +319,7 @@ func createAttestation(ctx context.Context, client MetadataClient, result CheckR
 func NewSuite() *Suite {
 	suite := new(Suite)
 	suite.checks = make(map[string]Check)
+	suite.policies = make(map[string]CheckPolicy)
+	suite.dependencies = make(map[string][]string)
 	return suite
 }
@@ -3,7 +3,9 @@ package voucher
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/grafeas/voucher/v2/metrics"
 	"github.com/stretchr/testify/assert"
@@ -53,6 +55,7 @@ func TestNewSuite(t *testing.T) {
 			Success:   true,
 			Attested:  false,
 			Details:   nil,
+			Attempts:  1,
 		},
 		{
 			Name:      "failer",
@@ -61,14 +64,17 @@ func TestNewSuite(t *testing.T) {
 			Success:   false,
 			Attested:  false,
 			Details:   nil,
+			Attempts:  1,
 		},
 		{
 			Name:      "broken",
 			ImageData: imageData,
 			Err:       errBrokenTest.Error(),
+			ErrCode:   ErrorCodeUnknown,
 			Success:   false,
 			Attested:  false,
 			Details:   nil,
+			Attempts:  1,
 		},
 	}
 
@@ -171,11 +177,13 @@ func TestAttestSuite(t *testing.T) {
 					CheckName: "snakeoil",
 				},
 			},
+			Attempts: 1,
 		},
 		{
 			Name:      "pass2",
 			ImageData: imageData,
 			Err:       errNoSigningEntity.Error(),
+			ErrCode:   ErrorCodeUnknown,
 			Success:   true,
 			Attested:  false,
 			Details: SignedAttestation{
@@ -183,11 +191,13 @@ func TestAttestSuite(t *testing.T) {
 					CheckName: "pass2",
 				},
 			},
+			Attempts: 1,
 		},
 		{
 			Name:      "pass3",
 			ImageData: imageData,
 			Err:       errNoSigningEntity.Error(),
+			ErrCode:   ErrorCodeUnknown,
 			Success:   true,
 			Attested:  false,
 			Details: SignedAttestation{
@@ -195,6 +205,7 @@ func TestAttestSuite(t *testing.T) {
 					CheckName: "pass3",
 				},
 			},
+			Attempts: 1,
 		},
 	}
 
@@ -222,10 +233,374 @@ func TestNonattestingSuite(t *testing.T) {
 		Name:      "snakeoil",
 		ImageData: imageData,
 		Err:       errCreatingPayload.Error(),
+		ErrCode:   ErrorCodeUnknown,
 		Success:   true,
 		Attested:  false,
 		Details:   nil,
+		Attempts:  1,
 	}
 
 	assert.Contains(t, results, expectedResult)
 }
+
+func TestSuiteRetriesTransientErrors(t *testing.T) {
+	imageData := newTestImageData(t)
+	errUnavailable := NewTransientError(errors.New("service unavailable"))
+	errBrokenTest := errors.New("this test is broken")
+
+	suite := NewSuite()
+
+	flaky := new(MockCheck)
+	flaky.On("Check", mock.Anything, imageData).Return(false, errUnavailable).Twice()
+	flaky.On("Check", mock.Anything, imageData).Return(true, nil).Once()
+	suite.Add("flaky", flaky)
+	suite.SetPolicy("flaky", CheckPolicy{Retries: 2, Backoff: time.Millisecond})
+
+	broken := new(MockCheck)
+	broken.On("Check", mock.Anything, imageData).Return(false, errBrokenTest).Once()
+	suite.Add("broken", broken)
+	suite.SetPolicy("broken", CheckPolicy{Retries: 2, Backoff: time.Millisecond})
+
+	down := new(MockCheck)
+	down.On("Check", mock.Anything, imageData).Return(false, errUnavailable).Twice()
+	suite.Add("down", down)
+	suite.SetPolicy("down", CheckPolicy{Retries: 1})
+
+	results := suite.Run(context.Background(), &metrics.NoopClient{}, imageData)
+
+	assert.ElementsMatch(t, []CheckResult{
+		{Name: "flaky", ImageData: imageData, Success: true, Attempts: 3},
+		{Name: "broken", ImageData: imageData, Err: errBrokenTest.Error(), ErrCode: ErrorCodeUnknown, Attempts: 1},
+		{Name: "down", ImageData: imageData, Err: errUnavailable.Error(), ErrCode: ErrorCodeUnknown, Attempts: 2},
+	}, results)
+
+	flaky.AssertExpectations(t)
+	broken.AssertExpectations(t)
+	down.AssertExpectations(t)
+}
+
+func TestSuiteWarnings(t *testing.T) {
+	imageData := newTestImageData(t)
+	errMinor := NewCheckError(ErrorCodeVulnerable, "something minor")
+
+	suite := NewSuite()
+
+	warns := new(MockCheck)
+	warns.On("Check", mock.Anything, imageData).Return(true, NewWarning(errMinor)).Once()
+	suite.Add("warns", warns)
+
+	fails := new(MockCheck)
+	fails.On("Check", mock.Anything, imageData).Return(false, NewWarning(errMinor)).Once()
+	suite.Add("fails", fails)
+
+	results := suite.Run(context.Background(), &metrics.NoopClient{}, imageData)
+
+	assert.ElementsMatch(t, []CheckResult{
+		{Name: "warns", ImageData: imageData, Success: true, Err: errMinor.Error(), ErrCode: ErrorCodeVulnerable, Attempts: 1},
+		{Name: "fails", ImageData: imageData, Err: errMinor.Error(), ErrCode: ErrorCodeVulnerable, Attempts: 1},
+	}, results)
+
+	assert.True(t, IsWarning(fmt.Errorf("wrapped: %w", NewWarning(errMinor))))
+	assert.False(t, IsWarning(errMinor))
+
+	warns.AssertExpectations(t)
+	fails.AssertExpectations(t)
+}
+
+func TestSuiteCheckTimeout(t *testing.T) {
+	imageData := newTestImageData(t)
+
+	suite := NewSuite()
+
+	slow := new(MockCheck)
+	slow.On("Check", mock.Anything, imageData).After(time.Second).Return(true, nil)
+	suite.Add("slow", slow)
+	suite.SetPolicy("slow", CheckPolicy{Timeout: 10 * time.Millisecond, Retries: 1})
+
+	fast := new(MockCheck)
+	fast.On("Check", mock.Anything, imageData).Return(true, nil)
+	suite.Add("fast", fast)
+	suite.SetPolicy("fast", CheckPolicy{Timeout: time.Second})
+
+	results := suite.Run(context.Background(), &metrics.NoopClient{}, imageData)
+
+	assert.ElementsMatch(t, []CheckResult{
+		{Name: "slow", ImageData: imageData, Err: ErrCheckTimedOut.Error(), ErrCode: ErrorCodeTimedOut, Attempts: 2, TimedOut: true},
+		{Name: "fast", ImageData: imageData, Success: true, Attempts: 1},
+	}, results)
+}
+
+func TestIsTransientError(t *testing.T) {
+	errBase := errors.New("something went wrong")
+
+	assert.False(t, IsTransientError(errBase))
+	assert.True(t, IsTransientError(NewTransientError(errBase)))
+	assert.True(t, IsTransientError(fmt.Errorf("wrapped: %w", NewTransientError(errBase))))
+	assert.True(t, errors.Is(NewTransientError(errBase), errBase))
+}
+
+// orderedCheck is a Check which records the order Checks were run in.
+type orderedCheck struct {
+	name  string
+	pass  bool
+	order chan string
+}
+
+func (c *orderedCheck) Check(ctx context.Context, i ImageData) (bool, error) {
+	c.order <- c.name
+	return c.pass, nil
+}
+
+func TestSuiteDependencies(t *testing.T) {
+	imageData := newTestImageData(t)
+	order := make(chan string, 5)
+
+	suite := NewSuite()
+	for name, pass := range map[string]bool{
+		"provenance": true,
+		"approved":   true,
+		"is_org":     true,
+		"diy":        false,
+		"nobody":     true,
+	} {
+		suite.Add(name, &orderedCheck{name: name, pass: pass, order: order})
+	}
+
+	suite.SetDependencies("approved", "provenance")
+	suite.SetDependencies("is_org", "approved", "provenance")
+	suite.SetDependencies("nobody", "diy", "not_in_suite")
+
+	results := suite.Run(context.Background(), &metrics.NoopClient{}, imageData)
+	close(order)
+
+	assert.ElementsMatch(t, []CheckResult{
+		{Name: "provenance", ImageData: imageData, Success: true, Attempts: 1},
+		{Name: "approved", ImageData: imageData, Success: true, Attempts: 1},
+		{Name: "is_org", ImageData: imageData, Success: true, Attempts: 1},
+		{Name: "diy", ImageData: imageData, Success: false, Attempts: 1},
+		{Name: "nobody", ImageData: imageData, Err: "skipped: dependency failed: diy", ErrCode: ErrorCodeDependencyFailed, ErrDetails: map[string]string{"dependency": "diy"}, Skipped: true},
+	}, results)
+
+	ran := make([]string, 0, 4)
+	for name := range order {
+		ran = append(ran, name)
+	}
+
+	require.Len(t, ran, 4)
+	assert.NotContains(t, ran, "nobody")
+	assert.Equal(t, []string{"provenance", "approved", "is_org"}, filterNames(ran, "provenance", "approved", "is_org"))
+}
+
+// filterNames returns the passed names that are in the passed filter, in order.
+func filterNames(names []string, filter ...string) []string {
+	filtered := make([]string, 0, len(names))
+	for _, name := range names {
+		for _, allowed := range filter {
+			if name == allowed {
+				filtered = append(filtered, name)
+			}
+		}
+	}
+	return filtered
+}
+
+func TestSuiteDependencyFailureCascades(t *testing.T) {
+	imageData := newTestImageData(t)
+
+	suite := NewSuite()
+
+	provenance := new(MockCheck)
+	provenance.On("Check", mock.Anything, imageData).Return(false, errors.New("no build metadata"))
+	suite.Add("provenance", provenance)
+
+	for _, name := range []string{"approved", "is_org"} {
+		check := new(MockCheck)
+		suite.Add(name, check)
+	}
+
+	suite.SetDependencies("approved", "provenance")
+	suite.SetDependencies("is_org", "approved")
+
+	results := suite.Run(context.Background(), &metrics.NoopClient{}, imageData)
+
+	assert.ElementsMatch(t, []CheckResult{
+		{Name: "provenance", ImageData: imageData, Err: "no build metadata", ErrCode: ErrorCodeUnknown, Attempts: 1},
+		{Name: "approved", ImageData: imageData, Err: "skipped: dependency failed: provenance", ErrCode: ErrorCodeDependencyFailed, ErrDetails: map[string]string{"dependency": "provenance"}, Skipped: true},
+		{Name: "is_org", ImageData: imageData, Err: "skipped: dependency failed: approved", ErrCode: ErrorCodeDependencyFailed, ErrDetails: map[string]string{"dependency": "approved"}, Skipped: true},
+	}, results)
+}
+
+func TestSuiteDependencyCycle(t *testing.T) {
+	imageData := newTestImageData(t)
+
+	suite := NewSuite()
+	for _, name := range []string{"a", "b"} {
+		suite.Add(name, new(MockCheck))
+	}
+
+	suite.SetDependencies("a", "b")
+	suite.SetDependencies("b", "a")
+
+	results := suite.Run(context.Background(), &metrics.NoopClient{}, imageData)
+
+	assert.ElementsMatch(t, []CheckResult{
+		{Name: "a", ImageData: imageData, Err: ErrDependencyCycle.Error(), ErrCode: ErrorCodeDependencyCycle},
+		{Name: "b", ImageData: imageData, Err: ErrDependencyCycle.Error(), ErrCode: ErrorCodeDependencyCycle},
+	}, results)
+}
+
+func TestAttestWithDependencies(t *testing.T) {
+	imageData := newTestImageData(t)
+
+	metadataClient := new(MockMetadataClient)
+	metadataClient.
+		On("NewPayloadBody", imageData).Return(imageData.String(), nil).
+		On("AddAttestationToImage", mock.Anything, imageData, NewAttestation("diy", imageData.String())).Return(SignedAttestation{
+		Attestation: Attestation{
+			CheckName: "diy",
+		},
+	}, nil)
+
+	suite := NewSuite()
+	for _, name := range []string{"provenance", "approved", "diy"} {
+		suite.Add(name, new(MockCheck))
+	}
+	suite.SetDependencies("approved", "provenance")
+
+	results := suite.Attest(context.Background(), &metrics.NoopClient{}, metadataClient, []CheckResult{
+		{Name: "approved", ImageData: imageData, Success: true},
+		{Name: "provenance", ImageData: imageData, Success: false},
+		{Name: "diy", ImageData: imageData, Success: true},
+	})
+
+	assert.Equal(t, []CheckResult{
+		{Name: "approved", ImageData: imageData, Success: true},
+		{Name: "provenance", ImageData: imageData, Success: false},
+		{Name: "diy", ImageData: imageData, Success: true, Attested: true, Details: SignedAttestation{
+			Attestation: Attestation{
+				CheckName: "diy",
+			},
+		}},
+	}, results)
+	metadataClient.AssertNumberOfCalls(t, "AddAttestationToImage", 1)
+}
+
+func TestSuiteOrder(t *testing.T) {
+	suite := NewSuite()
+	for _, name := range []string{"approved", "diy", "is_org", "provenance"} {
+		suite.Add(name, new(MockCheck))
+	}
+
+	suite.SetDependencies("is_org", "approved")
+	suite.SetDependencies("approved", "provenance")
+
+	assert.Equal(t, []string{"diy", "provenance", "approved", "is_org"}, suite.order())
+}
+
+// blockingCheck is a Check which blocks until its context is done.
+type blockingCheck struct{}
+
+func (c *blockingCheck) Check(ctx context.Context, i ImageData) (bool, error) {
+	<-ctx.Done()
+	return false, ctx.Err()
+}
+
+func TestSuiteFailFast(t *testing.T) {
+	imageData := newTestImageData(t)
+
+	suite := NewSuite()
+	suite.SetFailFast(true)
+
+	diy := new(MockCheck)
+	diy.On("Check", mock.Anything, imageData).Return(false, ErrNoCheck)
+	suite.Add("diy", diy)
+
+	suite.Add("snakeoil", new(blockingCheck))
+
+	slow := new(MockCheck)
+	slow.On("Check", mock.Anything, imageData).After(time.Second).Return(true, nil)
+	suite.Add("slow", slow)
+
+	suite.Add("nobody", new(MockCheck))
+	suite.SetDependencies("nobody", "snakeoil")
+
+	start := time.Now()
+	results := suite.Run(context.Background(), &metrics.NoopClient{}, imageData)
+	assert.True(t, time.Since(start) < time.Second, "fail-fast suite waited for cancelled checks")
+
+	assert.ElementsMatch(t, []CheckResult{
+		{Name: "diy", ImageData: imageData, Err: ErrNoCheck.Error(), ErrCode: ErrorCodeNoCheck, Attempts: 1},
+		{Name: "snakeoil", ImageData: imageData, Err: ErrCheckCancelled.Error(), Attempts: 1, ErrCode: ErrorCodeCancelled, Cancelled: true},
+		{Name: "slow", ImageData: imageData, Err: ErrCheckCancelled.Error(), Attempts: 1, ErrCode: ErrorCodeCancelled, Cancelled: true},
+		{Name: "nobody", ImageData: imageData, Err: ErrCheckCancelled.Error(), ErrCode: ErrorCodeCancelled, Cancelled: true},
+	}, results)
+
+	response := NewResponse(imageData, results)
+	assert.False(t, response.Success)
+}
+
+func TestSuiteFailFastWithGroupPolicy(t *testing.T) {
+	imageData := newTestImageData(t)
+
+	policy, err := ParsePolicyExpression("diy OR slow")
+	require.NoError(t, err)
+
+	suite := NewSuite()
+	suite.SetFailFast(true)
+	suite.SetGroupPolicy(policy)
+
+	diy := new(MockCheck)
+	diy.On("Check", mock.Anything, imageData).Return(false, nil)
+	suite.Add("diy", diy)
+
+	slow := new(MockCheck)
+	slow.On("Check", mock.Anything, imageData).After(10*time.Millisecond).Return(true, nil)
+	suite.Add("slow", slow)
+
+	results := suite.Run(context.Background(), &metrics.NoopClient{}, imageData)
+
+	assert.ElementsMatch(t, []CheckResult{
+		{Name: "diy", ImageData: imageData, Attempts: 1},
+		{Name: "slow", ImageData: imageData, Success: true, Attempts: 1},
+	}, results, "a failure the policy allows should not cancel the other checks")
+
+	response := NewPolicyResponse(imageData, results, policy)
+	assert.True(t, response.Success)
+
+	policy, err = ParsePolicyExpression("diy AND (slow OR snakeoil)")
+	require.NoError(t, err)
+
+	suite = NewSuite()
+	suite.SetFailFast(true)
+	suite.SetGroupPolicy(policy)
+	suite.Add("diy", diy)
+	suite.Add("snakeoil", new(blockingCheck))
+
+	results = suite.Run(context.Background(), &metrics.NoopClient{}, imageData)
+
+	assert.ElementsMatch(t, []CheckResult{
+		{Name: "diy", ImageData: imageData, Attempts: 1},
+		{Name: "snakeoil", ImageData: imageData, Err: ErrCheckCancelled.Error(), Attempts: 1, ErrCode: ErrorCodeCancelled, Cancelled: true},
+	}, results, "a failure the policy cannot pass without should cancel the other checks")
+}
+
+func TestSuiteWithoutFailFast(t *testing.T) {
+	imageData := newTestImageData(t)
+
+	suite := NewSuite()
+
+	diy := new(MockCheck)
+	diy.On("Check", mock.Anything, imageData).Return(false, nil)
+	suite.Add("diy", diy)
+
+	slow := new(MockCheck)
+	slow.On("Check", mock.Anything, imageData).After(10*time.Millisecond).Return(true, nil)
+	suite.Add("slow", slow)
+
+	results := suite.Run(context.Background(), &metrics.NoopClient{}, imageData)
+
+	assert.ElementsMatch(t, []CheckResult{
+		{Name: "diy", ImageData: imageData, Attempts: 1},
+		{Name: "slow", ImageData: imageData, Success: true, Attempts: 1},
+	}, results)
+}
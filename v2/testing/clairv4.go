@@ -0,0 +1,88 @@
+package vtesting
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	clairV4IndexPath  = "/indexer/api/v1/index_report"
+	clairV4ReportPath = "/matcher/api/v1/vulnerability_report/"
+)
+
+type clairV4APIMock struct {
+	reports map[string]interface{}
+	indexed map[string]bool
+	lock    sync.Mutex
+}
+
+func (mock *clairV4APIMock) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	user, pass, _ := req.BasicAuth()
+	if user != basicUsername || pass != basicPassword {
+		http.Error(writer, fmt.Sprintf("Unauthorized: %s", req.URL.Path), http.StatusUnauthorized)
+		return
+	}
+
+	mock.lock.Lock()
+	defer mock.lock.Unlock()
+
+	switch {
+	case http.MethodPost == req.Method && clairV4IndexPath == req.URL.Path:
+		var manifest struct {
+			Hash   string `json:"hash"`
+			Layers []struct {
+				Hash    string              `json:"hash"`
+				URI     string              `json:"uri"`
+				Headers map[string][]string `json:"headers"`
+			} `json:"layers"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&manifest); nil != err {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		for _, layer := range manifest.Layers {
+			if "" == layer.URI || 0 == len(layer.Headers["Authorization"]) {
+				http.Error(writer, fmt.Sprintf("layer %s cannot be downloaded", layer.Hash), http.StatusBadRequest)
+				return
+			}
+		}
+
+		mock.indexed[manifest.Hash] = true
+		jsonRespond(writer, jsonContentType, map[string]interface{}{
+			"manifest_hash": manifest.Hash,
+			"state":         "IndexFinished",
+			"success":       true,
+		})
+		return
+	case http.MethodGet == req.Method && strings.HasPrefix(req.URL.Path, clairV4ReportPath):
+		hash := strings.TrimPrefix(req.URL.Path, clairV4ReportPath)
+		report, ok := mock.reports[hash]
+		if !mock.indexed[hash] || !ok {
+			http.Error(writer, fmt.Sprintf("no vulnerability report for %s", hash), http.StatusNotFound)
+			return
+		}
+		jsonRespond(writer, jsonContentType, report)
+		return
+	}
+
+	http.Error(writer, fmt.Sprintf("failed to handle request: %s", req.URL.Path), http.StatusInternalServerError)
+}
+
+// NewTestClairV4Server creates a mock of Clair v4's indexer and matcher. The
+// passed reports are returned as the vulnerability reports for the manifest
+// digests they are keyed by, once those manifests have been indexed. Requests
+// must use Basic Auth with the same credentials as NewTestClairServer.
+func NewTestClairV4Server(t *testing.T, reports map[string]interface{}) *httptest.Server {
+	handler := new(clairV4APIMock)
+	handler.reports = reports
+	handler.indexed = make(map[string]bool)
+
+	server := httptest.NewServer(handler)
+	return server
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/docker/distribution/manifest/schema2"
 	dockerTypes "github.com/docker/docker/api/types"
 	"github.com/docker/libtrust"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // RateLimitOutput is the data that is returned when we similuate a Docker
@@ -26,8 +27,8 @@ type dockerAPIMock struct {
 // invalid requests with garbage data.
 func (mock *dockerAPIMock) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
 	switch req.URL.Path {
-	case "/v2/path/to/image/manifests/latest", "/v2/path/to/image/manifests/sha256:b148c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da":
-		writer.Header().Set("Docker-Content-Digest", "sha256:b148c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da")
+	case "/v2/path/to/image/manifests/latest", "/v2/path/to/image/manifests/sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9":
+		writer.Header().Set("Docker-Content-Digest", "sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9")
 		mimeType, raw, _ := NewTestManifest().Payload()
 		rawRespond(writer, mimeType, string(raw))
 		return
@@ -35,26 +36,69 @@ func (mock *dockerAPIMock) ServeHTTP(writer http.ResponseWriter, req *http.Reque
 		writer.Header().Set("Docker-Content-Digest", "sha256:03f65aeeb2e8e8db022b297cae4cdce9248633f551452e63ba520d1f9ef2eca0")
 		jsonRespond(writer, schema1.MediaTypeManifest, NewTestSchema1Manifest())
 		return
-	case "/v2/schema1imagesigned/manifests/latest", "/v2/schema1imagesigned/manifests/sha256:18e6e7971438ab792d13563dcd8972acf4445bc0dcfdff84a6374d63a9c3ed62":
-		writer.Header().Set("Docker-Content-Digest", "sha256:18e6e7971438ab792d13563dcd8972acf4445bc0dcfdff84a6374d63a9c3ed62")
+	case "/v2/schema1imagesigned/manifests/latest", "/v2/schema1imagesigned/manifests/sha256:af0ddbf967bc9796ddb1cec96a1c30d626c65842a2c9dc1672ee358cc452f836":
+		writer.Header().Set("Docker-Content-Digest", "sha256:af0ddbf967bc9796ddb1cec96a1c30d626c65842a2c9dc1672ee358cc452f836")
 		mimeType, raw, _ := NewTestSchema1SignedManifest(mock.privateKey).Payload()
 		rawRespond(writer, mimeType, string(raw))
 		return
-	case "/v2/path/to/ratelimited/manifests/latest", "/v2/path/to/ratelimited/manifests/sha256:b148c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da":
+	case "/v2/path/to/ratelimited/manifests/latest", "/v2/path/to/ratelimited/manifests/sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9":
 		rawRespond(writer, "text/html", RateLimitOutput)
 		return
-	case "/v2/path/to/image/blobs/sha256:b5b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7":
+	case "/v2/path/to/image/manifests/sha256:0305f9da9df288289d7ddf5952af8fb009dc6712a07533df24ddb2c2e785c217":
+		writer.Header().Set("Docker-Content-Digest", "sha256:0305f9da9df288289d7ddf5952af8fb009dc6712a07533df24ddb2c2e785c217")
+		mimeType, raw, _ := NewTestIndex().Payload()
+		rawRespond(writer, mimeType, string(raw))
+		return
+	case "/v2/path/to/ociimage/manifests/sha256:6906b90dc2faeea25d3d3de1a3727fd6c6d41abdff97d5e498fff3bbd0b7212f":
+		writer.Header().Set("Docker-Content-Digest", "sha256:6906b90dc2faeea25d3d3de1a3727fd6c6d41abdff97d5e498fff3bbd0b7212f")
+		mimeType, raw, _ := NewTestOCIManifest().Payload()
+		rawRespond(writer, mimeType, string(raw))
+		return
+	case "/v2/path/to/ociimage/blobs/sha256:46b82a049a06a504be1286cec37e959b65dce0d5ae9ffe946895914aa1870c87":
+		jsonRespond(writer, v1.MediaTypeImageConfig, NewTestOCIImageConfig())
+		return
+	case "/v2/path/to/tampered/manifests/sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2",
+		"/v2/path/to/tampered/manifests/sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9":
+		mimeType, raw, _ := NewTestManifest().Payload()
+		rawRespond(writer, mimeType, string(raw))
+		return
+	case "/v2/path/to/tampered/blobs/sha256:971e93cb33faeed22cb48192a7e6d0b41ddd252f3acb27514a53157442be661d":
+		jsonRespond(writer, schema2.MediaTypeImageConfig, NewTestRootImageConfig())
+		return
+	case "/v2/path/to/image/tags/list":
+		jsonRespond(writer, "application/json", map[string]interface{}{
+			"name": "path/to/image",
+			"tags": []string{"latest", "previous"},
+			"manifest": map[string]interface{}{
+				"sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9": map[string]interface{}{
+					"tag":            []string{"latest"},
+					"timeUploadedMs": "1600000200000",
+				},
+				"sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2": map[string]interface{}{
+					"tag":            []string{"previous"},
+					"timeUploadedMs": "1600000100000",
+				},
+			},
+		})
+		return
+	case "/v2/schema1image/tags/list":
+		jsonRespond(writer, "application/json", map[string]interface{}{
+			"name": "schema1image",
+			"tags": []string{"latest"},
+		})
+		return
+	case "/v2/path/to/image/blobs/sha256:971e93cb33faeed22cb48192a7e6d0b41ddd252f3acb27514a53157442be661d":
 		jsonRespond(writer, schema2.MediaTypeImageConfig, NewTestNobodyImageConfig())
 		return
 	case "/v2/path/to/bad/image/manifests/latest", "/v2/path/to/bad/image/manifests/sha256:bad8c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da":
 		http.Error(writer, "image doesn't exist", 404)
 		return
-	case "/v2/path/to/image/manifests/sha256:b248c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da":
-		writer.Header().Set("Docker-Content-Digest", "sha256:b248c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da")
+	case "/v2/path/to/image/manifests/sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2":
+		writer.Header().Set("Docker-Content-Digest", "sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2")
 		mimeType, raw, _ := NewTestRootManifest().Payload()
 		rawRespond(writer, mimeType, string(raw))
 		return
-	case "/v2/path/to/image/blobs/sha256:b5b2b2c507a0944348e0303114d8d93bbbb081732b86451d9bce1f432a537bc7":
+	case "/v2/path/to/image/blobs/sha256:7ab4d809ed4ac6e81859e62610c20a46ce11d724b97572d8689710502ed7ebc8":
 		jsonRespond(writer, schema2.MediaTypeImageConfig, NewTestRootImageConfig())
 		return
 	}
@@ -88,7 +132,7 @@ func jsonRespond(writer http.ResponseWriter, content string, v interface{}) {
 // http.Error on the writer.
 func rawRespond(writer http.ResponseWriter, content, body string) {
 	writer.Header().Set("Content-Type", content)
-	_, err := fmt.Fprintln(writer, body)
+	_, err := fmt.Fprint(writer, body)
 	if nil != err {
 		http.Error(writer, fmt.Sprintf("failed to handle request: %s", err), 500)
 	}
@@ -119,3 +163,16 @@ func NewTestRootImageConfig() interface{} {
 
 	return config
 }
+
+// NewTestOCIImageConfig creates a test OCI Image Config with user as nobody for our mock Docker API.
+func NewTestOCIImageConfig() interface{} {
+	config := struct {
+		Config dockerTypes.ExecConfig `json:"config"`
+	}{
+		Config: dockerTypes.ExecConfig{
+			User: "nobody",
+		},
+	}
+
+	return config
+}
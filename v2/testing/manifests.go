@@ -2,9 +2,12 @@ package vtesting
 
 import (
 	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/ocischema"
 	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/libtrust"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // NewTestManifest creates a test schema2 manifest for our mock Docker API.
@@ -13,7 +16,7 @@ func NewTestManifest() *schema2.DeserializedManifest {
 		Config: distribution.Descriptor{
 			MediaType: schema2.MediaTypeImageConfig,
 			Size:      7023,
-			Digest:    "sha256:b5b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7",
+			Digest:    "sha256:971e93cb33faeed22cb48192a7e6d0b41ddd252f3acb27514a53157442be661d",
 		},
 		Layers: []distribution.Descriptor{
 			{
@@ -51,7 +54,7 @@ func NewTestRootManifest() *schema2.DeserializedManifest {
 		Config: distribution.Descriptor{
 			MediaType: schema2.MediaTypeImageConfig,
 			Size:      7023,
-			Digest:    "sha256:b5b2b2c507a0944348e0303114d8d93bbbb081732b86451d9bce1f432a537bc7",
+			Digest:    "sha256:7ab4d809ed4ac6e81859e62610c20a46ce11d724b97572d8689710502ed7ebc8",
 		},
 		Layers: []distribution.Descriptor{
 			{
@@ -176,3 +179,66 @@ func NewTestSchema1SignedManifest(pk libtrust.PrivateKey) *schema1.SignedManifes
 
 	return signed
 }
+
+// NewTestOCIManifest creates a test OCI image manifest for our mock Docker API.
+func NewTestOCIManifest() *ocischema.DeserializedManifest {
+	manifest := ocischema.Manifest{
+		Versioned: ocischema.SchemaVersion,
+		Config: distribution.Descriptor{
+			MediaType: v1.MediaTypeImageConfig,
+			Size:      1469,
+			Digest:    "sha256:46b82a049a06a504be1286cec37e959b65dce0d5ae9ffe946895914aa1870c87",
+		},
+		Layers: []distribution.Descriptor{
+			{
+				MediaType: v1.MediaTypeImageLayerGzip,
+				Size:      32654,
+				Digest:    "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f",
+			},
+		},
+	}
+
+	newManifest, err := ocischema.FromStruct(manifest)
+	if nil != err {
+		panic("failed to generate new OCI manifest")
+	}
+
+	return newManifest
+}
+
+// NewTestIndex creates a test OCI image index for our mock Docker API. Its
+// linux/amd64 image runs as nobody, its linux/arm64 image runs as root, and it
+// has an attestation manifest that isn't for any platform.
+func NewTestIndex() *manifestlist.DeserializedManifestList {
+	index, err := manifestlist.FromDescriptorsWithMediaType([]manifestlist.ManifestDescriptor{
+		{
+			Descriptor: distribution.Descriptor{
+				MediaType: schema2.MediaTypeManifest,
+				Size:      948,
+				Digest:    "sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9",
+			},
+			Platform: manifestlist.PlatformSpec{OS: "linux", Architecture: "amd64"},
+		},
+		{
+			Descriptor: distribution.Descriptor{
+				MediaType: schema2.MediaTypeManifest,
+				Size:      948,
+				Digest:    "sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2",
+			},
+			Platform: manifestlist.PlatformSpec{OS: "linux", Architecture: "arm64", Variant: "v8"},
+		},
+		{
+			Descriptor: distribution.Descriptor{
+				MediaType: v1.MediaTypeImageManifest,
+				Size:      566,
+				Digest:    "sha256:c348c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da",
+			},
+			Platform: manifestlist.PlatformSpec{OS: "unknown", Architecture: "unknown"},
+		},
+	}, v1.MediaTypeImageIndex)
+	if nil != err {
+		panic("failed to generate new image index")
+	}
+
+	return index
+}
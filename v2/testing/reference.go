@@ -13,7 +13,7 @@ import (
 func NewTestReference(t *testing.T) reference.Canonical {
 	t.Helper()
 
-	return parseReference(t, "localhost/path/to/image@sha256:b148c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da")
+	return parseReference(t, "localhost/path/to/image@sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9")
 }
 
 // NewBadTestReference creates a new reference to be used throughout the docker tests.
@@ -31,7 +31,43 @@ func NewBadTestReference(t *testing.T) reference.Canonical {
 func NewNobodyBadTestReference(t *testing.T) reference.Canonical {
 	t.Helper()
 
-	return parseReference(t, "localhost/path/to/image@sha256:b248c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da")
+	return parseReference(t, "localhost/path/to/image@sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2")
+}
+
+// NewTestIndexReference creates a new reference to an image index to be used
+// throughout the docker tests. The index has images for linux/amd64, which
+// runs as nobody, and linux/arm64/v8, which runs as root.
+func NewTestIndexReference(t *testing.T) reference.Canonical {
+	t.Helper()
+
+	return parseReference(t, "localhost/path/to/image@sha256:0305f9da9df288289d7ddf5952af8fb009dc6712a07533df24ddb2c2e785c217")
+}
+
+// NewTestOCIReference creates a new reference to an OCI image manifest to be
+// used throughout the docker tests.
+func NewTestOCIReference(t *testing.T) reference.Canonical {
+	t.Helper()
+
+	return parseReference(t, "localhost/path/to/ociimage@sha256:6906b90dc2faeea25d3d3de1a3727fd6c6d41abdff97d5e498fff3bbd0b7212f")
+}
+
+// NewTamperedManifestTestReference creates a new reference to be used to test
+// the handling of manifests which don't match their digest. The registry
+// returns a different manifest than the one the reference is to.
+func NewTamperedManifestTestReference(t *testing.T) reference.Canonical {
+	t.Helper()
+
+	return parseReference(t, "localhost/path/to/tampered@sha256:5c69aa39cf6b4f6b50438813a262393d619cd499b1957d828a0b1d24a430bae2")
+}
+
+// NewTamperedConfigTestReference creates a new reference to be used to test
+// the handling of image configurations which don't match their digest. The
+// manifest is correct, but the registry returns a different configuration
+// than the one it refers to.
+func NewTamperedConfigTestReference(t *testing.T) reference.Canonical {
+	t.Helper()
+
+	return parseReference(t, "localhost/path/to/tampered@sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9")
 }
 
 // NewRateLimitedTestReference creates a new reference to be used to test
@@ -41,7 +77,7 @@ func NewNobodyBadTestReference(t *testing.T) reference.Canonical {
 func NewRateLimitedTestReference(t *testing.T) reference.Canonical {
 	t.Helper()
 
-	return parseReference(t, "localhost/path/to/ratelimited@sha256:b148c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da")
+	return parseReference(t, "localhost/path/to/ratelimited@sha256:5d8eb1409c3bb185b977dceb517baaa93f68ec07c59bce5fa3d9ccaec3810be9")
 }
 
 // NewTestSchema1Reference creates a new schema version 1 reference to be used
@@ -59,7 +95,7 @@ func NewTestSchema1Reference(t *testing.T) reference.Canonical {
 func NewTestSchema1SignedReference(t *testing.T) reference.Canonical {
 	t.Helper()
 
-	return parseReference(t, "localhost/schema1imagesigned@sha256:18e6e7971438ab792d13563dcd8972acf4445bc0dcfdff84a6374d63a9c3ed62")
+	return parseReference(t, "localhost/schema1imagesigned@sha256:af0ddbf967bc9796ddb1cec96a1c30d626c65842a2c9dc1672ee358cc452f836")
 }
 
 // parseReference parses the passed reference and returns it (or fails)
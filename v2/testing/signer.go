@@ -1,6 +1,7 @@
 package vtesting
 
 import (
+	"crypto"
 	"os"
 	"testing"
 
@@ -8,6 +9,8 @@ import (
 	"github.com/grafeas/voucher/v2/signer/pgp"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/packet"
 )
 
 // NewPGPSigner creates a new signer using the test key in the testdata
@@ -26,3 +29,21 @@ func NewPGPSigner(t *testing.T) signer.AttestationSigner {
 
 	return newKeyRing
 }
+
+// NewGeneratedPGPSigner creates a new signer with a newly generated key for
+// the check with the passed name. It does not need the test key, and the
+// signer created by NewPGPSigner does not trust its signatures.
+func NewGeneratedPGPSigner(t *testing.T, checkName string) signer.AttestationSigner {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("generated", "", "generated@example.com", &packet.Config{
+		DefaultHash: crypto.SHA512,
+		RSABits:     1024,
+	})
+	require.NoError(t, err, "failed to generate key")
+
+	newKeyRing := pgp.NewKeyRing()
+	newKeyRing.AddEntities(checkName, openpgp.EntityList{entity})
+
+	return newKeyRing
+}
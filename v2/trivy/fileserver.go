@@ -0,0 +1,65 @@
+package trivy
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// fileServerSource is a ReportSource which requests precomputed reports
+// from a plain file server, such as one in front of the bucket that CI uploads
+// reports to. It does not scan images itself, and is not a client for
+// "trivy server", which Voucher does not support.
+type fileServerSource struct {
+	address string
+	client  *http.Client
+}
+
+// GetReport requests the report for the passed digest from the file server.
+func (source *fileServerSource) GetReport(ctx context.Context, imageDigest digest.Digest) (Report, error) {
+	request, err := http.NewRequest(http.MethodGet, source.address+"/"+reportName(imageDigest), nil)
+	if nil != err {
+		return Report{}, err
+	}
+
+	resp, err := source.client.Do(request.WithContext(ctx))
+	if nil != err {
+		return Report{}, err
+	}
+	defer resp.Body.Close()
+
+	if http.StatusNotFound == resp.StatusCode {
+		return Report{}, ErrNoReport
+	}
+
+	if 300 <= resp.StatusCode {
+		return Report{}, fmt.Errorf("getting trivy report failed: %s", resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if nil != err {
+		return Report{}, err
+	}
+
+	return ParseReport(data)
+}
+
+// NewFileServerSource creates a ReportSource which requests reports from
+// the file server at the passed address, using the same names as
+// NewDirectorySource, such as "<address>/sha256-<hex>.json". The reports must
+// already have been generated, such as with "trivy image --format json". If
+// the address does not include "http://" or "https://", HTTPS is used.
+func NewFileServerSource(address string, client *http.Client) ReportSource {
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		address = "https://" + address
+	}
+
+	return &fileServerSource{
+		address: strings.TrimSuffix(address, "/"),
+		client:  client,
+	}
+}
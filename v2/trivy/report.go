@@ -0,0 +1,64 @@
+package trivy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Report is a Trivy JSON report, as written by `trivy image --format json`.
+type Report struct {
+	ArtifactName string   `json:"ArtifactName,omitempty"`
+	Results      []Result `json:"Results"`
+}
+
+// Result is the result of scanning one target (such as the OS packages or a
+// language's lock file) in an image.
+type Result struct {
+	Target          string          `json:"Target"`
+	Type            string          `json:"Type,omitempty"`
+	Vulnerabilities []Vulnerability `json:"Vulnerabilities"`
+}
+
+// Vulnerability is a vulnerability that Trivy detected in a package.
+type Vulnerability struct {
+	VulnerabilityID  string          `json:"VulnerabilityID"`
+	PkgName          string          `json:"PkgName"`
+	InstalledVersion string          `json:"InstalledVersion"`
+	FixedVersion     string          `json:"FixedVersion,omitempty"`
+	Title            string          `json:"Title,omitempty"`
+	Description      string          `json:"Description,omitempty"`
+	Severity         string          `json:"Severity"`
+	PrimaryURL       string          `json:"PrimaryURL,omitempty"`
+	References       []string        `json:"References,omitempty"`
+	CVSS             map[string]CVSS `json:"CVSS,omitempty"`
+}
+
+// CVSS is a CVSS score and vector from one source, such as "nvd".
+type CVSS struct {
+	V2Vector string  `json:"V2Vector,omitempty"`
+	V3Vector string  `json:"V3Vector,omitempty"`
+	V2Score  float32 `json:"V2Score,omitempty"`
+	V3Score  float32 `json:"V3Score,omitempty"`
+}
+
+// ParseReport parses a Trivy JSON report. Both the current report format,
+// which is an object with a list of Results, and the format used by older
+// versions of Trivy, which is just the list of Results, are supported.
+func ParseReport(data []byte) (Report, error) {
+	var report Report
+
+	data = bytes.TrimSpace(data)
+	if 0 < len(data) && '[' == data[0] {
+		err := json.Unmarshal(data, &report.Results)
+		if nil != err {
+			return report, fmt.Errorf("could not parse trivy report: %s", err)
+		}
+		return report, nil
+	}
+
+	if err := json.Unmarshal(data, &report); nil != err {
+		return report, fmt.Errorf("could not parse trivy report: %s", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,68 @@
+package trivy
+
+import (
+	"context"
+	"time"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// Scanner implements voucher.VulnerabilityScanner, and reads vulnerabilities
+// from the Trivy reports for images.
+type Scanner struct {
+	source     ReportSource
+	failOn     voucher.Severity
+	failOnCVSS float32
+	allowlist  voucher.Allowlist
+}
+
+// FailOn sets severity level that a vulnerability must match or exheed to
+// prompt a failure.
+func (scanner *Scanner) FailOn(severity voucher.Severity) {
+	scanner.failOn = severity
+}
+
+// FailOnCVSS sets the CVSS score that a vulnerability must match or exceed to
+// prompt a failure.
+func (scanner *Scanner) FailOnCVSS(score float32) {
+	scanner.failOnCVSS = score
+}
+
+// SetAllowlist sets the Allowlist to suppress vulnerabilities with.
+func (scanner *Scanner) SetAllowlist(allowlist voucher.Allowlist) {
+	scanner.allowlist = allowlist
+}
+
+// Scan gets the Trivy report for the passed image, and returns its
+// vulnerabilities. If there is no report, it returns a NoMetadataError.
+func (scanner *Scanner) Scan(ctx context.Context, i voucher.ImageData) ([]voucher.Vulnerability, error) {
+	vulns := make([]voucher.Vulnerability, 0)
+
+	report, err := scanner.source.GetReport(ctx, i.Digest())
+	if ErrNoReport == err {
+		return vulns, &voucher.NoMetadataError{Type: voucher.VulnerabilityType, Err: err}
+	}
+	if nil != err {
+		return vulns, err
+	}
+
+	for _, result := range report.Results {
+		for _, trivyVuln := range result.Vulnerabilities {
+			vuln := toVoucherVulnerability(trivyVuln)
+			if voucher.ShouldIncludeVulnerabilityWithCVSS(vuln, scanner.failOn, scanner.failOnCVSS) {
+				vulns = append(vulns, vuln)
+			}
+		}
+	}
+
+	return scanner.allowlist.Suppress(vulns, i, time.Now()), nil
+}
+
+// NewScanner creates a new Scanner, which gets reports from the passed
+// ReportSource.
+func NewScanner(source ReportSource) *Scanner {
+	scanner := new(Scanner)
+	scanner.source = source
+
+	return scanner
+}
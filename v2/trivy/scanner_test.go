@@ -0,0 +1,158 @@
+package trivy
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+const testImage = "gcr.io/path/to/image@sha256:97db2bc359ccc94d3b2d6f5daa4173e9e91c513b0dcd961408adbb95ec5e5ce5"
+
+const testReportName = "sha256-97db2bc359ccc94d3b2d6f5daa4173e9e91c513b0dcd961408adbb95ec5e5ce5.json"
+
+const testReport = `{
+	"SchemaVersion": 2,
+	"ArtifactName": "gcr.io/path/to/image",
+	"Results": [
+		{
+			"Target": "gcr.io/path/to/image (debian 10.4)",
+			"Type": "debian",
+			"Vulnerabilities": [
+				{
+					"VulnerabilityID": "CVE-2020-1967",
+					"PkgName": "libssl1.1",
+					"InstalledVersion": "1.1.1d-0+deb10u2",
+					"FixedVersion": "1.1.1d-0+deb10u3",
+					"Title": "openssl: Segmentation fault in SSL_check_chain",
+					"Severity": "HIGH",
+					"PrimaryURL": "https://avd.aquasec.com/nvd/cve-2020-1967",
+					"References": [
+						"https://avd.aquasec.com/nvd/cve-2020-1967",
+						"https://www.openssl.org/news/secadv/20200421.txt"
+					],
+					"CVSS": {
+						"nvd": {
+							"V2Vector": "AV:N/AC:M/Au:N/C:N/I:N/A:P",
+							"V3Vector": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H",
+							"V2Score": 4.3,
+							"V3Score": 7.5
+						}
+					}
+				},
+				{
+					"VulnerabilityID": "CVE-2019-18276",
+					"PkgName": "bash",
+					"InstalledVersion": "5.0-4",
+					"Severity": "LOW"
+				}
+			]
+		}
+	]
+}`
+
+const testLegacyReport = `[
+	{
+		"Target": "gcr.io/path/to/image (alpine 3.11.5)",
+		"Vulnerabilities": [
+			{
+				"VulnerabilityID": "CVE-2020-1967",
+				"PkgName": "libssl1.1",
+				"InstalledVersion": "1.1.1d-r3",
+				"FixedVersion": "1.1.1g-r0",
+				"Severity": "CRITICAL",
+				"CVSS": {
+					"redhat": {"V2Score": 5.0},
+					"ghsa": {"V3Score": 9.8, "V3Vector": "CVSS:3.1/AV:N"}
+				}
+			}
+		]
+	}
+]`
+
+func newTestImageData(t *testing.T) voucher.ImageData {
+	t.Helper()
+
+	i, err := voucher.NewImageData(testImage)
+	require.NoError(t, err)
+	return i
+}
+
+func TestScannerWithDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "voucher-trivy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	scanner := NewScanner(NewDirectorySource(dir))
+	scanner.FailOn(voucher.LowSeverity)
+
+	_, err = scanner.Scan(context.Background(), newTestImageData(t))
+	assert.True(t, voucher.IsNoMetadataError(err), "missing report was not a NoMetadataError: %s", err)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, testReportName), []byte(testReport), 0600))
+
+	vulns, err := scanner.Scan(context.Background(), newTestImageData(t))
+	require.NoError(t, err)
+	assert.Equal(t, []voucher.Vulnerability{
+		{
+			Name:           "CVE-2020-1967",
+			Description:    "openssl: Segmentation fault in SSL_check_chain",
+			Severity:       voucher.HighSeverity,
+			FixedBy:        "1.1.1d-0+deb10u3",
+			CVSSScore:      7.5,
+			CVSSVector:     "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H",
+			PackageName:    "libssl1.1",
+			PackageVersion: "1.1.1d-0+deb10u2",
+			URLs:           []string{"https://avd.aquasec.com/nvd/cve-2020-1967", "https://www.openssl.org/news/secadv/20200421.txt"},
+		},
+		{
+			Name:           "CVE-2019-18276",
+			Severity:       voucher.LowSeverity,
+			PackageName:    "bash",
+			PackageVersion: "5.0-4",
+		},
+	}, vulns)
+
+	scanner.FailOn(voucher.HighSeverity)
+	vulns, err = scanner.Scan(context.Background(), newTestImageData(t))
+	require.NoError(t, err)
+	assert.Len(t, vulns, 1)
+}
+
+func TestScannerWithFileServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if "/reports/"+testReportName != r.URL.Path {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testLegacyReport))
+	}))
+	defer server.Close()
+
+	scanner := NewScanner(NewFileServerSource(server.URL+"/reports/", server.Client()))
+	vulns, err := scanner.Scan(context.Background(), newTestImageData(t))
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	assert.Equal(t, voucher.CriticalSeverity, vulns[0].Severity)
+	assert.Equal(t, float32(9.8), vulns[0].CVSSScore)
+
+	scanner = NewScanner(NewFileServerSource(server.URL, server.Client()))
+	_, err = scanner.Scan(context.Background(), newTestImageData(t))
+	assert.True(t, voucher.IsNoMetadataError(err), "missing report was not a NoMetadataError: %s", err)
+}
+
+func TestGetSeverity(t *testing.T) {
+	assert.Equal(t, voucher.LowSeverity, getSeverity("LOW"))
+	assert.Equal(t, voucher.MediumSeverity, getSeverity("MEDIUM"))
+	assert.Equal(t, voucher.HighSeverity, getSeverity("HIGH"))
+	assert.Equal(t, voucher.CriticalSeverity, getSeverity("critical"))
+	assert.Equal(t, voucher.UnknownSeverity, getSeverity("UNKNOWN"))
+}
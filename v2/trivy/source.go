@@ -0,0 +1,52 @@
+package trivy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// ErrNoReport is returned when there is no Trivy report for an image.
+var ErrNoReport = errors.New("no trivy report for image")
+
+// ReportSource gets the Trivy report for an image digest.
+type ReportSource interface {
+	GetReport(ctx context.Context, imageDigest digest.Digest) (Report, error)
+}
+
+// reportName returns the name of the report for the passed digest, which is
+// the digest with its algorithm and hex separated by a dash rather than a
+// colon, such as "sha256-<hex>.json".
+func reportName(imageDigest digest.Digest) string {
+	return fmt.Sprintf("%s-%s.json", imageDigest.Algorithm(), imageDigest.Hex())
+}
+
+// directorySource is a ReportSource which reads reports from a directory.
+type directorySource struct {
+	dir string
+}
+
+// GetReport reads the report for the passed digest from the directory.
+func (source *directorySource) GetReport(ctx context.Context, imageDigest digest.Digest) (Report, error) {
+	data, err := ioutil.ReadFile(filepath.Join(source.dir, reportName(imageDigest)))
+	if os.IsNotExist(err) {
+		return Report{}, ErrNoReport
+	}
+	if nil != err {
+		return Report{}, err
+	}
+
+	return ParseReport(data)
+}
+
+// NewDirectorySource creates a ReportSource which reads reports from the
+// passed directory. Reports are named after the image digest, such as
+// "sha256-<hex>.json".
+func NewDirectorySource(dir string) ReportSource {
+	return &directorySource{dir: dir}
+}
@@ -0,0 +1,89 @@
+package trivy
+
+import (
+	"sort"
+	"strings"
+
+	voucher "github.com/grafeas/voucher/v2"
+)
+
+// cvssSources are the CVSS sources to prefer, in order. Other sources are
+// used if none of these have a score.
+var cvssSources = []string{"nvd", "redhat"}
+
+// getSeverity converts a Trivy severity into a Voucher severity.
+func getSeverity(severity string) voucher.Severity {
+	switch strings.ToUpper(severity) {
+	case "LOW":
+		return voucher.LowSeverity
+	case "MEDIUM":
+		return voucher.MediumSeverity
+	case "HIGH":
+		return voucher.HighSeverity
+	case "CRITICAL":
+		return voucher.CriticalSeverity
+	}
+	return voucher.UnknownSeverity
+}
+
+// getCVSS returns the CVSS score and vector of the passed vulnerability,
+// preferring CVSS v3 to v2, and the sources in cvssSources to others.
+func getCVSS(vuln Vulnerability) (float32, string) {
+	others := make([]string, 0, len(vuln.CVSS))
+	for source := range vuln.CVSS {
+		others = append(others, source)
+	}
+	sort.Strings(others)
+
+	sources := append(append([]string{}, cvssSources...), others...)
+
+	for _, source := range sources {
+		if cvss, ok := vuln.CVSS[source]; ok && 0 < cvss.V3Score {
+			return cvss.V3Score, cvss.V3Vector
+		}
+	}
+
+	for _, source := range sources {
+		if cvss, ok := vuln.CVSS[source]; ok && 0 < cvss.V2Score {
+			return cvss.V2Score, cvss.V2Vector
+		}
+	}
+
+	return 0, ""
+}
+
+// toVoucherVulnerability converts a Trivy Vulnerability to a Voucher
+// Vulnerability.
+func toVoucherVulnerability(vuln Vulnerability) voucher.Vulnerability {
+	score, vector := getCVSS(vuln)
+
+	description := vuln.Description
+	if "" == description {
+		description = vuln.Title
+	}
+
+	urls := make([]string, 0, len(vuln.References)+1)
+	if "" != vuln.PrimaryURL {
+		urls = append(urls, vuln.PrimaryURL)
+	}
+	for _, url := range vuln.References {
+		if url != vuln.PrimaryURL {
+			urls = append(urls, url)
+		}
+	}
+	if 0 == len(urls) {
+		urls = nil
+	}
+
+	return voucher.Vulnerability{
+		Name:           vuln.VulnerabilityID,
+		Description:    description,
+		Severity:       getSeverity(vuln.Severity),
+		FixedBy:        vuln.FixedVersion,
+		CVSSScore:      score,
+		CVSSVector:     vector,
+		PackageName:    vuln.PkgName,
+		PackageVersion: vuln.InstalledVersion,
+		URLs:           urls,
+	}
+}
@@ -0,0 +1,276 @@
+package voucher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// VEXStatus is the status of a vulnerability in a VEX statement.
+type VEXStatus string
+
+// VEX statuses, as defined by OpenVEX.
+const (
+	VEXNotAffected        VEXStatus = "not_affected"
+	VEXAffected           VEXStatus = "affected"
+	VEXFixed              VEXStatus = "fixed"
+	VEXUnderInvestigation VEXStatus = "under_investigation"
+)
+
+// Suppresses returns true if vulnerabilities with this status should not
+// cause an image to fail a vulnerability scan.
+func (status VEXStatus) Suppresses() bool {
+	return VEXNotAffected == status || VEXFixed == status
+}
+
+// VEXDocument is an OpenVEX document, which contains statements about whether
+// products are affected by vulnerabilities.
+type VEXDocument struct {
+	ID         string         `json:"@id"`
+	Author     string         `json:"author"`
+	Timestamp  time.Time      `json:"timestamp"`
+	Statements []VEXStatement `json:"statements"`
+}
+
+// VEXStatement is a statement about whether the listed products are affected
+// by a vulnerability.
+type VEXStatement struct {
+	Vulnerability   VEXVulnerability `json:"vulnerability"`
+	Products        []VEXProduct     `json:"products"`
+	Status          VEXStatus        `json:"status"`
+	Justification   string           `json:"justification,omitempty"`
+	ImpactStatement string           `json:"impact_statement,omitempty"`
+	StatusNotes     string           `json:"status_notes,omitempty"`
+	Timestamp       *time.Time       `json:"timestamp,omitempty"`
+}
+
+// VEXVulnerability identifies the vulnerability in a VEXStatement.
+type VEXVulnerability struct {
+	ID      string   `json:"@id,omitempty"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// UnmarshalJSON parses a VEXVulnerability, which may also be the name of
+// the vulnerability on its own, as in earlier versions of OpenVEX.
+func (vuln *VEXVulnerability) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); nil == err {
+		*vuln = VEXVulnerability{Name: name}
+		return nil
+	}
+
+	type vexVulnerability VEXVulnerability
+	return json.Unmarshal(data, (*vexVulnerability)(vuln))
+}
+
+// names returns the names that the vulnerability is known by.
+func (vuln *VEXVulnerability) names() []string {
+	return append([]string{vuln.Name, vuln.ID}, vuln.Aliases...)
+}
+
+// VEXProduct identifies a product in a VEXStatement, such as an image, or
+// the packages in it that a VEXStatement is limited to.
+type VEXProduct struct {
+	ID            string            `json:"@id"`
+	Identifiers   map[string]string `json:"identifiers,omitempty"`
+	Subcomponents []VEXProduct      `json:"subcomponents,omitempty"`
+}
+
+// UnmarshalJSON parses a VEXProduct, which may also be the product's ID on
+// its own, as in earlier versions of OpenVEX.
+func (product *VEXProduct) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); nil == err {
+		*product = VEXProduct{ID: id}
+		return nil
+	}
+
+	type vexProduct VEXProduct
+	return json.Unmarshal(data, (*vexProduct)(product))
+}
+
+// ids returns the identifiers of the product.
+func (product *VEXProduct) ids() []string {
+	ids := []string{product.ID}
+	for _, id := range product.Identifiers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// purlName returns the name of the package in the passed package URL, such as
+// "openssl" for "pkg:deb/debian/openssl@1.1.1d-0+deb10u2". IDs which are not
+// package URLs are returned as is.
+func purlName(id string) string {
+	if !strings.HasPrefix(id, "pkg:") {
+		return id
+	}
+
+	id = strings.SplitN(strings.SplitN(id, "#", 2)[0], "?", 2)[0]
+	id = strings.SplitN(id, "@", 2)[0]
+	id = id[strings.LastIndex(id, "/")+1:]
+
+	if name, err := url.PathUnescape(id); nil == err {
+		return name
+	}
+	return id
+}
+
+// purlQualifier returns the value of the passed qualifier in the passed
+// package URL, or an empty string if it does not have one.
+func purlQualifier(id, qualifier string) string {
+	parts := strings.SplitN(strings.SplitN(id, "#", 2)[0], "?", 2)
+	if 2 != len(parts) {
+		return ""
+	}
+
+	values, err := url.ParseQuery(parts[1])
+	if nil != err {
+		return ""
+	}
+	return values.Get(qualifier)
+}
+
+// matchesImage returns true if the passed ID identifies the passed image. IDs
+// match if they contain the image's digest, such as the image reference or an
+// OCI package URL, or if they name the image's repository without a digest.
+func matchesImage(id string, imageData ImageData) bool {
+	if "" == id {
+		return false
+	}
+
+	if unescaped, err := url.PathUnescape(id); nil == err {
+		id = unescaped
+	}
+
+	if strings.Contains(id, imageData.Digest().String()) {
+		return true
+	}
+
+	if strings.HasPrefix(id, "pkg:oci/") && !strings.Contains(strings.SplitN(id, "?", 2)[0], "@") {
+		return purlQualifier(id, "repository_url") == imageData.Name()
+	}
+
+	return id == imageData.Name()
+}
+
+// appliesToImage returns true if the product is the passed image.
+func (product *VEXProduct) appliesToImage(imageData ImageData) bool {
+	for _, id := range product.ids() {
+		if matchesImage(id, imageData) {
+			return true
+		}
+	}
+	return false
+}
+
+// appliesToPackage returns true if the product's subcomponents include the
+// passed package, or if the product does not list any subcomponents.
+func (product *VEXProduct) appliesToPackage(packageName string) bool {
+	if 0 == len(product.Subcomponents) {
+		return true
+	}
+
+	for i := range product.Subcomponents {
+		for _, id := range product.Subcomponents[i].ids() {
+			if "" != id && purlName(id) == packageName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Applies returns true if the VEXStatement applies to the passed
+// Vulnerability in the passed image.
+func (statement *VEXStatement) Applies(vuln Vulnerability, imageData ImageData) bool {
+	named := false
+	for _, name := range statement.Vulnerability.names() {
+		if "" != name && strings.EqualFold(name, vuln.Name) {
+			named = true
+			break
+		}
+	}
+
+	if !named {
+		return false
+	}
+
+	for i := range statement.Products {
+		if statement.Products[i].appliesToImage(imageData) && statement.Products[i].appliesToPackage(vuln.PackageName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reason returns the reason to report for vulnerabilities the VEXStatement
+// suppresses.
+func (statement *VEXStatement) reason() string {
+	switch {
+	case "" != statement.ImpactStatement:
+		return statement.ImpactStatement
+	case "" != statement.StatusNotes:
+		return statement.StatusNotes
+	}
+	return fmt.Sprintf("VEX status is %s", statement.Status)
+}
+
+// VEX is a set of OpenVEX documents.
+type VEX []VEXDocument
+
+// Apply returns a copy of the passed Vulnerabilities, where each
+// Vulnerability that a VEXStatement applies to has that statement's status
+// and justification. If more than one statement applies, the latest one is
+// used, as in OpenVEX. Vulnerabilities that are not affected, or have been
+// fixed, are marked as suppressed.
+func (vex VEX) Apply(vulns []Vulnerability, imageData ImageData) []Vulnerability {
+	applied := make([]Vulnerability, len(vulns))
+	for i, vuln := range vulns {
+		var latest *VEXStatement
+		var latestTime time.Time
+
+		for j := range vex {
+			for k := range vex[j].Statements {
+				statement := &vex[j].Statements[k]
+				if !statement.Applies(vuln, imageData) {
+					continue
+				}
+
+				statementTime := vex[j].Timestamp
+				if nil != statement.Timestamp {
+					statementTime = *statement.Timestamp
+				}
+
+				if nil == latest || !statementTime.Before(latestTime) {
+					latest, latestTime = statement, statementTime
+				}
+			}
+		}
+
+		if nil != latest {
+			vuln.VEXStatus = latest.Status
+			vuln.VEXJustification = latest.Justification
+			if latest.Status.Suppresses() && !vuln.Suppressed {
+				vuln.Suppressed = true
+				vuln.SuppressedReason = latest.reason()
+			}
+		}
+
+		applied[i] = vuln
+	}
+	return applied
+}
+
+// ParseVEXDocument parses the passed OpenVEX document.
+func ParseVEXDocument(data []byte) (VEXDocument, error) {
+	var document VEXDocument
+	if err := json.Unmarshal(data, &document); nil != err {
+		return document, fmt.Errorf("could not parse VEX document: %s", err)
+	}
+	return document, nil
+}
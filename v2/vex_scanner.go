@@ -0,0 +1,68 @@
+package voucher
+
+import (
+	"context"
+)
+
+// vexScanner is a VulnerabilityScanner which applies VEX statements to the
+// vulnerabilities found by another VulnerabilityScanner.
+type vexScanner struct {
+	scanner    VulnerabilityScanner
+	vex        VEX
+	failOn     Severity
+	failOnCVSS float32
+}
+
+// FailOn sets severity level that a vulnerability must match or exheed to
+// prompt a failure.
+func (s *vexScanner) FailOn(severity Severity) {
+	s.failOn = severity
+}
+
+// FailOnCVSS sets the CVSS score that a vulnerability must match or exceed to
+// prompt a failure.
+func (s *vexScanner) FailOnCVSS(score float32) {
+	s.failOnCVSS = score
+}
+
+// SetAllowlist sets the Allowlist of the wrapped scanner, if it supports one.
+func (s *vexScanner) SetAllowlist(allowlist Allowlist) {
+	if allowlistScanner, ok := s.scanner.(AllowlistScanner); ok {
+		allowlistScanner.SetAllowlist(allowlist)
+	}
+}
+
+// Scan runs the wrapped scanner, applies the VEX statements to the
+// vulnerabilities it found, and then returns those that meet the severity
+// threshold.
+func (s *vexScanner) Scan(ctx context.Context, i ImageData) ([]Vulnerability, error) {
+	vulns, err := s.scanner.Scan(ctx, i)
+	if nil != err {
+		return vulns, err
+	}
+
+	filtered := make([]Vulnerability, 0, len(vulns))
+	for _, vuln := range s.vex.Apply(vulns, i) {
+		if ShouldIncludeVulnerabilityWithCVSS(vuln, s.failOn, s.failOnCVSS) {
+			filtered = append(filtered, vuln)
+		}
+	}
+
+	return filtered, nil
+}
+
+// NewVEXScanner creates a VulnerabilityScanner which applies the passed VEX
+// statements to the vulnerabilities found by the passed scanner, before
+// checking them against the severity threshold. The passed scanner is set to
+// report vulnerabilities of every severity.
+func NewVEXScanner(scanner VulnerabilityScanner, vex VEX) VulnerabilityScanner {
+	scanner.FailOn(NegligibleSeverity)
+	if cvssScanner, ok := scanner.(CVSSScanner); ok {
+		cvssScanner.FailOnCVSS(0)
+	}
+
+	return &vexScanner{
+		scanner: scanner,
+		vex:     vex,
+	}
+}
@@ -0,0 +1,150 @@
+package voucher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testVEXDocument = `{
+	"@context": "https://openvex.dev/ns/v0.2.0",
+	"@id": "https://example.com/vex/image-2020-1234",
+	"author": "Product Security",
+	"timestamp": "2023-01-01T00:00:00Z",
+	"version": 1,
+	"statements": [
+		{
+			"vulnerability": {"name": "CVE-2020-1234", "aliases": ["GHSA-xxxx-yyyy-zzzz"]},
+			"products": [
+				{
+					"@id": "pkg:oci/image@sha256%3Ab148c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da?repository_url=localhost.local/path/to/image",
+					"subcomponents": [{"@id": "pkg:deb/debian/openssl@1.1.1d-0+deb10u2"}]
+				}
+			],
+			"status": "not_affected",
+			"justification": "vulnerable_code_not_in_execute_path",
+			"impact_statement": "TLS is terminated by the load balancer."
+		},
+		{
+			"vulnerability": {"name": "CVE-2020-5678"},
+			"products": [{"@id": "localhost.local/path/to/image"}],
+			"status": "under_investigation"
+		}
+	]
+}`
+
+const testLegacyVEXDocument = `{
+	"@context": "https://openvex.dev/ns",
+	"timestamp": "2023-02-01T00:00:00Z",
+	"statements": [
+		{
+			"vulnerability": "CVE-2020-5678",
+			"products": ["localhost.local/path/to/image@sha256:b148c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da"],
+			"status": "fixed"
+		}
+	]
+}`
+
+type testScanner struct {
+	vulns  []Vulnerability
+	failOn Severity
+}
+
+func (s *testScanner) FailOn(severity Severity) {
+	s.failOn = severity
+}
+
+func (s *testScanner) Scan(ctx context.Context, i ImageData) ([]Vulnerability, error) {
+	vulns := make([]Vulnerability, 0, len(s.vulns))
+	for _, vuln := range s.vulns {
+		if ShouldIncludeVulnerability(vuln, s.failOn) {
+			vulns = append(vulns, vuln)
+		}
+	}
+	return vulns, nil
+}
+
+func newTestVEX(t *testing.T) VEX {
+	t.Helper()
+
+	vex := make(VEX, 0, 2)
+	for _, data := range []string{testVEXDocument, testLegacyVEXDocument} {
+		document, err := ParseVEXDocument([]byte(data))
+		require.NoError(t, err)
+		vex = append(vex, document)
+	}
+	return vex
+}
+
+func TestVEXApply(t *testing.T) {
+	imageData := newTestImageData(t)
+
+	vex := newTestVEX(t)
+	require.Len(t, vex[1].Statements, 1)
+	assert.Equal(t, "CVE-2020-5678", vex[1].Statements[0].Vulnerability.Name)
+
+	vulns := vex.Apply([]Vulnerability{
+		{Name: "CVE-2020-1234", Severity: HighSeverity, PackageName: "openssl"},
+		{Name: "CVE-2020-1234", Severity: HighSeverity, PackageName: "bash"},
+		{Name: "cve-2020-5678", Severity: MediumSeverity},
+		{Name: "CVE-2020-9999", Severity: LowSeverity},
+	}, imageData)
+
+	assert.Equal(t, []Vulnerability{
+		{
+			Name:             "CVE-2020-1234",
+			Severity:         HighSeverity,
+			PackageName:      "openssl",
+			Suppressed:       true,
+			SuppressedReason: "TLS is terminated by the load balancer.",
+			VEXStatus:        VEXNotAffected,
+			VEXJustification: "vulnerable_code_not_in_execute_path",
+		},
+		{Name: "CVE-2020-1234", Severity: HighSeverity, PackageName: "bash"},
+		{
+			Name:             "cve-2020-5678",
+			Severity:         MediumSeverity,
+			Suppressed:       true,
+			SuppressedReason: "VEX status is fixed",
+			VEXStatus:        VEXFixed,
+		},
+		{Name: "CVE-2020-9999", Severity: LowSeverity},
+	}, vulns)
+
+	otherImage, err := NewImageData("localhost.local/path/to/other@sha256:b248c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da")
+	require.NoError(t, err)
+
+	vulns = vex.Apply([]Vulnerability{{Name: "CVE-2020-1234", PackageName: "openssl"}}, otherImage)
+	assert.False(t, vulns[0].Suppressed, "VEX statement applied to another image")
+}
+
+func TestVEXApplyUsesLatestStatement(t *testing.T) {
+	imageData := newTestImageData(t)
+
+	vex := newTestVEX(t)
+	vex[1].Timestamp = vex[0].Timestamp.Add(-1)
+
+	vulns := vex.Apply([]Vulnerability{{Name: "CVE-2020-5678"}}, imageData)
+	assert.False(t, vulns[0].Suppressed)
+	assert.Equal(t, VEXUnderInvestigation, vulns[0].VEXStatus)
+}
+
+func TestVEXScanner(t *testing.T) {
+	imageData := newTestImageData(t)
+
+	scanner := NewVEXScanner(&testScanner{vulns: []Vulnerability{
+		{Name: "CVE-2020-1234", Severity: HighSeverity, PackageName: "openssl"},
+		{Name: "CVE-2020-5678", Severity: MediumSeverity},
+		{Name: "CVE-2020-9999", Severity: LowSeverity},
+	}}, newTestVEX(t))
+	scanner.FailOn(MediumSeverity)
+
+	vulns, err := scanner.Scan(context.Background(), imageData)
+	require.NoError(t, err)
+	require.Len(t, vulns, 2)
+	assert.True(t, vulns[0].Suppressed)
+	assert.True(t, vulns[1].Suppressed)
+	assert.Empty(t, UnsuppressedVulnerabilities(vulns))
+}
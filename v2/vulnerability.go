@@ -1,5 +1,7 @@
 package voucher
 
+import "strings"
+
 // Vulnerability is a type that describes a security vulnerability. Third-party scanner vulnerabilities
 // should be converted to this type.
 type Vulnerability struct {
@@ -7,6 +9,59 @@ type Vulnerability struct {
 	Description string   `json:"description"` // Description of the Vulnerability.
 	Severity    Severity `json:"severity"`    // Severity of the Vulnerability.
 	FixedBy     string   `json:"fixed_by"`    // If this vulnerability was fixed, what it was fixed by.
+
+	CVSSScore      float32  `json:"cvss_score,omitempty"`      // CVSS base score of the Vulnerability, if known.
+	CVSSVector     string   `json:"cvss_vector,omitempty"`     // CVSS vector of the Vulnerability, if known.
+	PackageName    string   `json:"package_name,omitempty"`    // Name of the affected package.
+	PackageVersion string   `json:"package_version,omitempty"` // Version of the affected package.
+	URLs           []string `json:"urls,omitempty"`            // URLs with more information about the Vulnerability.
+	Scanners       []string `json:"scanners,omitempty"`        // Names of the scanners that reported the Vulnerability.
+
+	Suppressed       bool   `json:"suppressed,omitempty"`        // If this vulnerability was suppressed by an Allowlist or VEX statement.
+	SuppressedReason string `json:"suppressed_reason,omitempty"` // Why this vulnerability was suppressed.
+
+	VEXStatus        VEXStatus `json:"vex_status,omitempty"`        // Status of the Vulnerability in the VEX statement that applies to it.
+	VEXJustification string    `json:"vex_justification,omitempty"` // Justification of that VEX statement.
+}
+
+// Fixable returns true if a fix is available for the Vulnerability.
+func (v Vulnerability) Fixable() bool {
+	return "" != v.FixedBy
+}
+
+// key identifies the Vulnerability when comparing the vulnerabilities of two
+// images. The same vulnerability in a different package is a different key.
+func (v Vulnerability) key() string {
+	return strings.ToUpper(v.Name) + "\x00" + v.PackageName
+}
+
+// DiffVulnerabilities compares the vulnerabilities of an image with those of
+// an earlier image. It returns the vulnerabilities that were added since the
+// earlier image, and those that were removed.
+func DiffVulnerabilities(current, previous []Vulnerability) ([]Vulnerability, []Vulnerability) {
+	previousKeys := make(map[string]bool, len(previous))
+	for _, vuln := range previous {
+		previousKeys[vuln.key()] = true
+	}
+
+	currentKeys := make(map[string]bool, len(current))
+	added := make([]Vulnerability, 0)
+	for _, vuln := range current {
+		currentKeys[vuln.key()] = true
+		if !previousKeys[vuln.key()] {
+			added = append(added, vuln)
+		}
+	}
+
+	removed := make([]Vulnerability, 0)
+	for _, vuln := range previous {
+		if !currentKeys[vuln.key()] {
+			removed = append(removed, vuln)
+			currentKeys[vuln.key()] = true
+		}
+	}
+
+	return added, removed
 }
 
 // ShouldIncludeVulnerability returns true if the passed vulnerability should be included
@@ -14,3 +69,14 @@ type Vulnerability struct {
 func ShouldIncludeVulnerability(test Vulnerability, baseline Severity) bool {
 	return (test.Severity >= baseline)
 }
+
+// ShouldIncludeVulnerabilityWithCVSS returns true if the passed vulnerability should
+// be included in our vulnerability report. If the cutoff is greater than zero,
+// vulnerabilities with a CVSS score are included if their score is at or above the
+// cutoff. Otherwise, the vulnerability is compared against the baseline Severity.
+func ShouldIncludeVulnerabilityWithCVSS(test Vulnerability, baseline Severity, cutoff float32) bool {
+	if 0 < cutoff && 0 < test.CVSSScore {
+		return (test.CVSSScore >= cutoff)
+	}
+	return ShouldIncludeVulnerability(test, baseline)
+}
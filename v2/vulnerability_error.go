@@ -3,15 +3,56 @@ package voucher
 import "fmt"
 
 // VulnerabilitiesError is an error that also contains a list of vulnerabilities.
+// Warnings are vulnerabilities which were reported, but which did not cause the
+// image to fail, such as those without a fix when only failing on fixable
+// vulnerabilities. Budgets describe the number of Vulnerabilities of each
+// Severity against the image's VulnerabilityBudget, if it has one. If the
+// image was compared with an earlier image, Baseline is that image, and
+// Removed lists the vulnerabilities it had that this image does not.
 type VulnerabilitiesError struct {
 	Vulnerabilities []Vulnerability
+	Warnings        []Vulnerability
+	Budgets         []BudgetUsage
+	Baseline        string
+	Removed         []Vulnerability
 }
 
-// Error returns the error message for the VulnerabilitiesError
+// Error returns the error message for the VulnerabilitiesError. Suppressed
+// vulnerabilities are not included in the message.
 func (err VulnerabilitiesError) Error() string {
-	output := fmt.Sprintf("vulnernable to %d vulnerabilities: ", len(err.Vulnerabilities))
+	unsuppressed := UnsuppressedVulnerabilities(err.Vulnerabilities)
+	warnings := UnsuppressedVulnerabilities(err.Warnings)
 
-	for i, vulnerability := range err.Vulnerabilities {
+	if 0 == len(unsuppressed) && 0 != len(warnings) {
+		return fmt.Sprintf("%d vulnerabilities have no fix available: %s", len(warnings), listVulnerabilities(warnings))
+	}
+
+	var output string
+	if 0 == len(unsuppressed) {
+		output = "no unsuppressed vulnerabilities"
+		if suppressed := len(err.Vulnerabilities) + len(err.Warnings); 0 != suppressed {
+			output += fmt.Sprintf(" (%d suppressed)", suppressed)
+		}
+	} else {
+		output = fmt.Sprintf("vulnernable to %d vulnerabilities: %s", len(unsuppressed), listVulnerabilities(unsuppressed))
+		if 0 != len(warnings) {
+			output += fmt.Sprintf(" (%d more have no fix available)", len(warnings))
+		}
+	}
+	if 0 != len(err.Budgets) {
+		output += "; " + describeBudgets(err.Budgets)
+	}
+	if "" != err.Baseline {
+		output += fmt.Sprintf("; compared with %s, %d were removed", err.Baseline, len(err.Removed))
+	}
+	return output
+}
+
+// listVulnerabilities returns the names and severities of the passed
+// vulnerabilities, separated by commas.
+func listVulnerabilities(vulns []Vulnerability) string {
+	output := ""
+	for i, vulnerability := range vulns {
 		if i != 0 {
 			output += ", "
 		}
@@ -20,6 +61,32 @@ func (err VulnerabilitiesError) Error() string {
 	return output
 }
 
+// ErrorCode returns ErrorCodeVulnerable.
+func (err VulnerabilitiesError) ErrorCode() ErrorCode {
+	return ErrorCodeVulnerable
+}
+
+// ErrorDetails returns the Vulnerabilities, so they can be reported as a
+// structured list rather than as part of the error message.
+func (err VulnerabilitiesError) ErrorDetails() interface{} {
+	return VulnerabilitiesDetails{
+		Vulnerabilities: err.Vulnerabilities,
+		Warnings:        err.Warnings,
+		Budgets:         err.Budgets,
+		Baseline:        err.Baseline,
+		Removed:         err.Removed,
+	}
+}
+
+// VulnerabilitiesDetails are the details of a VulnerabilitiesError.
+type VulnerabilitiesDetails struct {
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+	Warnings        []Vulnerability `json:"warnings,omitempty"`
+	Budgets         []BudgetUsage   `json:"budgets,omitempty"`
+	Baseline        string          `json:"baseline,omitempty"`
+	Removed         []Vulnerability `json:"removed,omitempty"`
+}
+
 // NewVulnerabilityError creates a new VulnerabilityError with the passed
 // Vulnerabilities.
 func NewVulnerabilityError(vuls []Vulnerability) (err error) {
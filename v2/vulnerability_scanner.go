@@ -16,3 +16,24 @@ type VulnerabilityScanner interface {
 	// Vulnerabilities.
 	Scan(context.Context, ImageData) ([]Vulnerability, error)
 }
+
+// CVSSScanner is a VulnerabilityScanner which can fail on a CVSS score rather
+// than a Severity.
+type CVSSScanner interface {
+	VulnerabilityScanner
+
+	// FailOnCVSS sets the minimum CVSS score to consider an image vulnerable.
+	// Vulnerabilities without a CVSS score are compared against the Severity
+	// passed to FailOn.
+	FailOnCVSS(float32)
+}
+
+// AllowlistScanner is a VulnerabilityScanner which can suppress the
+// vulnerabilities in an Allowlist. Suppressed vulnerabilities are still
+// returned by Scan, but are marked as suppressed.
+type AllowlistScanner interface {
+	VulnerabilityScanner
+
+	// SetAllowlist sets the Allowlist to suppress vulnerabilities with.
+	SetAllowlist(Allowlist)
+}
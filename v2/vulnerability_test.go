@@ -33,6 +33,17 @@ func TestVulnerabilityError(t *testing.T) {
 	assert.Equal(t, err.Error(), expected)
 }
 
+func TestShouldIncludeVulnerabilityWithCVSS(t *testing.T) {
+	scored := Vulnerability{Name: "Scored", Severity: HighSeverity, CVSSScore: 7.5}
+	unscored := Vulnerability{Name: "Unscored", Severity: HighSeverity}
+
+	assert.True(t, ShouldIncludeVulnerabilityWithCVSS(scored, CriticalSeverity, 7.5))
+	assert.False(t, ShouldIncludeVulnerabilityWithCVSS(scored, LowSeverity, 8.0))
+	assert.False(t, ShouldIncludeVulnerabilityWithCVSS(scored, CriticalSeverity, 0))
+	assert.True(t, ShouldIncludeVulnerabilityWithCVSS(unscored, HighSeverity, 9.0))
+	assert.False(t, ShouldIncludeVulnerabilityWithCVSS(unscored, CriticalSeverity, 5.0))
+}
+
 func TestShouldIncludeVulnerability(t *testing.T) {
 	vulns := makeTestVulns()
 
@@ -82,3 +93,39 @@ func TestShouldIncludeVulnerability(t *testing.T) {
 		)
 	}
 }
+
+func TestDiffVulnerabilities(t *testing.T) {
+	kept := Vulnerability{Name: "CVE-2020-0001", PackageName: "openssl", Severity: HighSeverity}
+	fixed := Vulnerability{Name: "CVE-2020-0002", PackageName: "openssl", Severity: MediumSeverity}
+	added := Vulnerability{Name: "CVE-2020-0003", PackageName: "zlib", Severity: CriticalSeverity}
+	moved := Vulnerability{Name: "CVE-2020-0001", PackageName: "libssl", Severity: HighSeverity}
+
+	current := []Vulnerability{
+		{Name: "cve-2020-0001", PackageName: "openssl", Severity: HighSeverity},
+		added,
+		moved,
+	}
+
+	newVulns, removed := DiffVulnerabilities(current, []Vulnerability{kept, fixed, fixed})
+	assert.Equal(t, []Vulnerability{added, moved}, newVulns)
+	assert.Equal(t, []Vulnerability{fixed}, removed)
+
+	newVulns, removed = DiffVulnerabilities(current, current)
+	assert.Empty(t, newVulns)
+	assert.Empty(t, removed)
+}
+
+func TestVulnerabilityErrorWithBaseline(t *testing.T) {
+	err := VulnerabilitiesError{
+		Vulnerabilities: makeTestVulns()[:1],
+		Baseline:        "gcr.io/path/to/image@sha256:b248c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da",
+		Removed:         makeTestVulns()[1:],
+	}
+
+	assert.Equal(t, "vulnernable to 1 vulnerabilities: Bad One (high); compared with gcr.io/path/to/image@sha256:b248c8af52ba402ed7dd98d73f5a41836ece508d1f4704b274562ac0c9b3b7da, 2 were removed", err.Error())
+	assert.Equal(t, VulnerabilitiesDetails{
+		Vulnerabilities: makeTestVulns()[:1],
+		Baseline:        err.Baseline,
+		Removed:         makeTestVulns()[1:],
+	}, err.ErrorDetails())
+}
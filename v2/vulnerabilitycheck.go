@@ -5,3 +5,42 @@ type VulnerabilityCheck interface {
 	Check
 	SetScanner(VulnerabilityScanner)
 }
+
+// FixableVulnerabilityCheck is a VulnerabilityCheck which can be limited to
+// failing on vulnerabilities that have a fix available.
+type FixableVulnerabilityCheck interface {
+	VulnerabilityCheck
+	SetFixableOnly(bool)
+}
+
+// BudgetedVulnerabilityCheck is a VulnerabilityCheck which only fails when an
+// image has more vulnerabilities of a Severity than its budget allows.
+type BudgetedVulnerabilityCheck interface {
+	VulnerabilityCheck
+	SetBudget(VulnerabilityBudget)
+}
+
+// NewVulnerabilityCheck is a VulnerabilityCheck which can be limited to
+// failing on vulnerabilities that were not in the previous image from the
+// same repository to pass it.
+type NewVulnerabilityCheck interface {
+	VulnerabilityCheck
+	SetNewOnly(bool)
+}
+
+// ExploitCheck is a VulnerabilityCheck which fails on vulnerabilities that
+// are known to be exploited, or are likely to be exploited.
+type ExploitCheck interface {
+	VulnerabilityCheck
+	SetExploitSource(ExploitSource)
+	SetEPSSThreshold(float32)
+}
+
+// PolicyCheck is a VulnerabilityCheck which evaluates a user-supplied policy.
+// Policies decide for themselves which vulnerabilities matter, so they are
+// passed every vulnerability in an image, rather than only those at or above
+// failon.
+type PolicyCheck interface {
+	VulnerabilityCheck
+	PolicyName() string
+}
@@ -0,0 +1,83 @@
+package voucher
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Waiver allows a Check to fail for a set of images until it expires. It
+// applies to images in a repository or any repository beneath it, to an image
+// digest, or to both if both are set.
+type Waiver struct {
+	Repository    string    `json:"repository,omitempty"`
+	Digest        string    `json:"digest,omitempty"`
+	Check         string    `json:"check"`
+	Expires       time.Time `json:"expires"`
+	Justification string    `json:"justification"`
+	Owner         string    `json:"owner"`
+	Attest        bool      `json:"attest"` // If true, waived Checks are attested as though they passed.
+}
+
+// Validate returns an error if the Waiver is missing any of its required
+// fields.
+func (waiver *Waiver) Validate() error {
+	switch {
+	case "" == waiver.Repository && "" == waiver.Digest:
+		return errors.New("waiver must have a repository or a digest")
+	case "" == waiver.Check:
+		return errors.New("waiver must have a check")
+	case waiver.Expires.IsZero():
+		return errors.New("waiver must have an expiry time")
+	case "" == waiver.Justification:
+		return errors.New("waiver must have a justification")
+	case "" == waiver.Owner:
+		return errors.New("waiver must have an owner")
+	}
+	return nil
+}
+
+// Applies returns true if the Waiver applies to the named Check for the
+// passed image at the passed time. Waivers stop applying once they expire.
+func (waiver *Waiver) Applies(check string, imageData ImageData, now time.Time) bool {
+	if waiver.Check != check || !now.Before(waiver.Expires) {
+		return false
+	}
+
+	if "" != waiver.Repository && !inRepository(imageData.Name(), waiver.Repository) {
+		return false
+	}
+
+	if "" != waiver.Digest && waiver.Digest != imageData.Digest().String() {
+		return false
+	}
+
+	return true
+}
+
+// inRepository returns true if the image with the passed name is in the
+// passed repository, or in a repository beneath it. Repositories are matched
+// by whole path components, so "gcr.io/project/app" does not contain
+// "gcr.io/project/app-internal".
+func inRepository(name string, repository string) bool {
+	repository = strings.TrimSuffix(repository, "/")
+	return name == repository || strings.HasPrefix(name, repository+"/")
+}
+
+// waive marks the passed result as successful, if it failed and one of the
+// Suite's Waivers applies to it. The original error is kept.
+func (cs *Suite) waive(result *CheckResult, now time.Time) {
+	if result.Success || result.Cancelled || result.Skipped {
+		return
+	}
+
+	for i := range cs.waivers {
+		waiver := cs.waivers[i]
+		if waiver.Applies(result.Name, result.ImageData, now) {
+			result.Success = true
+			result.Waived = true
+			result.Waiver = &waiver
+			return
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package voucher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/grafeas/voucher/v2/metrics"
+)
+
+func newTestWaiver(check string, expires time.Time) Waiver {
+	return Waiver{
+		Repository:    "localhost.local/path/to",
+		Check:         check,
+		Expires:       expires,
+		Justification: "incident 1234",
+		Owner:         "oncall@example.com",
+	}
+}
+
+func TestWaiverApplies(t *testing.T) {
+	imageData := newTestImageData(t)
+	now := time.Now()
+
+	waiver := newTestWaiver("snakeoil", now.Add(time.Hour))
+	assert.True(t, waiver.Applies("snakeoil", imageData, now))
+	assert.False(t, waiver.Applies("diy", imageData, now))
+	assert.False(t, waiver.Applies("snakeoil", imageData, now.Add(time.Hour)), "waiver applied after it expired")
+
+	waiver.Repository = "localhost.local/other"
+	assert.False(t, waiver.Applies("snakeoil", imageData, now))
+
+	waiver.Repository = "localhost.local/path/to/image"
+	assert.True(t, waiver.Applies("snakeoil", imageData, now))
+
+	waiver.Repository = "localhost.local/path/to/"
+	assert.True(t, waiver.Applies("snakeoil", imageData, now))
+
+	waiver.Repository = "localhost.local/path/to/ima"
+	assert.False(t, waiver.Applies("snakeoil", imageData, now), "waiver applied to a sibling repository")
+
+	waiver.Repository = "localhost.local/path/t"
+	assert.False(t, waiver.Applies("snakeoil", imageData, now), "waiver applied to a sibling repository")
+
+	waiver.Repository = ""
+	waiver.Digest = imageData.Digest().String()
+	assert.True(t, waiver.Applies("snakeoil", imageData, now))
+
+	waiver.Digest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	assert.False(t, waiver.Applies("snakeoil", imageData, now))
+}
+
+func TestWaiverValidate(t *testing.T) {
+	waiver := newTestWaiver("snakeoil", time.Now().Add(time.Hour))
+	assert.NoError(t, waiver.Validate())
+
+	for _, invalidate := range []func(*Waiver){
+		func(w *Waiver) { w.Repository = "" },
+		func(w *Waiver) { w.Check = "" },
+		func(w *Waiver) { w.Expires = time.Time{} },
+		func(w *Waiver) { w.Justification = "" },
+		func(w *Waiver) { w.Owner = "" },
+	} {
+		invalid := waiver
+		invalidate(&invalid)
+		assert.Error(t, invalid.Validate())
+	}
+}
+
+func TestSuiteWaivers(t *testing.T) {
+	imageData := newTestImageData(t)
+	now := time.Now()
+
+	metadataClient := new(MockMetadataClient)
+	metadataClient.On("NewPayloadBody", imageData).Return(imageData.String(), nil)
+	metadataClient.On("AddAttestationToImage", mock.Anything, imageData, NewAttestation("approved", imageData.String())).Return(SignedAttestation{}, nil)
+	metadataClient.On("AddAttestationToImage", mock.Anything, imageData, NewAttestation("nobody", imageData.String())).Return(SignedAttestation{}, nil)
+
+	suite := NewSuite()
+	for name, err := range map[string]error{
+		"snakeoil": NewVulnerabilityError(makeTestVulns()),
+		"approved": ErrNoCheck,
+		"diy":      ErrNoCheck,
+	} {
+		check := new(MockCheck)
+		check.On("Check", mock.Anything, imageData).Return(false, err)
+		suite.Add(name, check)
+	}
+
+	nobody := new(MockCheck)
+	nobody.On("Check", mock.Anything, imageData).Return(true, nil)
+	suite.Add("nobody", nobody)
+	suite.SetDependencies("nobody", "snakeoil")
+
+	attestingWaiver := newTestWaiver("approved", now.Add(time.Hour))
+	attestingWaiver.Attest = true
+
+	suite.SetWaivers([]Waiver{
+		newTestWaiver("snakeoil", now.Add(time.Hour)),
+		attestingWaiver,
+		newTestWaiver("diy", now.Add(-time.Hour)),
+	})
+
+	results := suite.RunAndAttest(context.Background(), metadataClient, &metrics.NoopClient{}, imageData)
+
+	byName := make(map[string]CheckResult, len(results))
+	for _, result := range results {
+		byName[result.Name] = result
+	}
+
+	assert.True(t, byName["snakeoil"].Success)
+	assert.True(t, byName["snakeoil"].Waived)
+	assert.Equal(t, ErrorCodeVulnerable, byName["snakeoil"].ErrCode)
+	assert.Equal(t, "incident 1234", byName["snakeoil"].Waiver.Justification)
+	assert.False(t, byName["snakeoil"].Attested, "waived check was attested without its waiver allowing it")
+
+	assert.True(t, byName["approved"].Success)
+	assert.True(t, byName["approved"].Waived)
+	assert.True(t, byName["approved"].Attested)
+
+	assert.False(t, byName["diy"].Success, "expired waiver was applied")
+	assert.False(t, byName["diy"].Waived)
+
+	assert.True(t, byName["nobody"].Success, "check depending on a waived check was not run")
+	assert.True(t, byName["nobody"].Attested)
+
+	metadataClient.AssertNotCalled(t, "AddAttestationToImage", mock.Anything, imageData, NewAttestation("snakeoil", imageData.String()))
+}
@@ -0,0 +1,41 @@
+package voucher
+
+import "errors"
+
+// warningError wraps an error which should be reported, but which should not
+// cause the Check that returned it to fail.
+type warningError struct {
+	err error
+}
+
+// Error returns the error message of the wrapped error.
+func (err *warningError) Error() string {
+	return err.err.Error()
+}
+
+// Warning returns true, marking this error as a warning.
+func (err *warningError) Warning() bool {
+	return true
+}
+
+// Unwrap returns the wrapped error.
+func (err *warningError) Unwrap() error {
+	return err.err
+}
+
+// NewWarning wraps the passed error to mark it as a warning. If a Check passes
+// and returns a warning, its result is still successful, but includes the
+// warning's message, ErrorCode, and details.
+func NewWarning(err error) error {
+	return &warningError{err: err}
+}
+
+// IsWarning returns true if the passed error, or any error it wraps, reports
+// itself as a warning.
+func IsWarning(err error) bool {
+	var warning interface {
+		Warning() bool
+	}
+
+	return errors.As(err, &warning) && warning.Warning()
+}